@@ -0,0 +1,13 @@
+package approvals
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "approvals",
+		Short: "Manage executions pending approval",
+		Long:  "This command helps to list, approve and reject executions of procs requiring approval",
+	}
+}
@@ -0,0 +1,31 @@
+package approve
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"proctor/daemon"
+	"proctor/io"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(printer io.Printer, proctorDClient daemon.Client) *cobra.Command {
+	return &cobra.Command{
+		Use:     "approve",
+		Short:   "Approve an execution pending approval",
+		Long:    "This command helps to approve an execution requested by someone else, submitting it on their behalf",
+		Example: "proctor approvals approve 7b3d9a2c-1f4e-4c6a-9f0b-2d8e5a1c3b7f",
+		Args:    cobra.ExactArgs(1),
+
+		Run: func(cmd *cobra.Command, args []string) {
+			executedProcName, err := proctorDClient.ApproveExecution(args[0])
+			if err != nil {
+				printer.Println(err.Error(), color.FgRed)
+				return
+			}
+
+			printer.Println(fmt.Sprintf("Approved, proc submitted for execution: %s", executedProcName), color.FgGreen)
+			printer.Println(fmt.Sprintf("To stream its logs run: proctor logs %s --follow", executedProcName), color.Reset)
+		},
+	}
+}
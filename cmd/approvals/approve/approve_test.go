@@ -0,0 +1,56 @@
+package approve
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fatih/color"
+	"proctor/daemon"
+	"proctor/io"
+	"proctor/proctord/utility"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ApprovalsApproveCmdTestSuite struct {
+	suite.Suite
+	mockPrinter             *io.MockPrinter
+	mockProctorDClient      *daemon.MockClient
+	testApprovalsApproveCmd *cobra.Command
+}
+
+func (s *ApprovalsApproveCmdTestSuite) SetupTest() {
+	s.mockPrinter = &io.MockPrinter{}
+	s.mockProctorDClient = &daemon.MockClient{}
+	s.testApprovalsApproveCmd = NewCmd(s.mockPrinter, s.mockProctorDClient)
+}
+
+func (s *ApprovalsApproveCmdTestSuite) TestApprovalsApproveCmdHelp() {
+	assert.Equal(s.T(), "Approve an execution pending approval", s.testApprovalsApproveCmd.Short)
+	assert.Equal(s.T(), "proctor approvals approve 7b3d9a2c-1f4e-4c6a-9f0b-2d8e5a1c3b7f", s.testApprovalsApproveCmd.Example)
+}
+
+func (s *ApprovalsApproveCmdTestSuite) TestApprovalsApproveCmdRun() {
+	s.mockProctorDClient.On("ApproveExecution", "approval-id").Return("proctor-ipsum-lorem", nil).Once()
+	s.mockPrinter.On("Println", "Approved, proc submitted for execution: proctor-ipsum-lorem", color.FgGreen).Once()
+	s.mockPrinter.On("Println", "To stream its logs run: proctor logs proctor-ipsum-lorem --follow", color.Reset).Once()
+
+	s.testApprovalsApproveCmd.Run(&cobra.Command{}, []string{"approval-id"})
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *ApprovalsApproveCmdTestSuite) TestApprovalsApproveCmdRunProctorDClientFailure() {
+	s.mockProctorDClient.On("ApproveExecution", "approval-id").Return("", errors.New(utility.SelfApprovalClientError)).Once()
+	s.mockPrinter.On("Println", utility.SelfApprovalClientError, color.FgRed).Once()
+
+	s.testApprovalsApproveCmd.Run(&cobra.Command{}, []string{"approval-id"})
+
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func TestApprovalsApproveCmdTestSuite(t *testing.T) {
+	suite.Run(t, new(ApprovalsApproveCmdTestSuite))
+}
@@ -0,0 +1,37 @@
+package list
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"proctor/daemon"
+	"proctor/io"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(printer io.Printer, proctorDClient daemon.Client) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "List executions pending approval",
+		Long:    "This command helps to list pending approval requests you made or can approve",
+		Example: "proctor approvals list",
+
+		Run: func(cmd *cobra.Command, args []string) {
+			approvals, err := proctorDClient.ListApprovals()
+			if err != nil {
+				printer.Println(err.Error(), color.FgRed)
+				return
+			}
+
+			if len(approvals) == 0 {
+				printer.Println("No executions pending approval", color.FgGreen)
+				return
+			}
+
+			printer.Println(fmt.Sprintf("%-38s %-25s %-30s %-22s %s", "ID", "PROC", "REQUESTED BY", "EXPIRES AT", "REASON"), color.FgGreen)
+			for _, approval := range approvals {
+				printer.Println(fmt.Sprintf("%-38s %-25s %-30s %-22s %s", approval.ID, approval.JobName, approval.UserEmail, approval.ExpiresAt.Format("2006-01-02 15:04 MST"), approval.Reason), color.Reset)
+			}
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package list
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"proctor/daemon"
+	"proctor/io"
+	"proctor/proctord/jobs/execution"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ApprovalsListCmdTestSuite struct {
+	suite.Suite
+	mockPrinter          *io.MockPrinter
+	mockProctorDClient   *daemon.MockClient
+	testApprovalsListCmd *cobra.Command
+}
+
+func (s *ApprovalsListCmdTestSuite) SetupTest() {
+	s.mockPrinter = &io.MockPrinter{}
+	s.mockProctorDClient = &daemon.MockClient{}
+	s.testApprovalsListCmd = NewCmd(s.mockPrinter, s.mockProctorDClient)
+}
+
+func (s *ApprovalsListCmdTestSuite) TestApprovalsListCmdHelp() {
+	assert.Equal(s.T(), "List executions pending approval", s.testApprovalsListCmd.Short)
+	assert.Equal(s.T(), "This command helps to list pending approval requests you made or can approve", s.testApprovalsListCmd.Long)
+	assert.Equal(s.T(), "proctor approvals list", s.testApprovalsListCmd.Example)
+}
+
+func (s *ApprovalsListCmdTestSuite) TestApprovalsListCmdRun() {
+	expiresAt := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	approvals := []execution.Approval{
+		{ID: "approval-one", JobName: "refund", UserEmail: "mrproctor@example.com", Reason: "OPS-123", ExpiresAt: expiresAt},
+		{ID: "approval-two", JobName: "wipe", UserEmail: "someone@example.com", ExpiresAt: expiresAt},
+	}
+
+	s.mockProctorDClient.On("ListApprovals").Return(approvals, nil).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-38s %-25s %-30s %-22s %s", "ID", "PROC", "REQUESTED BY", "EXPIRES AT", "REASON"), color.FgGreen).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-38s %-25s %-30s %-22s %s", "approval-one", "refund", "mrproctor@example.com", "2019-01-01 00:00 UTC", "OPS-123"), color.Reset).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-38s %-25s %-30s %-22s %s", "approval-two", "wipe", "someone@example.com", "2019-01-01 00:00 UTC", ""), color.Reset).Once()
+
+	s.testApprovalsListCmd.Run(&cobra.Command{}, []string{})
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *ApprovalsListCmdTestSuite) TestApprovalsListCmdRunWithNoApprovals() {
+	s.mockProctorDClient.On("ListApprovals").Return([]execution.Approval{}, nil).Once()
+	s.mockPrinter.On("Println", "No executions pending approval", color.FgGreen).Once()
+
+	s.testApprovalsListCmd.Run(&cobra.Command{}, []string{})
+
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *ApprovalsListCmdTestSuite) TestApprovalsListCmdRunProctorDClientFailure() {
+	s.mockProctorDClient.On("ListApprovals").Return([]execution.Approval{}, errors.New("error")).Once()
+	s.mockPrinter.On("Println", "error", color.FgRed).Once()
+
+	s.testApprovalsListCmd.Run(&cobra.Command{}, []string{})
+
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func TestApprovalsListCmdTestSuite(t *testing.T) {
+	suite.Run(t, new(ApprovalsListCmdTestSuite))
+}
@@ -0,0 +1,30 @@
+package reject
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"proctor/daemon"
+	"proctor/io"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(printer io.Printer, proctorDClient daemon.Client) *cobra.Command {
+	return &cobra.Command{
+		Use:     "reject",
+		Short:   "Reject an execution pending approval",
+		Long:    "This command helps to reject an execution requested by someone else, so it is never executed",
+		Example: "proctor approvals reject 7b3d9a2c-1f4e-4c6a-9f0b-2d8e5a1c3b7f",
+		Args:    cobra.ExactArgs(1),
+
+		Run: func(cmd *cobra.Command, args []string) {
+			approvalID := args[0]
+			err := proctorDClient.RejectExecution(approvalID)
+			if err != nil {
+				printer.Println(err.Error(), color.FgRed)
+				return
+			}
+			printer.Println(fmt.Sprintf("Successfully rejected approval request: %s", approvalID), color.FgGreen)
+		},
+	}
+}
@@ -0,0 +1,55 @@
+package reject
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fatih/color"
+	"proctor/daemon"
+	"proctor/io"
+	"proctor/proctord/utility"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ApprovalsRejectCmdTestSuite struct {
+	suite.Suite
+	mockPrinter            *io.MockPrinter
+	mockProctorDClient     *daemon.MockClient
+	testApprovalsRejectCmd *cobra.Command
+}
+
+func (s *ApprovalsRejectCmdTestSuite) SetupTest() {
+	s.mockPrinter = &io.MockPrinter{}
+	s.mockProctorDClient = &daemon.MockClient{}
+	s.testApprovalsRejectCmd = NewCmd(s.mockPrinter, s.mockProctorDClient)
+}
+
+func (s *ApprovalsRejectCmdTestSuite) TestApprovalsRejectCmdHelp() {
+	assert.Equal(s.T(), "Reject an execution pending approval", s.testApprovalsRejectCmd.Short)
+	assert.Equal(s.T(), "proctor approvals reject 7b3d9a2c-1f4e-4c6a-9f0b-2d8e5a1c3b7f", s.testApprovalsRejectCmd.Example)
+}
+
+func (s *ApprovalsRejectCmdTestSuite) TestApprovalsRejectCmdRun() {
+	s.mockProctorDClient.On("RejectExecution", "approval-id").Return(nil).Once()
+	s.mockPrinter.On("Println", "Successfully rejected approval request: approval-id", color.FgGreen).Once()
+
+	s.testApprovalsRejectCmd.Run(&cobra.Command{}, []string{"approval-id"})
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *ApprovalsRejectCmdTestSuite) TestApprovalsRejectCmdRunProctorDClientFailure() {
+	s.mockProctorDClient.On("RejectExecution", "approval-id").Return(errors.New(utility.ApprovalRequestDecidedClientError)).Once()
+	s.mockPrinter.On("Println", utility.ApprovalRequestDecidedClientError, color.FgRed).Once()
+
+	s.testApprovalsRejectCmd.Run(&cobra.Command{}, []string{"approval-id"})
+
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func TestApprovalsRejectCmdTestSuite(t *testing.T) {
+	suite.Run(t, new(ApprovalsRejectCmdTestSuite))
+}
@@ -0,0 +1,259 @@
+package arguments
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	proctor_io "proctor/io"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+const stdinArgsFile = "-"
+
+const fromFileValue = "<from file>"
+
+// Flags are the sources of proc args besides positional KEY=VALUE args
+type Flags struct {
+	ArgsFile     string
+	ArgFromFiles []string
+	// fromFile are the keys of parsed args whose values came from files, which Print leaves out
+	fromFile map[string]bool
+}
+
+func (f *Flags) Register(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&f.ArgsFile, "args-file", "", "Read args from a YAML, JSON or dotenv file, - reads them from stdin")
+	flags.StringArrayVar(&f.ArgFromFiles, "arg-from-file", nil, "Read the value of an arg from a file, as KEY=path. Can be repeated")
+}
+
+// Parse merges args from all sources, each overriding the ones before it: --args-file or stdin,
+// then --arg-from-file, then positional KEY=VALUE args. Malformed positional args are returned apart
+func (f *Flags) Parse(positional []string, stdin io.Reader) (map[string]string, []string, error) {
+	args := make(map[string]string)
+	f.fromFile = make(map[string]bool)
+
+	if f.ArgsFile != "" {
+		fileArgs, err := f.readArgsFile(stdin)
+		if err != nil {
+			return nil, nil, err
+		}
+		for key, value := range fileArgs {
+			args[key] = value
+			f.fromFile[key] = true
+		}
+	}
+
+	for _, argFromFile := range f.ArgFromFiles {
+		keyAndPath := strings.SplitN(argFromFile, "=", 2)
+		if len(keyAndPath) < 2 || keyAndPath[0] == "" {
+			return nil, nil, fmt.Errorf("--arg-from-file must be KEY=path, got %s", argFromFile)
+		}
+
+		value, err := ioutil.ReadFile(keyAndPath[1])
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading value of %s: %v", keyAndPath[0], err)
+		}
+		args[keyAndPath[0]] = string(value)
+		f.fromFile[keyAndPath[0]] = true
+	}
+
+	var malformed []string
+	for _, arg := range positional {
+		keyAndValue := strings.SplitN(arg, "=", 2)
+		if len(keyAndValue) < 2 {
+			malformed = append(malformed, arg)
+			continue
+		}
+		args[keyAndValue[0]] = keyAndValue[1]
+		delete(f.fromFile, keyAndValue[0])
+	}
+
+	return args, malformed, nil
+}
+
+func (f Flags) readArgsFile(stdin io.Reader) (map[string]string, error) {
+	var content []byte
+	var err error
+	if f.ArgsFile == stdinArgsFile {
+		content, err = ioutil.ReadAll(stdin)
+	} else {
+		content, err = ioutil.ReadFile(f.ArgsFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading args file: %v", err)
+	}
+
+	var args map[string]string
+	switch format(f.ArgsFile, content) {
+	case "json":
+		args, err = parseJSON(content)
+	case "yaml":
+		args, err = parseYAML(content)
+	default:
+		args, err = parseDotenv(content)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing args file: %v", err)
+	}
+	return args, nil
+}
+
+// format goes by the file extension, and for stdin or unknown extensions by what the content looks like
+func format(path string, content []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json"
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".env":
+		return "dotenv"
+	}
+
+	trimmed := bytes.TrimSpace(content)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		return "json"
+	}
+	for _, line := range strings.Split(string(trimmed), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		equals, colon := strings.Index(line, "="), strings.Index(line, ":")
+		if equals >= 0 && (colon < 0 || equals < colon) {
+			return "dotenv"
+		}
+		return "yaml"
+	}
+	return "dotenv"
+}
+
+func parseJSON(content []byte) (map[string]string, error) {
+	var values map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	err := decoder.Decode(&values)
+	if err != nil {
+		return nil, err
+	}
+	return stringValues(values)
+}
+
+func parseYAML(content []byte) (map[string]string, error) {
+	var values map[string]interface{}
+	err := yaml.Unmarshal(content, &values)
+	if err != nil {
+		return nil, err
+	}
+	return stringValues(values)
+}
+
+func parseDotenv(content []byte) (map[string]string, error) {
+	args := make(map[string]string)
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		keyAndValue := strings.SplitN(strings.TrimPrefix(line, "export "), "=", 2)
+		if len(keyAndValue) < 2 {
+			return nil, fmt.Errorf("line %d is not KEY=VALUE", i+1)
+		}
+		key, value := strings.TrimSpace(keyAndValue[0]), strings.TrimSpace(keyAndValue[1])
+
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d has an invalid quoted value", i+1)
+			}
+			value = unquoted
+		} else if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+			value = value[1 : len(value)-1]
+		}
+		args[key] = value
+	}
+	return args, nil
+}
+
+// stringValues keeps strings as they are and encodes nested values as JSON, as procs get all args as env vars
+func stringValues(values map[string]interface{}) (map[string]string, error) {
+	args := make(map[string]string)
+	for key, value := range values {
+		switch v := value.(type) {
+		case nil:
+			args[key] = ""
+		case string:
+			args[key] = v
+		case map[string]interface{}, map[interface{}]interface{}, []interface{}:
+			encoded, err := json.Marshal(jsonCompatible(v))
+			if err != nil {
+				return nil, fmt.Errorf("error encoding value of %s: %v", key, err)
+			}
+			args[key] = string(encoded)
+		default:
+			args[key] = fmt.Sprint(v)
+		}
+	}
+	return args, nil
+}
+
+// jsonCompatible converts the map[interface{}]interface{} maps decoded from YAML, which JSON can't encode
+func jsonCompatible(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{})
+		for key, nested := range v {
+			converted[fmt.Sprint(key)] = jsonCompatible(nested)
+		}
+		return converted
+	case map[string]interface{}:
+		converted := make(map[string]interface{})
+		for key, nested := range v {
+			converted[key] = jsonCompatible(nested)
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(v))
+		for i, nested := range v {
+			converted[i] = jsonCompatible(nested)
+		}
+		return converted
+	}
+	return value
+}
+
+// Print echoes args as they will be sent. Values read from files are left out, as those flags
+// keep secret-like and multi-line values out of shell history, and so out of scrollback and CI logs
+func (f *Flags) Print(printer proctor_io.Printer, args map[string]string, malformed []string) {
+	if len(args) == 0 && len(malformed) == 0 {
+		printer.Println("With No Variables", color.FgRed)
+		return
+	}
+
+	printer.Println("With Variables", color.FgMagenta)
+	for _, arg := range malformed {
+		printer.Println(fmt.Sprintf("%-40s %-100s", "\nIncorrect variable format\n", arg), color.FgRed)
+	}
+
+	keys := make([]string, 0, len(args))
+	for key := range args {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := args[key]
+		if f.fromFile[key] {
+			value = fromFileValue
+		}
+		printer.Println(fmt.Sprintf("%-40s %-100s", key, value), color.Reset)
+	}
+}
@@ -0,0 +1,166 @@
+package arguments
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"proctor/io"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	err := ioutil.WriteFile(path, []byte(content), 0600)
+	assert.NoError(t, err)
+	return path
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "proctor-args")
+	assert.NoError(t, err)
+	return dir
+}
+
+func TestParsePositionalArgs(t *testing.T) {
+	args, malformed, err := (&Flags{}).Parse([]string{"ONE=1", "QUERY=a=b", "malformed"}, strings.NewReader(""))
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"ONE": "1", "QUERY": "a=b"}, args)
+	assert.Equal(t, []string{"malformed"}, malformed)
+}
+
+func TestParseYAMLArgsFile(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	path := writeFile(t, dir, "args.yaml", "ONE: 1\nENABLED: true\nSCRIPT: |\n  echo one\n  echo two\nCONFIG:\n  nested: [a, b]\n")
+
+	args, _, err := (&Flags{ArgsFile: path}).Parse(nil, strings.NewReader(""))
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"ONE":     "1",
+		"ENABLED": "true",
+		"SCRIPT":  "echo one\necho two\n",
+		"CONFIG":  `{"nested":["a","b"]}`,
+	}, args)
+}
+
+func TestParseJSONArgsFile(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	path := writeFile(t, dir, "args.json", `{"ID": 12345678901234567890, "PAYLOAD": {"key": "value"}, "EMPTY": null}`)
+
+	args, _, err := (&Flags{ArgsFile: path}).Parse(nil, strings.NewReader(""))
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"ID": "12345678901234567890", "PAYLOAD": `{"key":"value"}`, "EMPTY": ""}, args)
+}
+
+func TestParseDotenvArgsFile(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	path := writeFile(t, dir, "args.env", "# comment\nONE=1\nexport TWO='two words'\nTHREE=\"line one\\nline two\"\n\n")
+
+	args, _, err := (&Flags{ArgsFile: path}).Parse(nil, strings.NewReader(""))
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"ONE": "1", "TWO": "two words", "THREE": "line one\nline two"}, args)
+}
+
+func TestParseArgsFromStdin(t *testing.T) {
+	for input, expected := range map[string]map[string]string{
+		`{"ONE": "1"}`:     {"ONE": "1"},
+		"ONE: 1\nTWO: a=b": {"ONE": "1", "TWO": "a=b"},
+		"ONE=1\nTWO=a:b":   {"ONE": "1", "TWO": "a:b"},
+	} {
+		args, _, err := (&Flags{ArgsFile: "-"}).Parse(nil, strings.NewReader(input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, args, input)
+	}
+}
+
+func TestParseArgFromFile(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	path := writeFile(t, dir, "query.sql", "select *\nfrom executions;\n")
+
+	args, _, err := (&Flags{ArgFromFiles: []string{fmt.Sprintf("QUERY=%s", path)}}).Parse(nil, strings.NewReader(""))
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"QUERY": "select *\nfrom executions;\n"}, args)
+}
+
+func TestParsePrecedence(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	argsFile := writeFile(t, dir, "args.env", "ONE=file\nTWO=file\nTHREE=file\n")
+	valueFile := writeFile(t, dir, "value", "value-file")
+
+	flags := Flags{ArgsFile: argsFile, ArgFromFiles: []string{fmt.Sprintf("TWO=%s", valueFile), fmt.Sprintf("THREE=%s", valueFile)}}
+	args, _, err := flags.Parse([]string{"THREE=positional"}, strings.NewReader(""))
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"ONE": "file", "TWO": "value-file", "THREE": "positional"}, args)
+}
+
+func TestParseErrors(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	invalidDotenv := writeFile(t, dir, "args.env", "ONE\n")
+
+	_, _, err := (&Flags{ArgsFile: invalidDotenv}).Parse(nil, strings.NewReader(""))
+	assert.EqualError(t, err, "error parsing args file: line 1 is not KEY=VALUE")
+
+	_, _, err = (&Flags{ArgsFile: filepath.Join(dir, "missing.yaml")}).Parse(nil, strings.NewReader(""))
+	assert.Contains(t, err.Error(), "error reading args file")
+
+	_, _, err = (&Flags{ArgFromFiles: []string{"QUERY"}}).Parse(nil, strings.NewReader(""))
+	assert.EqualError(t, err, "--arg-from-file must be KEY=path, got QUERY")
+}
+
+func TestPrint(t *testing.T) {
+	mockPrinter := &io.MockPrinter{}
+	mockPrinter.On("Println", "With Variables", color.FgMagenta).Once()
+	mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "\nIncorrect variable format\n", "malformed"), color.FgRed).Once()
+	mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "ONE", "1"), color.Reset).Once()
+
+	(&Flags{}).Print(mockPrinter, map[string]string{"ONE": "1"}, []string{"malformed"})
+
+	mockPrinter.AssertExpectations(t)
+}
+
+func TestPrintWithoutArgs(t *testing.T) {
+	mockPrinter := &io.MockPrinter{}
+	mockPrinter.On("Println", "With No Variables", color.FgRed).Once()
+
+	(&Flags{}).Print(mockPrinter, map[string]string{}, nil)
+
+	mockPrinter.AssertExpectations(t)
+}
+
+func TestPrintLeavesOutValuesFromFiles(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	argsFile := writeFile(t, dir, "args.env", "TOKEN=secret\nREGION=file\n")
+	valueFile := writeFile(t, dir, "value", "select *\nfrom executions;\n")
+
+	flags := Flags{ArgsFile: argsFile, ArgFromFiles: []string{fmt.Sprintf("QUERY=%s", valueFile)}}
+	args, _, err := flags.Parse([]string{"REGION=positional"}, strings.NewReader(""))
+	assert.NoError(t, err)
+
+	mockPrinter := &io.MockPrinter{}
+	mockPrinter.On("Println", "With Variables", color.FgMagenta).Once()
+	mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "QUERY", "<from file>"), color.Reset).Once()
+	mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "REGION", "positional"), color.Reset).Once()
+	mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "TOKEN", "<from file>"), color.Reset).Once()
+
+	flags.Print(mockPrinter, args, nil)
+
+	mockPrinter.AssertExpectations(t)
+}
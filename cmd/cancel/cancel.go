@@ -0,0 +1,30 @@
+package cancel
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"proctor/daemon"
+	"proctor/io"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(printer io.Printer, proctorDClient daemon.Client) *cobra.Command {
+	return &cobra.Command{
+		Use:     "cancel",
+		Short:   "Cancel a running proc execution",
+		Long:    "This command helps to cancel a proc execution which has not finished yet",
+		Example: "proctor cancel proctor-7b3d9a2c-1f4e-4c6a-9f0b-2d8e5a1c3b7f",
+		Args:    cobra.ExactArgs(1),
+
+		Run: func(cmd *cobra.Command, args []string) {
+			executionID := args[0]
+			err := proctorDClient.CancelExecution(executionID)
+			if err != nil {
+				printer.Println(err.Error(), color.FgRed)
+				return
+			}
+			printer.Println(fmt.Sprintf("Successfully cancelled execution: %s", executionID), color.FgGreen)
+		},
+	}
+}
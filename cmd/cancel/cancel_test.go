@@ -0,0 +1,56 @@
+package cancel
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fatih/color"
+	"proctor/daemon"
+	"proctor/io"
+	"proctor/proctord/utility"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type CancelCmdTestSuite struct {
+	suite.Suite
+	mockPrinter        *io.MockPrinter
+	mockProctorDClient *daemon.MockClient
+	testCancelCmd      *cobra.Command
+}
+
+func (s *CancelCmdTestSuite) SetupTest() {
+	s.mockPrinter = &io.MockPrinter{}
+	s.mockProctorDClient = &daemon.MockClient{}
+	s.testCancelCmd = NewCmd(s.mockPrinter, s.mockProctorDClient)
+}
+
+func (s *CancelCmdTestSuite) TestCancelCmdHelp() {
+	assert.Equal(s.T(), "Cancel a running proc execution", s.testCancelCmd.Short)
+	assert.Equal(s.T(), "This command helps to cancel a proc execution which has not finished yet", s.testCancelCmd.Long)
+	assert.Equal(s.T(), "proctor cancel proctor-7b3d9a2c-1f4e-4c6a-9f0b-2d8e5a1c3b7f", s.testCancelCmd.Example)
+}
+
+func (s *CancelCmdTestSuite) TestCancelCmdRun() {
+	s.mockProctorDClient.On("CancelExecution", "proctor-ipsum-lorem").Return(nil).Once()
+	s.mockPrinter.On("Println", "Successfully cancelled execution: proctor-ipsum-lorem", color.FgGreen).Once()
+
+	s.testCancelCmd.Run(&cobra.Command{}, []string{"proctor-ipsum-lorem"})
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *CancelCmdTestSuite) TestCancelCmdRunProctorDClientFailure() {
+	s.mockProctorDClient.On("CancelExecution", "proctor-ipsum-lorem").Return(errors.New(utility.JobAlreadyFinishedClientError)).Once()
+	s.mockPrinter.On("Println", utility.JobAlreadyFinishedClientError, color.FgRed).Once()
+
+	s.testCancelCmd.Run(&cobra.Command{}, []string{"proctor-ipsum-lorem"})
+
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func TestCancelCmdTestSuite(t *testing.T) {
+	suite.Run(t, new(CancelCmdTestSuite))
+}
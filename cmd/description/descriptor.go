@@ -8,6 +8,7 @@ import (
 	"proctor/daemon"
 	"proctor/io"
 	proc_metadata "proctor/proctord/jobs/metadata"
+	"proctor/proctord/jobs/metadata/env"
 	"github.com/spf13/cobra"
 )
 
@@ -46,13 +47,39 @@ func NewCmd(printer io.Printer, proctorDClient daemon.Client) *cobra.Command {
 			printer.Println(fmt.Sprintf("%-40s %-100s", "Contributors", desiredProc.Contributors), color.Reset)
 			printer.Println(fmt.Sprintf("%-40s %-100s", "Organization", desiredProc.Organization), color.Reset)
 			printer.Println(fmt.Sprintf("%-40s [%s]", "Authorized Groups", strings.Join(desiredProc.AuthorizedGroups, ", ")), color.Reset)
+			if desiredProc.RequiresApproval {
+				printer.Println(fmt.Sprintf("%-40s [%s]", "Requires Approval From", strings.Join(desiredProc.ApproverGroups, ", ")), color.FgYellow)
+			}
 
 			printer.Println("\nArgs", color.FgMagenta)
 			for _, arg := range desiredProc.EnvVars.Args {
 				printer.Println(fmt.Sprintf("%-40s %-100s", arg.Name, arg.Description), color.Reset)
+				if constraints := argConstraints(arg); constraints != "" {
+					printer.Println(fmt.Sprintf("%-40s %-100s", "", constraints), color.FgCyan)
+				}
 			}
 
 			printer.Println(fmt.Sprintf("\nTo %s, run:\nproctor execute %s ARG_ONE=foo ARG_TWO=bar", userProvidedProcName, userProvidedProcName), color.FgGreen)
 		},
 	}
 }
+
+func argConstraints(arg env.VarMetadata) string {
+	var constraints []string
+	if arg.Required && arg.Default == "" {
+		constraints = append(constraints, "required")
+	}
+	if arg.Type != "" {
+		constraints = append(constraints, fmt.Sprintf("type: %s", arg.Type))
+	}
+	if len(arg.AllowedValues) > 0 {
+		constraints = append(constraints, fmt.Sprintf("allowed values: [%s]", strings.Join(arg.AllowedValues, ", ")))
+	}
+	if arg.Pattern != "" {
+		constraints = append(constraints, fmt.Sprintf("pattern: %s", arg.Pattern))
+	}
+	if arg.Default != "" {
+		constraints = append(constraints, fmt.Sprintf("default: %s", arg.Default))
+	}
+	return strings.Join(constraints, ", ")
+}
@@ -46,6 +46,23 @@ func (s *DescribeCmdTestSuite) TestDescribeCmdRun() {
 		Description: "arg one description",
 	}
 
+	constrainedArg := env.VarMetadata{
+		Name:          "arg-two",
+		Description:   "arg two description",
+		Required:      true,
+		Type:          env.EnumType,
+		AllowedValues: []string{"blue", "green"},
+	}
+
+	defaultedArg := env.VarMetadata{
+		Name:        "arg-three",
+		Description: "arg three description",
+		Required:    true,
+		Type:        env.StringType,
+		Pattern:     "[a-z]+",
+		Default:     "abc",
+	}
+
 	secret := env.VarMetadata{
 		Name:        "secret-one",
 		Description: "secret one description",
@@ -57,8 +74,10 @@ func (s *DescribeCmdTestSuite) TestDescribeCmdRun() {
 		Contributors:     "user@example.com",
 		Organization:     "org",
 		AuthorizedGroups: []string{"group_one", "group_two"},
+		RequiresApproval: true,
+		ApproverGroups:   []string{"group_two"},
 		EnvVars: env.Vars{
-			Args:    []env.VarMetadata{arg},
+			Args:    []env.VarMetadata{arg, constrainedArg, defaultedArg},
 			Secrets: []env.VarMetadata{secret},
 		},
 	}
@@ -70,8 +89,13 @@ func (s *DescribeCmdTestSuite) TestDescribeCmdRun() {
 	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Contributors", anyProc.Contributors), color.Reset).Once()
 	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Organization", anyProc.Organization), color.Reset).Once()
 	s.mockPrinter.On("Println", fmt.Sprintf("%-40s [%s]", "Authorized Groups", strings.Join(anyProc.AuthorizedGroups, ", ")), color.Reset).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s [%s]", "Requires Approval From", "group_two"), color.FgYellow).Once()
 	s.mockPrinter.On("Println", "\nArgs", color.FgMagenta).Once()
 	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", arg.Name, arg.Description), color.Reset).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", constrainedArg.Name, constrainedArg.Description), color.Reset).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "", "required, type: enum, allowed values: [blue, green]"), color.FgCyan).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", defaultedArg.Name, defaultedArg.Description), color.Reset).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "", "type: string, pattern: [a-z]+, default: abc"), color.FgCyan).Once()
 	s.mockPrinter.On("Println", fmt.Sprintf("\nTo %s, run:\nproctor execute %s ARG_ONE=foo ARG_TWO=bar", anyProc.Name, anyProc.Name), color.FgGreen).Once()
 
 	s.testDescribeCmd.Run(&cobra.Command{}, []string{anyProc.Name})
@@ -2,48 +2,99 @@ package execution
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/fatih/color"
+	"proctor/cmd/arguments"
 	"proctor/daemon"
 	"proctor/io"
+	proctord_execution "proctor/proctord/jobs/execution"
+	proc_metadata "proctor/proctord/jobs/metadata"
+	"proctor/proctord/jobs/metadata/env"
 	proctord_utility "proctor/proctord/utility"
 	"github.com/spf13/cobra"
 )
 
-func NewCmd(printer io.Printer, proctorDClient daemon.Client, osExitFunc func(int)) *cobra.Command {
-	return &cobra.Command{
+func NewCmd(printer io.Printer, proctorDClient daemon.Client, prompter io.Prompter, osExitFunc func(int)) *cobra.Command {
+	var argumentFlags arguments.Flags
+	var detach bool
+	var labelFlags []string
+	var reason string
+
+	executionCmd := &cobra.Command{
 		Use:     "execute",
 		Short:   "Execute a proc with given arguments",
 		Long:    "To execute a proc, this command helps communicate with `proctord` and streams to logs of proc in execution",
-		Example: "proctor execute proc-one SOME_VAR=foo ANOTHER_VAR=bar\nproctor execute proc-two ANY_VAR=baz",
+		Example: "proctor execute proc-one SOME_VAR=foo ANOTHER_VAR=bar\nproctor execute proc-two ANY_VAR=baz\nproctor execute proc-three --label ticket=OPS-123 --reason \"Clearing the stuck payments queue\"",
 		Args:    cobra.MinimumNArgs(1),
 
 		Run: func(cmd *cobra.Command, args []string) {
 			procName := args[0]
 			printer.Println(fmt.Sprintf("%-40s %-100s", "Executing Proc", procName), color.Reset)
 
-			procArgs := make(map[string]string)
-			if len(args) > 1 {
-				printer.Println("With Variables", color.FgMagenta)
-				for _, v := range args[1:] {
-					arg := strings.Split(v, "=")
+			procArgs, malformedArgs, err := argumentFlags.Parse(args[1:], os.Stdin)
+			if err != nil {
+				printer.Println(err.Error(), color.FgRed)
+				osExitFunc(1)
+				return
+			}
 
-					if len(arg) < 2 {
-						printer.Println(fmt.Sprintf("%-40s %-100s", "\nIncorrect variable format\n", v), color.FgRed)
-						continue
-					}
+			labels, err := proctord_execution.ParseLabels(labelFlags)
+			if err != nil {
+				printer.Println(err.Error(), color.FgRed)
+				osExitFunc(1)
+				return
+			}
 
-					combinedArgValue := strings.Join(arg[1:], "=")
-					procArgs[arg[0]] = combinedArgValue
+			proc, found := findProc(proctorDClient, procName)
+			if found && hasUnsetArgs(proc, procArgs) {
+				if prompter.IsInteractive() {
+					err = promptForMissingArgs(printer, prompter, proc, procArgs)
+					if err != nil {
+						printer.Println(fmt.Sprintf("Error reading proc args: %s", err.Error()), color.FgRed)
+						osExitFunc(1)
+						return
+					}
+				} else if missingArgs := missingRequiredArgs(proc, procArgs); len(missingArgs) > 0 {
+					printer.Println("Missing required proc args, pass them as KEY=VALUE or run from a terminal to be prompted:", color.FgRed)
+					for _, arg := range missingArgs {
+						printer.Println(fmt.Sprintf("%-40s %-100s", arg.Name, arg.Description), color.FgRed)
+					}
+					osExitFunc(1)
+					return
+				}
+			}
+			if found && proc.ReasonRequired && strings.TrimSpace(reason) == "" {
+				if !prompter.IsInteractive() {
+					printer.Println(fmt.Sprintf("%s requires a reason to execute, pass it with --reason", procName), color.FgRed)
+					osExitFunc(1)
+					return
+				}
+				reason, err = promptForReason(prompter, procName)
+				if err != nil {
+					printer.Println(fmt.Sprintf("Error reading reason: %s", err.Error()), color.FgRed)
+					osExitFunc(1)
+					return
+				}
+			}
+			argumentFlags.Print(printer, procArgs, malformedArgs)
 
-					printer.Println(fmt.Sprintf("%-40s %-100s", arg[0], combinedArgValue), color.Reset)
+			problems := validateProcArgs(proc, found, procArgs)
+			if len(problems) > 0 {
+				printer.Println("Invalid proc args:", color.FgRed)
+				for _, problem := range problems {
+					printer.Println(problem, color.FgRed)
 				}
-			} else {
-				printer.Println("With No Variables", color.FgRed)
+				osExitFunc(1)
+				return
 			}
 
-			executedProcName, err := proctorDClient.ExecuteProc(procName, procArgs)
+			executedProcName, err := proctorDClient.ExecuteProc(procName, procArgs, proctord_execution.Provenance{Labels: labels, Reason: reason})
+			if approvalPending, ok := err.(daemon.ApprovalPendingError); ok {
+				PrintApprovalPending(printer, approvalPending)
+				return
+			}
 			if err != nil {
 				printer.Println(err.Error(), color.FgRed)
 				print()
@@ -51,30 +102,179 @@ func NewCmd(printer io.Printer, proctorDClient daemon.Client, osExitFunc func(in
 				return
 			}
 			
-			printer.Println("Proc submitted for execution. \nStreaming logs:", color.FgGreen)
-			err = proctorDClient.StreamProcLogs(executedProcName)
-			if err != nil {
-				printer.Println("Error Streaming Logs", color.FgRed)
-				osExitFunc(1)
+			if detach {
+				printer.Println(fmt.Sprintf("Proc submitted for execution: %s", executedProcName), color.FgGreen)
+				printer.Println(fmt.Sprintf("To stream its logs run: proctor logs %s --follow", executedProcName), color.Reset)
 				return
 			}
 
-			printer.Println("Log stream of proc completed.", color.FgGreen)
+			printer.Println("Proc submitted for execution. \nStreaming logs:", color.FgGreen)
+			Follow(printer, proctorDClient, prompter, osExitFunc, executedProcName)
+		},
+	}
+	argumentFlags.Register(executionCmd)
+	executionCmd.Flags().BoolVarP(&detach, "detach", "d", false, "Print the execution ID and exit instead of streaming logs")
+	executionCmd.Flags().StringArrayVarP(&labelFlags, "label", "l", nil, "Label the execution, e.g. with the ticket prompting it, as KEY=VALUE. Can be repeated")
+	executionCmd.Flags().StringVarP(&reason, "reason", "r", "", "Why the proc is being executed")
+
+	return executionCmd
+}
+
+// PrintApprovalPending tells the user their execution is held until an approver approves it
+func PrintApprovalPending(printer io.Printer, approvalPending daemon.ApprovalPendingError) {
+	printer.Println(fmt.Sprintf("Proc requires approval, execution is pending approval request: %s", approvalPending.ApprovalID), color.FgYellow)
+	printer.Println(fmt.Sprintf("An approver can approve it with: proctor approvals approve %s", approvalPending.ApprovalID), color.Reset)
+}
+
+// Follow streams the logs of a submitted execution and exits non zero unless it succeeds
+func Follow(printer io.Printer, proctorDClient daemon.Client, prompter io.Prompter, osExitFunc func(int), executedProcName string) {
+	err := proctorDClient.StreamProcLogs(executedProcName, true)
+	if err == daemon.ErrStreamInterrupted {
+		offerCancellation(printer, proctorDClient, prompter, executedProcName)
+		osExitFunc(1)
+		return
+	}
+	if err != nil {
+		printer.Println("Error Streaming Logs", color.FgRed)
+		osExitFunc(1)
+		return
+	}
+
+	printer.Println("Log stream of proc completed.", color.FgGreen)
+
+	procExecutionStatus, err := proctorDClient.GetDefinitiveProcExecutionStatus(executedProcName)
+	if err != nil {
+		printer.Println("Error Fetching Proc execution status", color.FgRed)
+		osExitFunc(1)
+		return
+	}
+
+	if procExecutionStatus == proctord_utility.JobCancelled {
+		printer.Println("Proc execution cancelled", color.FgRed)
+		osExitFunc(1)
+		return
+	}
 
-			procExecutionStatus, err := proctorDClient.GetDefinitiveProcExecutionStatus(executedProcName)
+	if procExecutionStatus != proctord_utility.JobSucceeded {
+		printer.Println("Proc execution failed", color.FgRed)
+		osExitFunc(1)
+		return
+	}
+
+	printer.Println("Proc execution successful", color.FgGreen)
+}
+
+// findProc fetches the proc's metadata, not finding it leaves checking args to proctord
+func findProc(proctorDClient daemon.Client, procName string) (proc_metadata.Metadata, bool) {
+	procList, err := proctorDClient.ListProcs()
+	if err != nil {
+		return proc_metadata.Metadata{}, false
+	}
+
+	for _, proc := range procList {
+		if proc.Name == procName {
+			return proc, true
+		}
+	}
+	return proc_metadata.Metadata{}, false
+}
+
+func validateProcArgs(proc proc_metadata.Metadata, found bool, procArgs map[string]string) []string {
+	if !found {
+		return nil
+	}
+	return proc.EnvVars.ValidateArgs(procArgs)
+}
+
+func hasUnsetArgs(proc proc_metadata.Metadata, procArgs map[string]string) bool {
+	for _, arg := range proc.EnvVars.Args {
+		if _, ok := procArgs[arg.Name]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+func missingRequiredArgs(proc proc_metadata.Metadata, procArgs map[string]string) []env.VarMetadata {
+	var missingArgs []env.VarMetadata
+	for _, arg := range proc.EnvVars.Args {
+		if _, ok := procArgs[arg.Name]; !ok && arg.Required && arg.Default == "" {
+			missingArgs = append(missingArgs, arg)
+		}
+	}
+	return missingArgs
+}
+
+// promptForMissingArgs asks for every arg of the proc without a value, until the answer is valid.
+// An empty answer leaves an optional arg to its default
+func promptForMissingArgs(printer io.Printer, prompter io.Prompter, proc proc_metadata.Metadata, procArgs map[string]string) error {
+	for _, arg := range proc.EnvVars.Args {
+		if _, ok := procArgs[arg.Name]; ok {
+			continue
+		}
+
+		if arg.Description != "" {
+			printer.Println(fmt.Sprintf("%s: %s", arg.Name, arg.Description), color.FgCyan)
+		}
+		for {
+			answer, err := prompter.Prompt(argPrompt(arg))
 			if err != nil {
-				printer.Println("Error Fetching Proc execution status", color.FgRed)
-				osExitFunc(1)
-				return
+				return err
 			}
 
-			if procExecutionStatus != proctord_utility.JobSucceeded {
-				printer.Println("Proc execution failed", color.FgRed)
-				osExitFunc(1)
-				return
+			if answer == "" {
+				if arg.Required && arg.Default == "" {
+					printer.Println(fmt.Sprintf("%s is required", arg.Name), color.FgRed)
+					continue
+				}
+				break
 			}
 
-			printer.Println("Proc execution successful", color.FgGreen)
-		},
+			err = arg.ValidateValue(answer)
+			if err != nil {
+				printer.Println(err.Error(), color.FgRed)
+				continue
+			}
+			procArgs[arg.Name] = answer
+			break
+		}
+	}
+	return nil
+}
+
+func argPrompt(arg env.VarMetadata) string {
+	if arg.Default != "" {
+		return fmt.Sprintf("%s [%s]: ", arg.Name, arg.Default)
+	}
+	if arg.Required {
+		return fmt.Sprintf("%s (required): ", arg.Name)
+	}
+	return fmt.Sprintf("%s (optional): ", arg.Name)
+}
+
+func promptForReason(prompter io.Prompter, procName string) (string, error) {
+	for {
+		answer, err := prompter.Prompt(fmt.Sprintf("Reason for executing %s (required): ", procName))
+		if err != nil {
+			return "", err
+		}
+		if strings.TrimSpace(answer) != "" {
+			return answer, nil
+		}
+	}
+}
+
+func offerCancellation(printer io.Printer, proctorDClient daemon.Client, prompter io.Prompter, executedProcName string) {
+	answer, err := prompter.Prompt(fmt.Sprintf("Do you want to cancel execution %s (y/N)? ", executedProcName))
+	if err != nil || strings.ToLower(answer) != "y" {
+		printer.Println(fmt.Sprintf("Proc is still executing. To cancel it later run: proctor cancel %s", executedProcName), color.Reset)
+		return
+	}
+
+	err = proctorDClient.CancelExecution(executedProcName)
+	if err != nil {
+		printer.Println(err.Error(), color.FgRed)
+		return
 	}
+	printer.Println(fmt.Sprintf("Successfully cancelled execution: %s", executedProcName), color.FgGreen)
 }
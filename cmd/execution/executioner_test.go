@@ -4,11 +4,17 @@ import (
 	"errors"
 	"fmt"
 	"github.com/stretchr/testify/mock"
+	"io/ioutil"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/fatih/color"
 	"proctor/daemon"
 	"proctor/io"
+	proctord_execution "proctor/proctord/jobs/execution"
+	proc_metadata "proctor/proctord/jobs/metadata"
+	"proctor/proctord/jobs/metadata/env"
 	"proctor/proctord/utility"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -19,13 +25,15 @@ type ExecutionCmdTestSuite struct {
 	suite.Suite
 	mockPrinter        *io.MockPrinter
 	mockProctorDClient *daemon.MockClient
+	mockPrompter       *io.MockPrompter
 	testExecutionCmd   *cobra.Command
 }
 
 func (s *ExecutionCmdTestSuite) SetupTest() {
 	s.mockPrinter = &io.MockPrinter{}
 	s.mockProctorDClient = &daemon.MockClient{}
-	s.testExecutionCmd = NewCmd(s.mockPrinter, s.mockProctorDClient, func(exitCode int) {})
+	s.mockPrompter = &io.MockPrompter{}
+	s.testExecutionCmd = NewCmd(s.mockPrinter, s.mockProctorDClient, s.mockPrompter, func(exitCode int) {})
 }
 
 func (s *ExecutionCmdTestSuite) TestExecutionCmdUsage() {
@@ -35,7 +43,7 @@ func (s *ExecutionCmdTestSuite) TestExecutionCmdUsage() {
 func (s *ExecutionCmdTestSuite) TestExecutionCmdHelp() {
 	assert.Equal(s.T(), "Execute a proc with given arguments", s.testExecutionCmd.Short)
 	assert.Equal(s.T(), "To execute a proc, this command helps communicate with `proctord` and streams to logs of proc in execution", s.testExecutionCmd.Long)
-	assert.Equal(s.T(), "proctor execute proc-one SOME_VAR=foo ANOTHER_VAR=bar\nproctor execute proc-two ANY_VAR=baz", s.testExecutionCmd.Example)
+	assert.Equal(s.T(), "proctor execute proc-one SOME_VAR=foo ANOTHER_VAR=bar\nproctor execute proc-two ANY_VAR=baz\nproctor execute proc-three --label ticket=OPS-123 --reason \"Clearing the stuck payments queue\"", s.testExecutionCmd.Example)
 }
 
 func (s *ExecutionCmdTestSuite) TestExecutionCmd() {
@@ -49,11 +57,12 @@ func (s *ExecutionCmdTestSuite) TestExecutionCmd() {
 	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "SAMPLE_ARG_ONE", "any"), color.Reset).Once()
 	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "SAMPLE_ARG_TWO", "variable"), color.Reset).Once()
 
-	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", procArgs).Return("executed-proc-name", nil).Once()
+	s.mockProctorDClient.On("ListProcs").Return([]proc_metadata.Metadata{}, nil).Once()
+	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", procArgs, proctord_execution.Provenance{}).Return("executed-proc-name", nil).Once()
 
 	s.mockPrinter.On("Println", "Proc submitted for execution. \nStreaming logs:", color.FgGreen).Once()
 
-	s.mockProctorDClient.On("StreamProcLogs", "executed-proc-name").Return(nil).Once()
+	s.mockProctorDClient.On("StreamProcLogs", "executed-proc-name", true).Return(nil).Once()
 	s.mockPrinter.On("Println", "Log stream of proc completed.", color.FgGreen).Once()
 
 	s.mockProctorDClient.On("GetDefinitiveProcExecutionStatus", "executed-proc-name").Return(utility.JobSucceeded, nil).Once()
@@ -65,6 +74,174 @@ func (s *ExecutionCmdTestSuite) TestExecutionCmd() {
 	s.mockPrinter.AssertExpectations(s.T())
 }
 
+func (s *ExecutionCmdTestSuite) TestExecutionCmdWithArgsFile() {
+	argsFile, err := ioutil.TempFile("", "args*.yaml")
+	assert.NoError(s.T(), err)
+	defer os.Remove(argsFile.Name())
+	_, err = argsFile.WriteString("SAMPLE_ARG_ONE: from-file\nSAMPLE_ARG_TWO: from-file\n")
+	assert.NoError(s.T(), err)
+	argsFile.Close()
+
+	args := []string{"say-hello-world", "SAMPLE_ARG_TWO=variable"}
+	procArgs := map[string]string{"SAMPLE_ARG_ONE": "from-file", "SAMPLE_ARG_TWO": "variable"}
+
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Executing Proc", "say-hello-world"), color.Reset).Once()
+	s.mockPrinter.On("Println", "With Variables", color.FgMagenta).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "SAMPLE_ARG_ONE", "<from file>"), color.Reset).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "SAMPLE_ARG_TWO", "variable"), color.Reset).Once()
+
+	s.mockProctorDClient.On("ListProcs").Return([]proc_metadata.Metadata{}, nil).Once()
+	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", procArgs, proctord_execution.Provenance{}).Return("executed-proc-name", nil).Once()
+
+	s.mockPrinter.On("Println", "Proc submitted for execution. \nStreaming logs:", color.FgGreen).Once()
+
+	s.mockProctorDClient.On("StreamProcLogs", "executed-proc-name", true).Return(nil).Once()
+	s.mockPrinter.On("Println", "Log stream of proc completed.", color.FgGreen).Once()
+
+	s.mockProctorDClient.On("GetDefinitiveProcExecutionStatus", "executed-proc-name").Return(utility.JobSucceeded, nil).Once()
+	s.mockPrinter.On("Println", "Proc execution successful", color.FgGreen).Once()
+
+	s.testExecutionCmd.Flags().Set("args-file", argsFile.Name())
+	s.testExecutionCmd.Run(s.testExecutionCmd, args)
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *ExecutionCmdTestSuite) TestExecutionCmdWithLabelsAndReason() {
+	provenance := proctord_execution.Provenance{
+		Labels: map[string]string{"ticket": "OPS-123", "team": "payments"},
+		Reason: "Clearing the stuck payments queue",
+	}
+
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Executing Proc", "say-hello-world"), color.Reset).Once()
+	s.mockPrinter.On("Println", "With No Variables", color.FgRed).Once()
+	s.mockProctorDClient.On("ListProcs").Return([]proc_metadata.Metadata{{Name: "say-hello-world", ReasonRequired: true}}, nil).Once()
+	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", map[string]string{}, provenance).Return("executed-proc-name", nil).Once()
+	s.mockPrinter.On("Println", "Proc submitted for execution: executed-proc-name", color.FgGreen).Once()
+	s.mockPrinter.On("Println", "To stream its logs run: proctor logs executed-proc-name --follow", color.Reset).Once()
+
+	s.testExecutionCmd.Flags().Set("label", "ticket=OPS-123")
+	s.testExecutionCmd.Flags().Set("label", "team=payments")
+	s.testExecutionCmd.Flags().Set("reason", provenance.Reason)
+	s.testExecutionCmd.Flags().Set("detach", "true")
+	s.testExecutionCmd.Run(s.testExecutionCmd, []string{"say-hello-world"})
+
+	s.mockPrompter.AssertNotCalled(s.T(), "Prompt", mock.Anything)
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *ExecutionCmdTestSuite) TestExecutionCmdForProcRequiringApproval() {
+	exitCode := 0
+	testExecutionCmd := NewCmd(s.mockPrinter, s.mockProctorDClient, s.mockPrompter, func(code int) { exitCode = code })
+
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Executing Proc", "refund"), color.Reset).Once()
+	s.mockPrinter.On("Println", "With No Variables", color.FgRed).Once()
+	s.mockProctorDClient.On("ListProcs").Return([]proc_metadata.Metadata{{Name: "refund", RequiresApproval: true}}, nil).Once()
+	s.mockProctorDClient.On("ExecuteProc", "refund", map[string]string{}, proctord_execution.Provenance{}).Return("", daemon.ApprovalPendingError{ApprovalID: "approval-id"}).Once()
+	s.mockPrinter.On("Println", "Proc requires approval, execution is pending approval request: approval-id", color.FgYellow).Once()
+	s.mockPrinter.On("Println", "An approver can approve it with: proctor approvals approve approval-id", color.Reset).Once()
+
+	testExecutionCmd.Run(testExecutionCmd, []string{"refund"})
+
+	s.mockProctorDClient.AssertNotCalled(s.T(), "StreamProcLogs", mock.Anything, mock.Anything)
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+	assert.Equal(s.T(), 0, exitCode)
+}
+
+func (s *ExecutionCmdTestSuite) TestExecutionCmdForMalformedLabel() {
+	exitCode := 0
+	testExecutionCmd := NewCmd(s.mockPrinter, s.mockProctorDClient, s.mockPrompter, func(code int) { exitCode = code })
+
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Executing Proc", "say-hello-world"), color.Reset).Once()
+	s.mockPrinter.On("Println", "label OPS-123 must be KEY=VALUE", color.FgRed).Once()
+
+	testExecutionCmd.Flags().Set("label", "OPS-123")
+	testExecutionCmd.Run(testExecutionCmd, []string{"say-hello-world"})
+
+	s.mockProctorDClient.AssertNotCalled(s.T(), "ExecuteProc", mock.Anything, mock.Anything, mock.Anything)
+	s.mockPrinter.AssertExpectations(s.T())
+	assert.Equal(s.T(), 1, exitCode)
+}
+
+func (s *ExecutionCmdTestSuite) TestExecutionCmdPromptsForRequiredReason() {
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Executing Proc", "say-hello-world"), color.Reset).Once()
+	s.mockPrinter.On("Println", "With No Variables", color.FgRed).Once()
+	s.mockProctorDClient.On("ListProcs").Return([]proc_metadata.Metadata{{Name: "say-hello-world", ReasonRequired: true}}, nil).Once()
+	s.mockPrompter.On("IsInteractive").Return(true).Once()
+	s.mockPrompter.On("Prompt", "Reason for executing say-hello-world (required): ").Return(" ", nil).Once()
+	s.mockPrompter.On("Prompt", "Reason for executing say-hello-world (required): ").Return("Clearing the stuck payments queue", nil).Once()
+
+	provenance := proctord_execution.Provenance{Reason: "Clearing the stuck payments queue"}
+	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", map[string]string{}, provenance).Return("executed-proc-name", nil).Once()
+	s.mockPrinter.On("Println", "Proc submitted for execution: executed-proc-name", color.FgGreen).Once()
+	s.mockPrinter.On("Println", "To stream its logs run: proctor logs executed-proc-name --follow", color.Reset).Once()
+
+	s.testExecutionCmd.Flags().Set("detach", "true")
+	s.testExecutionCmd.Run(s.testExecutionCmd, []string{"say-hello-world"})
+
+	s.mockPrompter.AssertExpectations(s.T())
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *ExecutionCmdTestSuite) TestExecutionCmdForRequiredReasonWithoutTerminal() {
+	exitCode := 0
+	testExecutionCmd := NewCmd(s.mockPrinter, s.mockProctorDClient, s.mockPrompter, func(code int) { exitCode = code })
+
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Executing Proc", "say-hello-world"), color.Reset).Once()
+	s.mockProctorDClient.On("ListProcs").Return([]proc_metadata.Metadata{{Name: "say-hello-world", ReasonRequired: true}}, nil).Once()
+	s.mockPrompter.On("IsInteractive").Return(false).Once()
+	s.mockPrinter.On("Println", "say-hello-world requires a reason to execute, pass it with --reason", color.FgRed).Once()
+
+	testExecutionCmd.Run(testExecutionCmd, []string{"say-hello-world"})
+
+	s.mockPrompter.AssertNotCalled(s.T(), "Prompt", mock.Anything)
+	s.mockProctorDClient.AssertNotCalled(s.T(), "ExecuteProc", mock.Anything, mock.Anything, mock.Anything)
+	s.mockPrinter.AssertExpectations(s.T())
+	assert.Equal(s.T(), 1, exitCode)
+}
+
+func (s *ExecutionCmdTestSuite) TestExecutionCmdForUnreadableArgsFile() {
+	exitCode := 0
+	testExecutionCmd := NewCmd(s.mockPrinter, s.mockProctorDClient, s.mockPrompter, func(code int) { exitCode = code })
+
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Executing Proc", "say-hello-world"), color.Reset).Once()
+	s.mockPrinter.On("Println", mock.MatchedBy(func(message string) bool {
+		return strings.HasPrefix(message, "error reading args file")
+	}), color.FgRed).Once()
+
+	testExecutionCmd.Flags().Set("args-file", "/non-existent/args.yaml")
+	testExecutionCmd.Run(testExecutionCmd, []string{"say-hello-world"})
+
+	s.mockProctorDClient.AssertNotCalled(s.T(), "ExecuteProc", "say-hello-world", mock.Anything, mock.Anything)
+	s.mockPrinter.AssertExpectations(s.T())
+	assert.Equal(s.T(), 1, exitCode)
+}
+
+func (s *ExecutionCmdTestSuite) TestExecutionCmdWithDetach() {
+	args := []string{"say-hello-world", "SAMPLE_ARG_ONE=any"}
+
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Executing Proc", "say-hello-world"), color.Reset).Once()
+	s.mockPrinter.On("Println", "With Variables", color.FgMagenta).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "SAMPLE_ARG_ONE", "any"), color.Reset).Once()
+
+	s.mockProctorDClient.On("ListProcs").Return([]proc_metadata.Metadata{}, nil).Once()
+	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", map[string]string{"SAMPLE_ARG_ONE": "any"}, proctord_execution.Provenance{}).Return("executed-proc-name", nil).Once()
+
+	s.mockPrinter.On("Println", "Proc submitted for execution: executed-proc-name", color.FgGreen).Once()
+	s.mockPrinter.On("Println", "To stream its logs run: proctor logs executed-proc-name --follow", color.Reset).Once()
+
+	s.testExecutionCmd.Flags().Set("detach", "true")
+	s.testExecutionCmd.Run(s.testExecutionCmd, args)
+
+	s.mockProctorDClient.AssertNotCalled(s.T(), "StreamProcLogs", "executed-proc-name", true)
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
 func (s *ExecutionCmdTestSuite) TestExecutionCmdForNoProcVariables() {
 	args := []string{"say-hello-world"}
 
@@ -72,11 +249,12 @@ func (s *ExecutionCmdTestSuite) TestExecutionCmdForNoProcVariables() {
 	s.mockPrinter.On("Println", "With No Variables", color.FgRed).Once()
 
 	procArgs := make(map[string]string)
-	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", procArgs).Return("executed-proc-name", nil).Once()
+	s.mockProctorDClient.On("ListProcs").Return([]proc_metadata.Metadata{}, nil).Once()
+	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", procArgs, proctord_execution.Provenance{}).Return("executed-proc-name", nil).Once()
 
 	s.mockPrinter.On("Println", "Proc submitted for execution. \nStreaming logs:", color.FgGreen).Once()
 
-	s.mockProctorDClient.On("StreamProcLogs", "executed-proc-name").Return(nil).Once()
+	s.mockProctorDClient.On("StreamProcLogs", "executed-proc-name", true).Return(nil).Once()
 	s.mockPrinter.On("Println", "Log stream of proc completed.", color.FgGreen).Once()
 
 	s.mockProctorDClient.On("GetDefinitiveProcExecutionStatus", "executed-proc-name").Return(utility.JobSucceeded, nil).Once()
@@ -96,11 +274,12 @@ func (s *ExecutionCmdTestSuite) TestExecutionCmdForIncorrectVariableFormat() {
 	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "\nIncorrect variable format\n", "incorrect-format"), color.FgRed).Once()
 
 	procArgs := make(map[string]string)
-	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", procArgs).Return("executed-proc-name", nil).Once()
+	s.mockProctorDClient.On("ListProcs").Return([]proc_metadata.Metadata{}, nil).Once()
+	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", procArgs, proctord_execution.Provenance{}).Return("executed-proc-name", nil).Once()
 
 	s.mockPrinter.On("Println", "Proc submitted for execution. \nStreaming logs:", color.FgGreen).Once()
 
-	s.mockProctorDClient.On("StreamProcLogs", "executed-proc-name").Return(nil).Once()
+	s.mockProctorDClient.On("StreamProcLogs", "executed-proc-name", true).Return(nil).Once()
 	s.mockPrinter.On("Println", "Log stream of proc completed.", color.FgGreen).Once()
 
 	s.mockProctorDClient.On("GetDefinitiveProcExecutionStatus", "executed-proc-name").Return(utility.JobSucceeded, nil).Once()
@@ -119,16 +298,147 @@ func (s *ExecutionCmdTestSuite) TestExecutionCmdForProctorDExecutionFailure() {
 	s.mockPrinter.On("Println", "With No Variables", color.FgRed).Once()
 
 	procArgs := make(map[string]string)
-	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", procArgs).Return("", errors.New("test error")).Once()
+	s.mockProctorDClient.On("ListProcs").Return([]proc_metadata.Metadata{}, nil).Once()
+	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", procArgs, proctord_execution.Provenance{}).Return("", errors.New("test error")).Once()
 
 	s.mockPrinter.On("Println", mock.Anything, color.FgRed).Once()
 
 	osExitFunc := func(exitCode int) {
 		assert.Equal(s.T(), 1, exitCode)
 	}
-	testExecutionCmdOSExit := NewCmd(s.mockPrinter, s.mockProctorDClient, osExitFunc)
+	testExecutionCmdOSExit := NewCmd(s.mockPrinter, s.mockProctorDClient, s.mockPrompter, osExitFunc)
+	testExecutionCmdOSExit.Run(&cobra.Command{}, args)
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *ExecutionCmdTestSuite) TestExecutionCmdForInvalidProcArgs() {
+	args := []string{"say-hello-world", "NAME=proctor", "COUNT=many", "UNKNOWN=any"}
+
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Executing Proc", "say-hello-world"), color.Reset).Once()
+	s.mockPrinter.On("Println", "With Variables", color.FgMagenta).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "NAME", "proctor"), color.Reset).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "COUNT", "many"), color.Reset).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "UNKNOWN", "any"), color.Reset).Once()
+
+	procList := []proc_metadata.Metadata{
+		{
+			Name: "say-hello-world",
+			EnvVars: env.Vars{
+				Args: []env.VarMetadata{
+					{Name: "NAME", Required: true},
+					{Name: "COUNT", Type: env.IntType},
+				},
+			},
+		},
+	}
+	s.mockProctorDClient.On("ListProcs").Return(procList, nil).Once()
+
+	s.mockPrinter.On("Println", "Invalid proc args:", color.FgRed).Once()
+	s.mockPrinter.On("Println", "COUNT must be an int", color.FgRed).Once()
+	s.mockPrinter.On("Println", "UNKNOWN is not an arg of this proc", color.FgRed).Once()
+
+	exitCode := 0
+	testExecutionCmdOSExit := NewCmd(s.mockPrinter, s.mockProctorDClient, s.mockPrompter, func(code int) { exitCode = code })
 	testExecutionCmdOSExit.Run(&cobra.Command{}, args)
 
+	assert.Equal(s.T(), 1, exitCode)
+	s.mockProctorDClient.AssertNotCalled(s.T(), "ExecuteProc", mock.Anything, mock.Anything, mock.Anything)
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *ExecutionCmdTestSuite) TestExecutionCmdPromptsForMissingArgs() {
+	args := []string{"say-hello-world"}
+
+	procList := []proc_metadata.Metadata{
+		{
+			Name: "say-hello-world",
+			EnvVars: env.Vars{
+				Args: []env.VarMetadata{
+					{Name: "NAME", Description: "Name to greet", Required: true},
+					{Name: "COUNT", Type: env.IntType, Default: "1"},
+				},
+			},
+		},
+	}
+	s.mockProctorDClient.On("ListProcs").Return(procList, nil).Once()
+	s.mockPrompter.On("IsInteractive").Return(true).Once()
+
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Executing Proc", "say-hello-world"), color.Reset).Once()
+	s.mockPrinter.On("Println", "NAME: Name to greet", color.FgCyan).Once()
+	s.mockPrompter.On("Prompt", "NAME (required): ").Return("", nil).Once()
+	s.mockPrinter.On("Println", "NAME is required", color.FgRed).Once()
+	s.mockPrompter.On("Prompt", "NAME (required): ").Return("proctor", nil).Once()
+	s.mockPrompter.On("Prompt", "COUNT [1]: ").Return("many", nil).Once()
+	s.mockPrinter.On("Println", "COUNT must be an int", color.FgRed).Once()
+	s.mockPrompter.On("Prompt", "COUNT [1]: ").Return("", nil).Once()
+
+	s.mockPrinter.On("Println", "With Variables", color.FgMagenta).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "NAME", "proctor"), color.Reset).Once()
+
+	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", map[string]string{"NAME": "proctor"}, proctord_execution.Provenance{}).Return("executed-proc-name", nil).Once()
+	s.mockPrinter.On("Println", "Proc submitted for execution. \nStreaming logs:", color.FgGreen).Once()
+	s.mockProctorDClient.On("StreamProcLogs", "executed-proc-name", true).Return(nil).Once()
+	s.mockPrinter.On("Println", "Log stream of proc completed.", color.FgGreen).Once()
+	s.mockProctorDClient.On("GetDefinitiveProcExecutionStatus", "executed-proc-name").Return(utility.JobSucceeded, nil).Once()
+	s.mockPrinter.On("Println", "Proc execution successful", color.FgGreen).Once()
+
+	s.testExecutionCmd.Run(&cobra.Command{}, args)
+
+	s.mockPrompter.AssertExpectations(s.T())
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *ExecutionCmdTestSuite) TestExecutionCmdForMissingArgsWithoutTerminal() {
+	args := []string{"say-hello-world"}
+
+	procList := []proc_metadata.Metadata{
+		{
+			Name: "say-hello-world",
+			EnvVars: env.Vars{
+				Args: []env.VarMetadata{
+					{Name: "NAME", Description: "Name to greet", Required: true},
+					{Name: "GREETING", Description: "Greeting to use", Required: true},
+					{Name: "COUNT", Type: env.IntType, Default: "1"},
+				},
+			},
+		},
+	}
+	s.mockProctorDClient.On("ListProcs").Return(procList, nil).Once()
+	s.mockPrompter.On("IsInteractive").Return(false).Once()
+
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Executing Proc", "say-hello-world"), color.Reset).Once()
+	s.mockPrinter.On("Println", "Missing required proc args, pass them as KEY=VALUE or run from a terminal to be prompted:", color.FgRed).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "NAME", "Name to greet"), color.FgRed).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "GREETING", "Greeting to use"), color.FgRed).Once()
+
+	exitCode := 0
+	testExecutionCmdOSExit := NewCmd(s.mockPrinter, s.mockProctorDClient, s.mockPrompter, func(code int) { exitCode = code })
+	testExecutionCmdOSExit.Run(&cobra.Command{}, args)
+
+	assert.Equal(s.T(), 1, exitCode)
+	s.mockPrompter.AssertNotCalled(s.T(), "Prompt", mock.Anything)
+	s.mockProctorDClient.AssertNotCalled(s.T(), "ExecuteProc", mock.Anything, mock.Anything, mock.Anything)
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *ExecutionCmdTestSuite) TestExecutionCmdSubmitsWhenProcListingFails() {
+	args := []string{"say-hello-world"}
+
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Executing Proc", "say-hello-world"), color.Reset).Once()
+	s.mockPrinter.On("Println", "With No Variables", color.FgRed).Once()
+
+	procArgs := make(map[string]string)
+	s.mockProctorDClient.On("ListProcs").Return([]proc_metadata.Metadata{}, errors.New("test error")).Once()
+	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", procArgs, proctord_execution.Provenance{}).Return("", errors.New("invalid proc args")).Once()
+
+	s.mockPrinter.On("Println", "invalid proc args", color.FgRed).Once()
+
+	s.testExecutionCmd.Run(&cobra.Command{}, args)
+
 	s.mockProctorDClient.AssertExpectations(s.T())
 	s.mockPrinter.AssertExpectations(s.T())
 }
@@ -140,17 +450,18 @@ func (s *ExecutionCmdTestSuite) TestExecutionCmdForProctorDLogStreamingFailure()
 	s.mockPrinter.On("Println", "With No Variables", color.FgRed).Once()
 
 	procArgs := make(map[string]string)
-	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", procArgs).Return("executed-proc-name", nil).Once()
+	s.mockProctorDClient.On("ListProcs").Return([]proc_metadata.Metadata{}, nil).Once()
+	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", procArgs, proctord_execution.Provenance{}).Return("executed-proc-name", nil).Once()
 
 	s.mockPrinter.On("Println", "Proc submitted for execution. \nStreaming logs:", color.FgGreen).Once()
 
-	s.mockProctorDClient.On("StreamProcLogs", "executed-proc-name").Return(errors.New("error")).Once()
+	s.mockProctorDClient.On("StreamProcLogs", "executed-proc-name", true).Return(errors.New("error")).Once()
 	s.mockPrinter.On("Println", "Error Streaming Logs", color.FgRed).Once()
 
 	osExitFunc := func(exitCode int) {
 		assert.Equal(s.T(), 1, exitCode)
 	}
-	testExecutionCmdOSExit := NewCmd(s.mockPrinter, s.mockProctorDClient, osExitFunc)
+	testExecutionCmdOSExit := NewCmd(s.mockPrinter, s.mockProctorDClient, s.mockPrompter, osExitFunc)
 	testExecutionCmdOSExit.Run(&cobra.Command{}, args)
 
 	s.mockProctorDClient.AssertExpectations(s.T())
@@ -164,11 +475,12 @@ func (s *ExecutionCmdTestSuite) TestExecutionCmdForProctorDGetDefinitiveProcExec
 	s.mockPrinter.On("Println", "With No Variables", color.FgRed).Once()
 
 	procArgs := make(map[string]string)
-	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", procArgs).Return("executed-proc-name", nil).Once()
+	s.mockProctorDClient.On("ListProcs").Return([]proc_metadata.Metadata{}, nil).Once()
+	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", procArgs, proctord_execution.Provenance{}).Return("executed-proc-name", nil).Once()
 
 	s.mockPrinter.On("Println", "Proc submitted for execution. \nStreaming logs:", color.FgGreen).Once()
 
-	s.mockProctorDClient.On("StreamProcLogs", "executed-proc-name").Return(nil).Once()
+	s.mockProctorDClient.On("StreamProcLogs", "executed-proc-name", true).Return(nil).Once()
 	s.mockPrinter.On("Println", "Log stream of proc completed.", color.FgGreen).Once()
 
 	s.mockProctorDClient.On("GetDefinitiveProcExecutionStatus", "executed-proc-name").Return("", errors.New("some error")).Once()
@@ -177,7 +489,7 @@ func (s *ExecutionCmdTestSuite) TestExecutionCmdForProctorDGetDefinitiveProcExec
 	osExitFunc := func(exitCode int) {
 		assert.Equal(s.T(), 1, exitCode)
 	}
-	testExecutionCmdOSExit := NewCmd(s.mockPrinter, s.mockProctorDClient, osExitFunc)
+	testExecutionCmdOSExit := NewCmd(s.mockPrinter, s.mockProctorDClient, s.mockPrompter, osExitFunc)
 	testExecutionCmdOSExit.Run(&cobra.Command{}, args)
 
 	s.mockProctorDClient.AssertExpectations(s.T())
@@ -191,11 +503,12 @@ func (s *ExecutionCmdTestSuite) TestExecutionCmdForProctorDGetDefinitiveProcExec
 	s.mockPrinter.On("Println", "With No Variables", color.FgRed).Once()
 
 	procArgs := make(map[string]string)
-	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", procArgs).Return("executed-proc-name", nil).Once()
+	s.mockProctorDClient.On("ListProcs").Return([]proc_metadata.Metadata{}, nil).Once()
+	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", procArgs, proctord_execution.Provenance{}).Return("executed-proc-name", nil).Once()
 
 	s.mockPrinter.On("Println", "Proc submitted for execution. \nStreaming logs:", color.FgGreen).Once()
 
-	s.mockProctorDClient.On("StreamProcLogs", "executed-proc-name").Return(nil).Once()
+	s.mockProctorDClient.On("StreamProcLogs", "executed-proc-name", true).Return(nil).Once()
 	s.mockPrinter.On("Println", "Log stream of proc completed.", color.FgGreen).Once()
 
 	s.mockProctorDClient.On("GetDefinitiveProcExecutionStatus", "executed-proc-name").Return(utility.JobFailed, nil).Once()
@@ -204,7 +517,89 @@ func (s *ExecutionCmdTestSuite) TestExecutionCmdForProctorDGetDefinitiveProcExec
 	osExitFunc := func(exitCode int) {
 		assert.Equal(s.T(), 1, exitCode)
 	}
-	testExecutionCmdOSExit := NewCmd(s.mockPrinter, s.mockProctorDClient, osExitFunc)
+	testExecutionCmdOSExit := NewCmd(s.mockPrinter, s.mockProctorDClient, s.mockPrompter, osExitFunc)
+	testExecutionCmdOSExit.Run(&cobra.Command{}, args)
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *ExecutionCmdTestSuite) TestExecutionCmdForInterruptedLogStreamingWithCancellation() {
+	args := []string{"say-hello-world"}
+
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Executing Proc", "say-hello-world"), color.Reset).Once()
+	s.mockPrinter.On("Println", "With No Variables", color.FgRed).Once()
+
+	procArgs := make(map[string]string)
+	s.mockProctorDClient.On("ListProcs").Return([]proc_metadata.Metadata{}, nil).Once()
+	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", procArgs, proctord_execution.Provenance{}).Return("executed-proc-name", nil).Once()
+
+	s.mockPrinter.On("Println", "Proc submitted for execution. \nStreaming logs:", color.FgGreen).Once()
+
+	s.mockProctorDClient.On("StreamProcLogs", "executed-proc-name", true).Return(daemon.ErrStreamInterrupted).Once()
+	s.mockPrompter.On("Prompt", "Do you want to cancel execution executed-proc-name (y/N)? ").Return("y", nil).Once()
+	s.mockProctorDClient.On("CancelExecution", "executed-proc-name").Return(nil).Once()
+	s.mockPrinter.On("Println", "Successfully cancelled execution: executed-proc-name", color.FgGreen).Once()
+
+	osExitFunc := func(exitCode int) {
+		assert.Equal(s.T(), 1, exitCode)
+	}
+	testExecutionCmdOSExit := NewCmd(s.mockPrinter, s.mockProctorDClient, s.mockPrompter, osExitFunc)
+	testExecutionCmdOSExit.Run(&cobra.Command{}, args)
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrompter.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *ExecutionCmdTestSuite) TestExecutionCmdForInterruptedLogStreamingWithoutCancellation() {
+	args := []string{"say-hello-world"}
+
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Executing Proc", "say-hello-world"), color.Reset).Once()
+	s.mockPrinter.On("Println", "With No Variables", color.FgRed).Once()
+
+	procArgs := make(map[string]string)
+	s.mockProctorDClient.On("ListProcs").Return([]proc_metadata.Metadata{}, nil).Once()
+	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", procArgs, proctord_execution.Provenance{}).Return("executed-proc-name", nil).Once()
+
+	s.mockPrinter.On("Println", "Proc submitted for execution. \nStreaming logs:", color.FgGreen).Once()
+
+	s.mockProctorDClient.On("StreamProcLogs", "executed-proc-name", true).Return(daemon.ErrStreamInterrupted).Once()
+	s.mockPrompter.On("Prompt", "Do you want to cancel execution executed-proc-name (y/N)? ").Return("", nil).Once()
+	s.mockPrinter.On("Println", "Proc is still executing. To cancel it later run: proctor cancel executed-proc-name", color.Reset).Once()
+
+	osExitFunc := func(exitCode int) {
+		assert.Equal(s.T(), 1, exitCode)
+	}
+	testExecutionCmdOSExit := NewCmd(s.mockPrinter, s.mockProctorDClient, s.mockPrompter, osExitFunc)
+	testExecutionCmdOSExit.Run(&cobra.Command{}, args)
+
+	s.mockProctorDClient.AssertNotCalled(s.T(), "CancelExecution", mock.Anything)
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *ExecutionCmdTestSuite) TestExecutionCmdForCancelledProcExecution() {
+	args := []string{"say-hello-world"}
+
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Executing Proc", "say-hello-world"), color.Reset).Once()
+	s.mockPrinter.On("Println", "With No Variables", color.FgRed).Once()
+
+	procArgs := make(map[string]string)
+	s.mockProctorDClient.On("ListProcs").Return([]proc_metadata.Metadata{}, nil).Once()
+	s.mockProctorDClient.On("ExecuteProc", "say-hello-world", procArgs, proctord_execution.Provenance{}).Return("executed-proc-name", nil).Once()
+
+	s.mockPrinter.On("Println", "Proc submitted for execution. \nStreaming logs:", color.FgGreen).Once()
+
+	s.mockProctorDClient.On("StreamProcLogs", "executed-proc-name", true).Return(nil).Once()
+	s.mockPrinter.On("Println", "Log stream of proc completed.", color.FgGreen).Once()
+
+	s.mockProctorDClient.On("GetDefinitiveProcExecutionStatus", "executed-proc-name").Return(utility.JobCancelled, nil).Once()
+	s.mockPrinter.On("Println", "Proc execution cancelled", color.FgRed).Once()
+
+	osExitFunc := func(exitCode int) {
+		assert.Equal(s.T(), 1, exitCode)
+	}
+	testExecutionCmdOSExit := NewCmd(s.mockPrinter, s.mockProctorDClient, s.mockPrompter, osExitFunc)
 	testExecutionCmdOSExit.Run(&cobra.Command{}, args)
 
 	s.mockProctorDClient.AssertExpectations(s.T())
@@ -0,0 +1,114 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"proctor/daemon"
+	"proctor/io"
+	"proctor/proctord/jobs/execution"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(printer io.Printer, proctorDClient daemon.Client) *cobra.Command {
+	var userEmail, executionStatus, submissionStatus, reason, from, to, cursor, output string
+	var labelFlags []string
+	var limit int
+
+	historyCmd := &cobra.Command{
+		Use:     "history",
+		Short:   "List past proc executions",
+		Long:    "This command lists proc executions newest first, optionally of a single proc, filtered by user, status and time",
+		Example: "proctor history\nproctor history proc-one --status FAILED --from 12h\nproctor history --user user@example.com --from 2019-03-01T00:00:00Z --to 2019-03-02T00:00:00Z -o json\nproctor history --label ticket=OPS-123 --reason payments",
+		Args:    cobra.MaximumNArgs(1),
+
+		Run: func(cmd *cobra.Command, args []string) {
+			filter := execution.HistoryFilter{
+				UserEmail:        userEmail,
+				ExecutionStatus:  executionStatus,
+				SubmissionStatus: submissionStatus,
+				Reason:           reason,
+				Cursor:           cursor,
+				Limit:            limit,
+			}
+			if len(args) > 0 {
+				filter.JobName = args[0]
+			}
+
+			var err error
+			filter.Labels, err = execution.ParseLabels(labelFlags)
+			if err != nil {
+				printer.Println(fmt.Sprintf("Invalid --label: %s", err.Error()), color.FgRed)
+				return
+			}
+			filter.From, err = parseTime(from)
+			if err != nil {
+				printer.Println(fmt.Sprintf("Invalid --from: %s", err.Error()), color.FgRed)
+				return
+			}
+			filter.To, err = parseTime(to)
+			if err != nil {
+				printer.Println(fmt.Sprintf("Invalid --to: %s", err.Error()), color.FgRed)
+				return
+			}
+
+			history, err := proctorDClient.ListExecutions(filter)
+			if err != nil {
+				printer.Println(err.Error(), color.FgRed)
+				return
+			}
+
+			if output == "json" {
+				historyInJSON, err := json.MarshalIndent(history, "", "  ")
+				if err != nil {
+					printer.Println(err.Error(), color.FgRed)
+					return
+				}
+				printer.Println(string(historyInJSON), color.Reset)
+				return
+			}
+
+			printer.Println(fmt.Sprintf("%-50s %-30s %-30s %-12s %-25s %s", "ID", "PROC", "USER", "STATUS", "STARTED AT", "DURATION"), color.FgGreen)
+			for _, executionDetail := range history.Executions {
+				duration := (time.Duration(executionDetail.DurationSeconds) * time.Second).String()
+				printer.Println(fmt.Sprintf("%-50s %-30s %-30s %-12s %-25s %s", executionDetail.Name, executionDetail.JobName, executionDetail.UserEmail, executionDetail.ExecutionStatus, executionDetail.CreatedAt.Format("2006-01-02 15:04:05 MST"), duration), color.Reset)
+			}
+			if history.NextCursor != "" {
+				printer.Println(fmt.Sprintf("\nMore executions are available, run the same command with --cursor %s", history.NextCursor), color.FgYellow)
+			}
+		},
+	}
+
+	historyCmd.Flags().StringVarP(&userEmail, "user", "u", "", "Only executions by this user")
+	historyCmd.Flags().StringVarP(&executionStatus, "status", "s", "", "Only executions with this status: WAITING, RUNNING, SUCCEEDED, FAILED, CANCELLED")
+	historyCmd.Flags().StringVar(&submissionStatus, "submission-status", "", "Only executions with this submission status: success, client_error, server_error, forbidden")
+	historyCmd.Flags().StringArrayVar(&labelFlags, "label", nil, "Only executions with this label, as KEY=VALUE. Can be repeated")
+	historyCmd.Flags().StringVar(&reason, "reason", "", "Only executions whose reason contains this text")
+	historyCmd.Flags().StringVar(&from, "from", "", "Only executions started at or after this RFC3339 time, or this long ago, e.g. 12h")
+	historyCmd.Flags().StringVar(&to, "to", "", "Only executions started before this RFC3339 time, or this long ago, e.g. 30m")
+	historyCmd.Flags().StringVar(&cursor, "cursor", "", "Continue from a previous page")
+	historyCmd.Flags().IntVarP(&limit, "limit", "l", 0, "Number of executions per page, defaults to proctord's default")
+	historyCmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table, json")
+	return historyCmd
+}
+
+// parseTime accepts an RFC3339 time or a duration to go back from now
+func parseTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	ago, err := time.ParseDuration(value)
+	if err == nil {
+		t := time.Now().Add(-ago).Truncate(time.Second)
+		return &t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s is neither an RFC3339 time nor a duration", value)
+	}
+	return &t, nil
+}
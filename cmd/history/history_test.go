@@ -0,0 +1,133 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"proctor/daemon"
+	"proctor/io"
+	"proctor/proctord/jobs/execution"
+	"proctor/proctord/utility"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type HistoryCmdTestSuite struct {
+	suite.Suite
+	mockPrinter        *io.MockPrinter
+	mockProctorDClient *daemon.MockClient
+	testHistoryCmd     *cobra.Command
+}
+
+func (s *HistoryCmdTestSuite) SetupTest() {
+	s.mockPrinter = &io.MockPrinter{}
+	s.mockProctorDClient = &daemon.MockClient{}
+	s.testHistoryCmd = NewCmd(s.mockPrinter, s.mockProctorDClient)
+}
+
+func (s *HistoryCmdTestSuite) history() execution.History {
+	return execution.History{
+		Executions: []execution.Detail{
+			{
+				Name:            "proctor-ipsum-lorem",
+				JobName:         "say-hello-world",
+				UserEmail:       "runner@example.com",
+				ExecutionStatus: utility.JobFailed,
+				CreatedAt:       time.Date(2019, 3, 1, 22, 15, 0, 0, time.UTC),
+				DurationSeconds: 75,
+			},
+		},
+		NextCursor: "41",
+	}
+}
+
+func (s *HistoryCmdTestSuite) TestHistoryCmdHelp() {
+	assert.Equal(s.T(), "history", s.testHistoryCmd.Use)
+	assert.Equal(s.T(), "List past proc executions", s.testHistoryCmd.Short)
+}
+
+func (s *HistoryCmdTestSuite) TestHistoryCmdRun() {
+	from := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)
+	expectedFilter := execution.HistoryFilter{JobName: "say-hello-world", ExecutionStatus: utility.JobFailed, From: &from}
+	s.mockProctorDClient.On("ListExecutions", expectedFilter).Return(s.history(), nil).Once()
+
+	s.mockPrinter.On("Println", fmt.Sprintf("%-50s %-30s %-30s %-12s %-25s %s", "ID", "PROC", "USER", "STATUS", "STARTED AT", "DURATION"), color.FgGreen).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-50s %-30s %-30s %-12s %-25s %s", "proctor-ipsum-lorem", "say-hello-world", "runner@example.com", utility.JobFailed, "2019-03-01 22:15:00 UTC", "1m15s"), color.Reset).Once()
+	s.mockPrinter.On("Println", "\nMore executions are available, run the same command with --cursor 41", color.FgYellow).Once()
+
+	s.testHistoryCmd.Flags().Set("status", utility.JobFailed)
+	s.testHistoryCmd.Flags().Set("from", "2019-03-01T00:00:00Z")
+	s.testHistoryCmd.Run(s.testHistoryCmd, []string{"say-hello-world"})
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *HistoryCmdTestSuite) TestHistoryCmdRunWithJSONOutput() {
+	history := s.history()
+	s.mockProctorDClient.On("ListExecutions", execution.HistoryFilter{}).Return(history, nil).Once()
+
+	historyInJSON, err := json.MarshalIndent(history, "", "  ")
+	assert.NoError(s.T(), err)
+	s.mockPrinter.On("Println", string(historyInJSON), color.Reset).Once()
+
+	s.testHistoryCmd.Flags().Set("output", "json")
+	s.testHistoryCmd.Run(s.testHistoryCmd, []string{})
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *HistoryCmdTestSuite) TestHistoryCmdRunWithRelativeTime() {
+	s.mockProctorDClient.On("ListExecutions", mock.MatchedBy(func(filter execution.HistoryFilter) bool {
+		return filter.From != nil && time.Since(*filter.From) >= 12*time.Hour && time.Since(*filter.From) < 13*time.Hour
+	})).Return(execution.History{}, nil).Once()
+	s.mockPrinter.On("Println", mock.Anything, color.FgGreen).Once()
+
+	s.testHistoryCmd.Flags().Set("from", "12h")
+	s.testHistoryCmd.Run(s.testHistoryCmd, []string{})
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+}
+
+func (s *HistoryCmdTestSuite) TestHistoryCmdRunWithLabelsAndReason() {
+	expectedFilter := execution.HistoryFilter{Labels: map[string]string{"ticket": "OPS-123"}, Reason: "payments"}
+	s.mockProctorDClient.On("ListExecutions", expectedFilter).Return(execution.History{}, nil).Once()
+	s.mockPrinter.On("Println", mock.Anything, color.FgGreen).Once()
+
+	s.testHistoryCmd.Flags().Set("label", "ticket=OPS-123")
+	s.testHistoryCmd.Flags().Set("reason", "payments")
+	s.testHistoryCmd.Run(s.testHistoryCmd, []string{})
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+}
+
+func (s *HistoryCmdTestSuite) TestHistoryCmdRunForInvalidTime() {
+	s.mockPrinter.On("Println", "Invalid --to: yesterday is neither an RFC3339 time nor a duration", color.FgRed).Once()
+
+	s.testHistoryCmd.Flags().Set("to", "yesterday")
+	s.testHistoryCmd.Run(s.testHistoryCmd, []string{})
+
+	s.mockProctorDClient.AssertNotCalled(s.T(), "ListExecutions", mock.Anything)
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *HistoryCmdTestSuite) TestHistoryCmdRunProctorDClientFailure() {
+	s.mockProctorDClient.On("ListExecutions", execution.HistoryFilter{}).Return(execution.History{}, errors.New("test error")).Once()
+	s.mockPrinter.On("Println", "test error", color.FgRed).Once()
+
+	s.testHistoryCmd.Run(s.testHistoryCmd, []string{})
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func TestHistoryCmdTestSuite(t *testing.T) {
+	suite.Run(t, new(HistoryCmdTestSuite))
+}
@@ -0,0 +1,88 @@
+package login
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"proctor/config"
+	"proctor/daemon"
+	"proctor/io"
+	"proctor/proctord/tokens"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(printer io.Printer, proctorDClient daemon.Client, proctorConfigLoader config.Loader, prompter io.Prompter) *cobra.Command {
+	var emailID, name, scopes string
+	var expiresInDays int
+
+	loginCmd := &cobra.Command{
+		Use:     "login",
+		Short:   "Login to proctord",
+		Long:    "This command issues a personal access token from proctord and saves it in proctor config",
+		Example: "proctor login --email example@proctor.com --name laptop --scopes execute,schedule",
+		Args:    cobra.NoArgs,
+
+		Run: func(cmd *cobra.Command, args []string) {
+			if emailID == "" {
+				proctorConfig, configErr := proctorConfigLoader.Load()
+				if configErr == (config.ConfigError{}) {
+					emailID = proctorConfig.Email
+				}
+			}
+			if emailID == "" {
+				printer.Println("Email id is missing, pass it using --email", color.FgRed)
+				return
+			}
+
+			accessToken, err := prompter.PromptSecret(fmt.Sprintf("Access token for %s: ", emailID))
+			if err != nil {
+				printer.Println(fmt.Sprintf("Error reading access token: %s", err.Error()), color.FgRed)
+				return
+			}
+
+			newAccessToken := tokens.NewAccessToken{
+				Name:          name,
+				Scopes:        parseScopes(scopes),
+				ExpiresInDays: expiresInDays,
+			}
+			issuedAccessToken, err := proctorDClient.CreateAccessToken(emailID, accessToken, newAccessToken)
+			if err != nil {
+				printer.Println(err.Error(), color.FgRed)
+				return
+			}
+
+			err = proctorConfigLoader.Save(map[string]string{
+				config.EmailId:     emailID,
+				config.AccessToken: issuedAccessToken.Token,
+			})
+			if err != nil {
+				printer.Println(fmt.Sprintf("Error saving access token in config: %s", err.Error()), color.FgRed)
+				return
+			}
+
+			message := fmt.Sprintf("Logged in as %s", emailID)
+			if issuedAccessToken.ExpiresAt != nil {
+				message += fmt.Sprintf(", access token expires at %s", issuedAccessToken.ExpiresAt.Format("2006-01-02 15:04:05 MST"))
+			}
+			printer.Println(message, color.FgGreen)
+		},
+	}
+
+	loginCmd.Flags().StringVarP(&emailID, "email", "e", "", "Email id to login with, defaults to EMAIL_ID in config")
+	loginCmd.Flags().StringVarP(&name, "name", "n", "", "Name to identify the access token with")
+	loginCmd.Flags().StringVarP(&scopes, "scopes", "s", "", "Comma separated scopes of the access token: execute, schedule, publish. Defaults to all")
+	loginCmd.Flags().IntVar(&expiresInDays, "expires-in-days", 0, "Days after which the access token expires, defaults to the maximum allowed by proctord")
+
+	return loginCmd
+}
+
+func parseScopes(scopes string) []string {
+	parsedScopes := []string{}
+	for _, scope := range strings.Split(scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			parsedScopes = append(parsedScopes, scope)
+		}
+	}
+	return parsedScopes
+}
@@ -0,0 +1,93 @@
+package login
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"proctor/config"
+	"proctor/daemon"
+	"proctor/io"
+	"proctor/proctord/tokens"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type LoginCmdTestSuite struct {
+	suite.Suite
+	mockPrinter        *io.MockPrinter
+	mockProctorDClient *daemon.MockClient
+	mockConfigLoader   *config.MockLoader
+	mockPrompter       *io.MockPrompter
+	testLoginCmd       *cobra.Command
+}
+
+func (s *LoginCmdTestSuite) SetupTest() {
+	s.mockPrinter = &io.MockPrinter{}
+	s.mockProctorDClient = &daemon.MockClient{}
+	s.mockConfigLoader = &config.MockLoader{}
+	s.mockPrompter = &io.MockPrompter{}
+	s.testLoginCmd = NewCmd(s.mockPrinter, s.mockProctorDClient, s.mockConfigLoader, s.mockPrompter)
+}
+
+func (s *LoginCmdTestSuite) TestLoginCmdHelp() {
+	assert.Equal(s.T(), "Login to proctord", s.testLoginCmd.Short)
+	assert.Equal(s.T(), "This command issues a personal access token from proctord and saves it in proctor config", s.testLoginCmd.Long)
+	assert.Equal(s.T(), "proctor login --email example@proctor.com --name laptop --scopes execute,schedule", s.testLoginCmd.Example)
+}
+
+func (s *LoginCmdTestSuite) TestLoginCmdRun() {
+	expiresAt := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	newAccessToken := tokens.NewAccessToken{Name: "laptop", Scopes: []string{"execute", "schedule"}, ExpiresInDays: 7}
+
+	s.testLoginCmd.Flags().Set("email", "example@proctor.com")
+	s.testLoginCmd.Flags().Set("name", "laptop")
+	s.testLoginCmd.Flags().Set("scopes", "execute, schedule")
+	s.testLoginCmd.Flags().Set("expires-in-days", "7")
+
+	s.mockPrompter.On("PromptSecret", "Access token for example@proctor.com: ").Return("current-access-token", nil).Once()
+	s.mockProctorDClient.On("CreateAccessToken", "example@proctor.com", "current-access-token", newAccessToken).
+		Return(tokens.AccessToken{Name: "laptop", Token: "issued-access-token", ExpiresAt: &expiresAt}, nil).Once()
+	s.mockConfigLoader.On("Save", map[string]string{config.EmailId: "example@proctor.com", config.AccessToken: "issued-access-token"}).Return(nil).Once()
+	s.mockPrinter.On("Println", "Logged in as example@proctor.com, access token expires at 2019-01-01 00:00:00 UTC", color.FgGreen).Once()
+
+	s.testLoginCmd.Run(s.testLoginCmd, []string{})
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockConfigLoader.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *LoginCmdTestSuite) TestLoginCmdRunUsesEmailFromConfig() {
+	s.mockConfigLoader.On("Load").Return(config.ProctorConfig{Email: "config@proctor.com"}, config.ConfigError{}).Once()
+	s.mockPrompter.On("PromptSecret", "Access token for config@proctor.com: ").Return("current-access-token", nil).Once()
+	s.mockProctorDClient.On("CreateAccessToken", "config@proctor.com", "current-access-token", tokens.NewAccessToken{Scopes: []string{}}).
+		Return(tokens.AccessToken{Token: "issued-access-token"}, nil).Once()
+	s.mockConfigLoader.On("Save", map[string]string{config.EmailId: "config@proctor.com", config.AccessToken: "issued-access-token"}).Return(nil).Once()
+	s.mockPrinter.On("Println", "Logged in as config@proctor.com", color.FgGreen).Once()
+
+	s.testLoginCmd.Run(s.testLoginCmd, []string{})
+
+	s.mockConfigLoader.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *LoginCmdTestSuite) TestLoginCmdRunProctorDClientFailure() {
+	s.testLoginCmd.Flags().Set("email", "example@proctor.com")
+
+	s.mockPrompter.On("PromptSecret", "Access token for example@proctor.com: ").Return("invalid-access-token", nil).Once()
+	s.mockProctorDClient.On("CreateAccessToken", "example@proctor.com", "invalid-access-token", tokens.NewAccessToken{Scopes: []string{}}).
+		Return(tokens.AccessToken{}, errors.New("Unauthorized Access!!!")).Once()
+	s.mockPrinter.On("Println", "Unauthorized Access!!!", color.FgRed).Once()
+
+	s.testLoginCmd.Run(s.testLoginCmd, []string{})
+
+	s.mockConfigLoader.AssertNotCalled(s.T(), "Save")
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func TestLoginCmdTestSuite(t *testing.T) {
+	suite.Run(t, new(LoginCmdTestSuite))
+}
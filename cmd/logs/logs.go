@@ -0,0 +1,37 @@
+package logs
+
+import (
+	"github.com/fatih/color"
+	"proctor/daemon"
+	"proctor/io"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(printer io.Printer, proctorDClient daemon.Client, osExitFunc func(int)) *cobra.Command {
+	var follow bool
+
+	logsCmd := &cobra.Command{
+		Use:     "logs",
+		Short:   "Print the logs of a proc execution",
+		Long:    "This command prints the logs of any proc execution, including ones started by the scheduler or other users",
+		Example: "proctor logs proctor-7b3d9a2c-1f4e-4c6a-9f0b-2d8e5a1c3b7f\nproctor logs proctor-7b3d9a2c-1f4e-4c6a-9f0b-2d8e5a1c3b7f --follow",
+		Args:    cobra.ExactArgs(1),
+
+		Run: func(cmd *cobra.Command, args []string) {
+			executionID := args[0]
+
+			err := proctorDClient.StreamProcLogs(executionID, follow)
+			if err == daemon.ErrStreamInterrupted {
+				return
+			}
+			if err != nil {
+				printer.Println(err.Error(), color.FgRed)
+				osExitFunc(1)
+				return
+			}
+		},
+	}
+	logsCmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep streaming logs until the execution finishes")
+
+	return logsCmd
+}
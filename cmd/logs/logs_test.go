@@ -0,0 +1,76 @@
+package logs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fatih/color"
+	"proctor/daemon"
+	"proctor/io"
+	"proctor/proctord/utility"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type LogsCmdTestSuite struct {
+	suite.Suite
+	mockPrinter        *io.MockPrinter
+	mockProctorDClient *daemon.MockClient
+	exitCode           int
+	testLogsCmd        *cobra.Command
+}
+
+func (s *LogsCmdTestSuite) SetupTest() {
+	s.mockPrinter = &io.MockPrinter{}
+	s.mockProctorDClient = &daemon.MockClient{}
+	s.exitCode = 0
+	s.testLogsCmd = NewCmd(s.mockPrinter, s.mockProctorDClient, func(code int) { s.exitCode = code })
+}
+
+func (s *LogsCmdTestSuite) TestLogsCmdHelp() {
+	assert.Equal(s.T(), "Print the logs of a proc execution", s.testLogsCmd.Short)
+	assert.Equal(s.T(), "proctor logs proctor-7b3d9a2c-1f4e-4c6a-9f0b-2d8e5a1c3b7f\nproctor logs proctor-7b3d9a2c-1f4e-4c6a-9f0b-2d8e5a1c3b7f --follow", s.testLogsCmd.Example)
+}
+
+func (s *LogsCmdTestSuite) TestLogsCmd() {
+	s.mockProctorDClient.On("StreamProcLogs", "proctor-ipsum-lorem", false).Return(nil).Once()
+
+	s.testLogsCmd.Run(s.testLogsCmd, []string{"proctor-ipsum-lorem"})
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+	assert.Equal(s.T(), 0, s.exitCode)
+}
+
+func (s *LogsCmdTestSuite) TestLogsCmdWithFollow() {
+	s.mockProctorDClient.On("StreamProcLogs", "proctor-ipsum-lorem", true).Return(nil).Once()
+
+	s.testLogsCmd.Flags().Set("follow", "true")
+	s.testLogsCmd.Run(s.testLogsCmd, []string{"proctor-ipsum-lorem"})
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+	assert.Equal(s.T(), 0, s.exitCode)
+}
+
+func (s *LogsCmdTestSuite) TestLogsCmdForInterruptedStream() {
+	s.mockProctorDClient.On("StreamProcLogs", "proctor-ipsum-lorem", false).Return(daemon.ErrStreamInterrupted).Once()
+
+	s.testLogsCmd.Run(s.testLogsCmd, []string{"proctor-ipsum-lorem"})
+
+	s.mockPrinter.AssertNotCalled(s.T(), "Println", daemon.ErrStreamInterrupted.Error(), color.FgRed)
+	assert.Equal(s.T(), 0, s.exitCode)
+}
+
+func (s *LogsCmdTestSuite) TestLogsCmdProctorDClientFailure() {
+	s.mockProctorDClient.On("StreamProcLogs", "proctor-ipsum-lorem", false).Return(errors.New(utility.JobNotFoundError)).Once()
+	s.mockPrinter.On("Println", utility.JobNotFoundError, color.FgRed).Once()
+
+	s.testLogsCmd.Run(s.testLogsCmd, []string{"proctor-ipsum-lorem"})
+
+	s.mockPrinter.AssertExpectations(s.T())
+	assert.Equal(s.T(), 1, s.exitCode)
+}
+
+func TestLogsCmdTestSuite(t *testing.T) {
+	suite.Run(t, new(LogsCmdTestSuite))
+}
@@ -0,0 +1,57 @@
+package rerun
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"proctor/cmd/execution"
+	"proctor/daemon"
+	"proctor/io"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(printer io.Printer, proctorDClient daemon.Client, prompter io.Prompter, osExitFunc func(int)) *cobra.Command {
+	return &cobra.Command{
+		Use:     "rerun",
+		Short:   "Re-run a proc execution with the same arguments",
+		Long:    "This command submits a new execution of the proc of a previous execution, with its arguments. Individual arguments can be overridden",
+		Example: "proctor rerun proctor-7b3d9a2c-1f4e-4c6a-9f0b-2d8e5a1c3b7f\nproctor rerun proctor-7b3d9a2c-1f4e-4c6a-9f0b-2d8e5a1c3b7f SOME_VAR=foo",
+		Args:    cobra.MinimumNArgs(1),
+
+		Run: func(cmd *cobra.Command, args []string) {
+			executionID := args[0]
+			printer.Println(fmt.Sprintf("%-40s %-100s", "Re-running Execution", executionID), color.Reset)
+
+			overrides := make(map[string]string)
+			if len(args) > 1 {
+				printer.Println("With Overridden Variables", color.FgMagenta)
+				for _, v := range args[1:] {
+					arg := strings.SplitN(v, "=", 2)
+					if len(arg) < 2 {
+						printer.Println(fmt.Sprintf("%-40s %-100s", "\nIncorrect variable format\n", v), color.FgRed)
+						osExitFunc(1)
+						return
+					}
+
+					overrides[arg[0]] = arg[1]
+					printer.Println(fmt.Sprintf("%-40s %-100s", arg[0], arg[1]), color.Reset)
+				}
+			}
+
+			executedProcName, err := proctorDClient.RerunExecution(executionID, overrides)
+			if approvalPending, ok := err.(daemon.ApprovalPendingError); ok {
+				execution.PrintApprovalPending(printer, approvalPending)
+				return
+			}
+			if err != nil {
+				printer.Println(err.Error(), color.FgRed)
+				osExitFunc(1)
+				return
+			}
+
+			printer.Println(fmt.Sprintf("Proc submitted for execution as %s. \nStreaming logs:", executedProcName), color.FgGreen)
+			execution.Follow(printer, proctorDClient, prompter, osExitFunc, executedProcName)
+		},
+	}
+}
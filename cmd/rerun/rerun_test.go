@@ -0,0 +1,95 @@
+package rerun
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/fatih/color"
+	"proctor/daemon"
+	"proctor/io"
+	"proctor/proctord/utility"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type RerunCmdTestSuite struct {
+	suite.Suite
+	mockPrinter        *io.MockPrinter
+	mockProctorDClient *daemon.MockClient
+	mockPrompter       *io.MockPrompter
+	exitCode           int
+	testRerunCmd       *cobra.Command
+}
+
+func (s *RerunCmdTestSuite) SetupTest() {
+	s.mockPrinter = &io.MockPrinter{}
+	s.mockProctorDClient = &daemon.MockClient{}
+	s.mockPrompter = &io.MockPrompter{}
+	s.exitCode = 0
+	s.testRerunCmd = NewCmd(s.mockPrinter, s.mockProctorDClient, s.mockPrompter, func(code int) { s.exitCode = code })
+}
+
+func (s *RerunCmdTestSuite) TestRerunCmdHelp() {
+	assert.Equal(s.T(), "Re-run a proc execution with the same arguments", s.testRerunCmd.Short)
+	assert.Equal(s.T(), "proctor rerun proctor-7b3d9a2c-1f4e-4c6a-9f0b-2d8e5a1c3b7f\nproctor rerun proctor-7b3d9a2c-1f4e-4c6a-9f0b-2d8e5a1c3b7f SOME_VAR=foo", s.testRerunCmd.Example)
+}
+
+func (s *RerunCmdTestSuite) TestRerunCmdWithOverrides() {
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Re-running Execution", "proctor-ipsum-lorem"), color.Reset).Once()
+	s.mockPrinter.On("Println", "With Overridden Variables", color.FgMagenta).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "SOME_VAR", "foo=bar"), color.Reset).Once()
+	s.mockProctorDClient.On("RerunExecution", "proctor-ipsum-lorem", map[string]string{"SOME_VAR": "foo=bar"}).Return("proctor-dolor-sit", nil).Once()
+	s.mockPrinter.On("Println", "Proc submitted for execution as proctor-dolor-sit. \nStreaming logs:", color.FgGreen).Once()
+	s.mockProctorDClient.On("StreamProcLogs", "proctor-dolor-sit", true).Return(nil).Once()
+	s.mockPrinter.On("Println", "Log stream of proc completed.", color.FgGreen).Once()
+	s.mockProctorDClient.On("GetDefinitiveProcExecutionStatus", "proctor-dolor-sit").Return(utility.JobSucceeded, nil).Once()
+	s.mockPrinter.On("Println", "Proc execution successful", color.FgGreen).Once()
+
+	s.testRerunCmd.Run(&cobra.Command{}, []string{"proctor-ipsum-lorem", "SOME_VAR=foo=bar"})
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+	assert.Equal(s.T(), 0, s.exitCode)
+}
+
+func (s *RerunCmdTestSuite) TestRerunCmdForIncorrectOverrideFormat() {
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Re-running Execution", "proctor-ipsum-lorem"), color.Reset).Once()
+	s.mockPrinter.On("Println", "With Overridden Variables", color.FgMagenta).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "\nIncorrect variable format\n", "SOME_VAR"), color.FgRed).Once()
+
+	s.testRerunCmd.Run(&cobra.Command{}, []string{"proctor-ipsum-lorem", "SOME_VAR"})
+
+	s.mockProctorDClient.AssertNotCalled(s.T(), "RerunExecution", "proctor-ipsum-lorem", map[string]string{})
+	s.mockPrinter.AssertExpectations(s.T())
+	assert.Equal(s.T(), 1, s.exitCode)
+}
+
+func (s *RerunCmdTestSuite) TestRerunCmdProctorDClientFailure() {
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Re-running Execution", "proctor-ipsum-lorem"), color.Reset).Once()
+	s.mockProctorDClient.On("RerunExecution", "proctor-ipsum-lorem", map[string]string{}).Return("", errors.New(utility.JobNotFoundError)).Once()
+	s.mockPrinter.On("Println", utility.JobNotFoundError, color.FgRed).Once()
+
+	s.testRerunCmd.Run(&cobra.Command{}, []string{"proctor-ipsum-lorem"})
+
+	s.mockPrinter.AssertExpectations(s.T())
+	assert.Equal(s.T(), 1, s.exitCode)
+}
+
+func (s *RerunCmdTestSuite) TestRerunCmdForProcRequiringApproval() {
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Re-running Execution", "proctor-ipsum-lorem"), color.Reset).Once()
+	s.mockProctorDClient.On("RerunExecution", "proctor-ipsum-lorem", map[string]string{}).Return("", daemon.ApprovalPendingError{ApprovalID: "approval-id"}).Once()
+	s.mockPrinter.On("Println", "Proc requires approval, execution is pending approval request: approval-id", color.FgYellow).Once()
+	s.mockPrinter.On("Println", "An approver can approve it with: proctor approvals approve approval-id", color.Reset).Once()
+
+	s.testRerunCmd.Run(&cobra.Command{}, []string{"proctor-ipsum-lorem"})
+
+	s.mockProctorDClient.AssertNotCalled(s.T(), "StreamProcLogs", "", true)
+	s.mockPrinter.AssertExpectations(s.T())
+	assert.Equal(s.T(), 0, s.exitCode)
+}
+
+func TestRerunCmdTestSuite(t *testing.T) {
+	suite.Run(t, new(RerunCmdTestSuite))
+}
@@ -4,16 +4,31 @@ import (
 	"fmt"
 	"proctor/cmd/schedule/remove"
 	"os"
+	"time"
 
+	"proctor/cmd/approvals"
+	approvals_approve "proctor/cmd/approvals/approve"
+	approvals_list "proctor/cmd/approvals/list"
+	approvals_reject "proctor/cmd/approvals/reject"
+	"proctor/cmd/cancel"
 	"proctor/cmd/config"
 	"proctor/cmd/config/view"
 	"proctor/cmd/description"
 	"proctor/cmd/execution"
+	"proctor/cmd/history"
 	"proctor/cmd/list"
+	"proctor/cmd/login"
+	"proctor/cmd/logs"
+	"proctor/cmd/rerun"
 	"proctor/cmd/schedule"
 	schedule_list "proctor/cmd/schedule/list"
 	schedule_describe "proctor/cmd/schedule/describe"
+	"proctor/cmd/status"
+	"proctor/cmd/token"
+	token_list "proctor/cmd/token/list"
+	token_revoke "proctor/cmd/token/revoke"
 	"proctor/cmd/version"
+	proctor_config "proctor/config"
 	"proctor/daemon"
 	"proctor/io"
 
@@ -29,7 +44,7 @@ var (
 	}
 )
 
-func Execute(printer io.Printer, proctorDClient daemon.Client, githubClient github.LatestReleaseFetcher) {
+func Execute(printer io.Printer, proctorDClient daemon.Client, githubClient github.LatestReleaseFetcher, proctorConfigLoader proctor_config.Loader, prompter io.Prompter) {
 	versionCmd := version.NewCmd(printer, githubClient)
 	rootCmd.AddCommand(versionCmd)
 
@@ -37,9 +52,24 @@ func Execute(printer io.Printer, proctorDClient daemon.Client, githubClient gith
 	rootCmd.AddCommand(descriptionCmd)
 
 	//TODO: Test execution.NewCmd is given os.Exit function as params
-	executionCmd := execution.NewCmd(printer, proctorDClient, os.Exit)
+	executionCmd := execution.NewCmd(printer, proctorDClient, prompter, os.Exit)
 	rootCmd.AddCommand(executionCmd)
 
+	cancelCmd := cancel.NewCmd(printer, proctorDClient)
+	rootCmd.AddCommand(cancelCmd)
+
+	logsCmd := logs.NewCmd(printer, proctorDClient, os.Exit)
+	rootCmd.AddCommand(logsCmd)
+
+	rerunCmd := rerun.NewCmd(printer, proctorDClient, prompter, os.Exit)
+	rootCmd.AddCommand(rerunCmd)
+
+	statusCmd := status.NewCmd(printer, proctorDClient, 2*time.Second)
+	rootCmd.AddCommand(statusCmd)
+
+	historyCmd := history.NewCmd(printer, proctorDClient)
+	rootCmd.AddCommand(historyCmd)
+
 	listCmd := list.NewCmd(printer, proctorDClient)
 	rootCmd.AddCommand(listCmd)
 
@@ -48,6 +78,25 @@ func Execute(printer io.Printer, proctorDClient daemon.Client, githubClient gith
 	rootCmd.AddCommand(configCmd)
 	configCmd.AddCommand(configShowCmd)
 
+	loginCmd := login.NewCmd(printer, proctorDClient, proctorConfigLoader, prompter)
+	rootCmd.AddCommand(loginCmd)
+
+	tokenCmd := token.NewCmd()
+	rootCmd.AddCommand(tokenCmd)
+	tokenListCmd := token_list.NewCmd(printer, proctorDClient)
+	tokenCmd.AddCommand(tokenListCmd)
+	tokenRevokeCmd := token_revoke.NewCmd(printer, proctorDClient)
+	tokenCmd.AddCommand(tokenRevokeCmd)
+
+	approvalsCmd := approvals.NewCmd()
+	rootCmd.AddCommand(approvalsCmd)
+	approvalsListCmd := approvals_list.NewCmd(printer, proctorDClient)
+	approvalsCmd.AddCommand(approvalsListCmd)
+	approvalsApproveCmd := approvals_approve.NewCmd(printer, proctorDClient)
+	approvalsCmd.AddCommand(approvalsApproveCmd)
+	approvalsRejectCmd := approvals_reject.NewCmd(printer, proctorDClient)
+	approvalsCmd.AddCommand(approvalsRejectCmd)
+
 	scheduleCmd := schedule.NewCmd(printer, proctorDClient)
 	rootCmd.AddCommand(scheduleCmd)
 	scheduleListCmd := schedule_list.NewCmd(printer, proctorDClient)
@@ -3,6 +3,7 @@ package cmd
 import (
 	"testing"
 
+	"proctor/config"
 	"proctor/daemon"
 	"proctor/io"
 	"github.com/spf13/cobra"
@@ -11,7 +12,7 @@ import (
 )
 
 func TestRootCmdUsage(t *testing.T) {
-	Execute(&io.MockPrinter{}, &daemon.MockClient{}, &github.MockClient{})
+	Execute(&io.MockPrinter{}, &daemon.MockClient{}, &github.MockClient{}, &config.MockLoader{}, &io.MockPrompter{})
 
 	assert.Equal(t, "proctor", rootCmd.Use)
 	assert.Equal(t, "A command-line interface to run procs", rootCmd.Short)
@@ -28,7 +29,7 @@ func contains(commands []*cobra.Command, commandName string) bool {
 }
 
 func TestRootCmdSubCommands(t *testing.T) {
-	Execute(&io.MockPrinter{}, &daemon.MockClient{}, &github.MockClient{})
+	Execute(&io.MockPrinter{}, &daemon.MockClient{}, &github.MockClient{}, &config.MockLoader{}, &io.MockPrompter{})
 
 	assert.True(t, contains(rootCmd.Commands(), "describe"))
 	assert.True(t, contains(rootCmd.Commands(), "execute"))
@@ -37,4 +38,12 @@ func TestRootCmdSubCommands(t *testing.T) {
 	assert.True(t, contains(rootCmd.Commands(), "config"))
 	assert.True(t, contains(rootCmd.Commands(), "version"))
 	assert.True(t, contains(rootCmd.Commands(), "schedule"))
+	assert.True(t, contains(rootCmd.Commands(), "login"))
+	assert.True(t, contains(rootCmd.Commands(), "token"))
+	assert.True(t, contains(rootCmd.Commands(), "cancel"))
+	assert.True(t, contains(rootCmd.Commands(), "rerun"))
+	assert.True(t, contains(rootCmd.Commands(), "logs"))
+	assert.True(t, contains(rootCmd.Commands(), "status"))
+	assert.True(t, contains(rootCmd.Commands(), "history"))
+	assert.True(t, contains(rootCmd.Commands(), "approvals"))
 }
@@ -3,14 +3,17 @@ package schedule
 import (
 	"fmt"
 	"github.com/fatih/color"
+	"os"
+	"proctor/cmd/arguments"
 	"proctor/daemon"
 	"proctor/io"
 	"github.com/spf13/cobra"
-	"strings"
 )
 
 func NewCmd(printer io.Printer,proctorDClient daemon.Client) *cobra.Command {
-	return &cobra.Command{
+	var argumentFlags arguments.Flags
+
+	scheduleCmd := &cobra.Command{
 		Use:     "schedule",
 		Short:   "Create scheduled jobs",
 		Long:    "This command helps to create scheduled jobs",
@@ -40,25 +43,12 @@ func NewCmd(printer io.Printer,proctorDClient daemon.Client) *cobra.Command {
 				printer.Println(err.Error(),color.FgRed)
 			}
 
-			jobArgs := make(map[string]string)
-			if len(args) > 1 {
-				printer.Println("With Variables", color.FgMagenta)
-				for _, v := range args[1:] {
-					arg := strings.Split(v, "=")
-
-					if len(arg) < 2 {
-						printer.Println(fmt.Sprintf("%-40s %-100s", "\nIncorrect variable format\n", v), color.FgRed)
-						continue
-					}
-
-					combinedArgValue := strings.Join(arg[1:], "=")
-					jobArgs[arg[0]] = combinedArgValue
-
-					printer.Println(fmt.Sprintf("%-40s %-100s", arg[0], combinedArgValue), color.Reset)
-				}
-			} else {
-				printer.Println("With No Variables", color.FgRed)
+			jobArgs, malformedArgs, err := argumentFlags.Parse(args[1:], os.Stdin)
+			if err != nil {
+				printer.Println(err.Error(), color.FgRed)
+				return
 			}
+			argumentFlags.Print(printer, jobArgs, malformedArgs)
 
 			scheduledJobID, err := proctorDClient.ScheduleJob(procName, tags, time, notificationEmails, group, jobArgs)
 			if err != nil {
@@ -69,5 +59,8 @@ func NewCmd(printer io.Printer,proctorDClient daemon.Client) *cobra.Command {
 			printer.Println(fmt.Sprintf("Scheduled Job UUID : %s", scheduledJobID), color.FgGreen)
 		},
 	}
+	argumentFlags.Register(scheduleCmd)
+
+	return scheduleCmd
 }
 
@@ -0,0 +1,108 @@
+package status
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/fatih/color"
+	"proctor/daemon"
+	"proctor/io"
+	"proctor/proctord/jobs/execution"
+	"proctor/proctord/utility"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(printer io.Printer, proctorDClient daemon.Client, watchInterval time.Duration) *cobra.Command {
+	var watch bool
+
+	statusCmd := &cobra.Command{
+		Use:     "status",
+		Short:   "Show details of a proc execution",
+		Long:    "This command shows who ran a proc execution, with which args and image, its status and how long it ran",
+		Example: "proctor status proctor-7b3d9a2c-1f4e-4c6a-9f0b-2d8e5a1c3b7f\nproctor status proctor-7b3d9a2c-1f4e-4c6a-9f0b-2d8e5a1c3b7f --watch",
+		Args:    cobra.ExactArgs(1),
+
+		Run: func(cmd *cobra.Command, args []string) {
+			executionID := args[0]
+			executionDetail, err := proctorDClient.GetExecution(executionID)
+			if err != nil {
+				printer.Println(err.Error(), color.FgRed)
+				return
+			}
+
+			printDetail(printer, executionDetail)
+			if !watch {
+				return
+			}
+
+			for !execution.IsFinished(executionDetail.ExecutionStatus) {
+				time.Sleep(watchInterval)
+
+				latestDetail, err := proctorDClient.GetExecution(executionID)
+				if err != nil {
+					printer.Println(err.Error(), color.FgRed)
+					return
+				}
+				if latestDetail.ExecutionStatus != executionDetail.ExecutionStatus {
+					printer.Println(fmt.Sprintf("%-40s %-100s", "Execution Status", latestDetail.ExecutionStatus), statusColor(latestDetail.ExecutionStatus))
+				}
+				executionDetail = latestDetail
+			}
+			printer.Println(fmt.Sprintf("%-40s %-100s", "Duration", formatDuration(executionDetail.DurationSeconds)), color.Reset)
+		},
+	}
+
+	statusCmd.Flags().BoolVarP(&watch, "watch", "w", false, "Keep polling until the execution finishes")
+	return statusCmd
+}
+
+func printDetail(printer io.Printer, executionDetail execution.Detail) {
+	printer.Println(fmt.Sprintf("%-40s %-100s", "ID", executionDetail.Name), color.Reset)
+	printer.Println(fmt.Sprintf("%-40s %-100s", "Proc", executionDetail.JobName), color.Reset)
+	printer.Println(fmt.Sprintf("%-40s %-100s", "Image", executionDetail.ImageName), color.Reset)
+	printer.Println(fmt.Sprintf("%-40s %-100s", "User", executionDetail.UserEmail), color.Reset)
+	printer.Println(fmt.Sprintf("%-40s %-100s", "Submission Status", executionDetail.SubmissionStatus), color.Reset)
+	printer.Println(fmt.Sprintf("%-40s %-100s", "Execution Status", executionDetail.ExecutionStatus), statusColor(executionDetail.ExecutionStatus))
+	if executionDetail.CancelledBy != "" {
+		printer.Println(fmt.Sprintf("%-40s %-100s", "Cancelled By", executionDetail.CancelledBy), color.Reset)
+	}
+	if executionDetail.ApprovedBy != "" {
+		printer.Println(fmt.Sprintf("%-40s %-100s", "Approved By", executionDetail.ApprovedBy), color.Reset)
+	}
+	if executionDetail.ParentName != "" {
+		printer.Println(fmt.Sprintf("%-40s %-100s", "Rerun Of", executionDetail.ParentName), color.Reset)
+	}
+	printer.Println(fmt.Sprintf("%-40s %-100s", "Started At", formatTime(executionDetail.CreatedAt)), color.Reset)
+	printer.Println(fmt.Sprintf("%-40s %-100s", "Updated At", formatTime(executionDetail.UpdatedAt)), color.Reset)
+	printer.Println(fmt.Sprintf("%-40s %-100s", "Duration", formatDuration(executionDetail.DurationSeconds)), color.Reset)
+
+	printer.Println("\nArgs", color.FgMagenta)
+	argNames := []string{}
+	for name := range executionDetail.Args {
+		argNames = append(argNames, name)
+	}
+	sort.Strings(argNames)
+	for _, name := range argNames {
+		printer.Println(fmt.Sprintf("%-40s %-100s", name, executionDetail.Args[name]), color.Reset)
+	}
+}
+
+func statusColor(executionStatus string) color.Attribute {
+	switch executionStatus {
+	case utility.JobSucceeded:
+		return color.FgGreen
+	case utility.JobFailed, utility.JobCancelled:
+		return color.FgRed
+	default:
+		return color.FgYellow
+	}
+}
+
+func formatTime(t time.Time) string {
+	return t.Format("2006-01-02 15:04:05 MST")
+}
+
+func formatDuration(durationSeconds int64) string {
+	return (time.Duration(durationSeconds) * time.Second).String()
+}
@@ -0,0 +1,106 @@
+package status
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"proctor/daemon"
+	"proctor/io"
+	"proctor/proctord/jobs/execution"
+	"proctor/proctord/utility"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusCmdTestSuite struct {
+	suite.Suite
+	mockPrinter        *io.MockPrinter
+	mockProctorDClient *daemon.MockClient
+	testStatusCmd      *cobra.Command
+}
+
+func (s *StatusCmdTestSuite) SetupTest() {
+	s.mockPrinter = &io.MockPrinter{}
+	s.mockProctorDClient = &daemon.MockClient{}
+	s.testStatusCmd = NewCmd(s.mockPrinter, s.mockProctorDClient, time.Millisecond)
+}
+
+func executionDetail(executionStatus string, durationSeconds int64) execution.Detail {
+	createdAt := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	return execution.Detail{
+		Name:             "proctor-ipsum-lorem",
+		JobName:          "say-hello-world",
+		ImageName:        "img",
+		UserEmail:        "runner@example.com",
+		Args:             map[string]string{"SAMPLE_ARG_TWO": "variable", "SAMPLE_ARG_ONE": "any"},
+		SubmissionStatus: utility.JobSubmissionSuccess,
+		ExecutionStatus:  executionStatus,
+		CreatedAt:        createdAt,
+		UpdatedAt:        createdAt.Add(time.Duration(durationSeconds) * time.Second),
+		DurationSeconds:  durationSeconds,
+	}
+}
+
+func (s *StatusCmdTestSuite) expectDetail(executionStatus string, statusColor color.Attribute, updatedAt, duration string) {
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "ID", "proctor-ipsum-lorem"), color.Reset).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Proc", "say-hello-world"), color.Reset).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Image", "img"), color.Reset).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "User", "runner@example.com"), color.Reset).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Submission Status", utility.JobSubmissionSuccess), color.Reset).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Execution Status", executionStatus), statusColor).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Started At", "2019-03-01 10:00:00 UTC"), color.Reset).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Updated At", updatedAt), color.Reset).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Duration", duration), color.Reset).Once()
+	s.mockPrinter.On("Println", "\nArgs", color.FgMagenta).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "SAMPLE_ARG_ONE", "any"), color.Reset).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "SAMPLE_ARG_TWO", "variable"), color.Reset).Once()
+}
+
+func (s *StatusCmdTestSuite) TestStatusCmdHelp() {
+	assert.Equal(s.T(), "status", s.testStatusCmd.Use)
+	assert.Equal(s.T(), "Show details of a proc execution", s.testStatusCmd.Short)
+	assert.Equal(s.T(), "This command shows who ran a proc execution, with which args and image, its status and how long it ran", s.testStatusCmd.Long)
+}
+
+func (s *StatusCmdTestSuite) TestStatusCmdRun() {
+	s.mockProctorDClient.On("GetExecution", "proctor-ipsum-lorem").Return(executionDetail(utility.JobSucceeded, 90), nil).Once()
+	s.expectDetail(utility.JobSucceeded, color.FgGreen, "2019-03-01 10:01:30 UTC", "1m30s")
+
+	s.testStatusCmd.Run(s.testStatusCmd, []string{"proctor-ipsum-lorem"})
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *StatusCmdTestSuite) TestStatusCmdRunWithWatch() {
+	s.mockProctorDClient.On("GetExecution", "proctor-ipsum-lorem").Return(executionDetail(utility.JobWaiting, 5), nil).Once()
+	s.mockProctorDClient.On("GetExecution", "proctor-ipsum-lorem").Return(executionDetail(utility.JobWaiting, 10), nil).Once()
+	s.mockProctorDClient.On("GetExecution", "proctor-ipsum-lorem").Return(executionDetail(utility.JobFailed, 12), nil).Once()
+	s.expectDetail(utility.JobWaiting, color.FgYellow, "2019-03-01 10:00:05 UTC", "5s")
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Execution Status", utility.JobFailed), color.FgRed).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-40s %-100s", "Duration", "12s"), color.Reset).Once()
+
+	s.testStatusCmd.Flags().Set("watch", "true")
+	s.testStatusCmd.Run(s.testStatusCmd, []string{"proctor-ipsum-lorem"})
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *StatusCmdTestSuite) TestStatusCmdRunProctorDClientFailure() {
+	s.mockProctorDClient.On("GetExecution", "proctor-ipsum-lorem").Return(execution.Detail{}, errors.New(utility.JobNotFoundError)).Once()
+	s.mockPrinter.On("Println", utility.JobNotFoundError, color.FgRed).Once()
+
+	s.testStatusCmd.Run(s.testStatusCmd, []string{"proctor-ipsum-lorem"})
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func TestStatusCmdTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusCmdTestSuite))
+}
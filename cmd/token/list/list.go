@@ -0,0 +1,45 @@
+package list
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"proctor/daemon"
+	"proctor/io"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(printer io.Printer, proctorDClient daemon.Client) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Short:   "List access tokens",
+		Long:    "This command helps to list access tokens issued to you",
+		Example: "proctor token list",
+
+		Run: func(cmd *cobra.Command, args []string) {
+			accessTokens, err := proctorDClient.ListAccessTokens()
+			if err != nil {
+				printer.Println(err.Error(), color.FgRed)
+				return
+			}
+
+			printer.Println(fmt.Sprintf("%-8s %-20s %-25s %-22s %s", "ID", "NAME", "SCOPES", "EXPIRES AT", "LAST USED AT"), color.FgGreen)
+			for _, accessToken := range accessTokens {
+				scopes := strings.Join(accessToken.Scopes, ",")
+				if scopes == "" {
+					scopes = "all"
+				}
+				printer.Println(fmt.Sprintf("%-8d %-20s %-25s %-22s %s", accessToken.ID, accessToken.Name, scopes, formatTime(accessToken.ExpiresAt), formatTime(accessToken.LastUsedAt)), color.Reset)
+			}
+		},
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format("2006-01-02 15:04 MST")
+}
@@ -0,0 +1,66 @@
+package list
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"proctor/daemon"
+	"proctor/io"
+	"proctor/proctord/tokens"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type TokenListCmdTestSuite struct {
+	suite.Suite
+	mockPrinter        *io.MockPrinter
+	mockProctorDClient *daemon.MockClient
+	testTokenListCmd   *cobra.Command
+}
+
+func (s *TokenListCmdTestSuite) SetupTest() {
+	s.mockPrinter = &io.MockPrinter{}
+	s.mockProctorDClient = &daemon.MockClient{}
+	s.testTokenListCmd = NewCmd(s.mockPrinter, s.mockProctorDClient)
+}
+
+func (s *TokenListCmdTestSuite) TestTokenListCmdHelp() {
+	assert.Equal(s.T(), "List access tokens", s.testTokenListCmd.Short)
+	assert.Equal(s.T(), "This command helps to list access tokens issued to you", s.testTokenListCmd.Long)
+	assert.Equal(s.T(), "proctor token list", s.testTokenListCmd.Example)
+}
+
+func (s *TokenListCmdTestSuite) TestTokenListCmdRun() {
+	expiresAt := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	accessTokens := []tokens.AccessToken{
+		{ID: 1, Name: "laptop", Scopes: []string{}, ExpiresAt: &expiresAt},
+		{ID: 2, Name: "ci", Scopes: []string{"execute", "schedule"}, ExpiresAt: &expiresAt, LastUsedAt: &expiresAt},
+	}
+
+	s.mockProctorDClient.On("ListAccessTokens").Return(accessTokens, nil).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-8s %-20s %-25s %-22s %s", "ID", "NAME", "SCOPES", "EXPIRES AT", "LAST USED AT"), color.FgGreen).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-8d %-20s %-25s %-22s %s", 1, "laptop", "all", "2019-01-01 00:00 UTC", "-"), color.Reset).Once()
+	s.mockPrinter.On("Println", fmt.Sprintf("%-8d %-20s %-25s %-22s %s", 2, "ci", "execute,schedule", "2019-01-01 00:00 UTC", "2019-01-01 00:00 UTC"), color.Reset).Once()
+
+	s.testTokenListCmd.Run(&cobra.Command{}, []string{})
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *TokenListCmdTestSuite) TestTokenListCmdRunProctorDClientFailure() {
+	s.mockProctorDClient.On("ListAccessTokens").Return([]tokens.AccessToken{}, errors.New("error")).Once()
+	s.mockPrinter.On("Println", "error", color.FgRed).Once()
+
+	s.testTokenListCmd.Run(&cobra.Command{}, []string{})
+
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func TestTokenListCmdTestSuite(t *testing.T) {
+	suite.Run(t, new(TokenListCmdTestSuite))
+}
@@ -0,0 +1,30 @@
+package revoke
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"proctor/daemon"
+	"proctor/io"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(printer io.Printer, proctorDClient daemon.Client) *cobra.Command {
+	return &cobra.Command{
+		Use:     "revoke",
+		Short:   "Revoke access token",
+		Long:    "This command helps to revoke an access token issued to you",
+		Example: "proctor token revoke 42",
+		Args:    cobra.ExactArgs(1),
+
+		Run: func(cmd *cobra.Command, args []string) {
+			accessTokenID := args[0]
+			err := proctorDClient.RevokeAccessToken(accessTokenID)
+			if err != nil {
+				printer.Println(err.Error(), color.FgRed)
+				return
+			}
+			printer.Println(fmt.Sprintf("Successfully revoked access token ID: %s", accessTokenID), color.FgGreen)
+		},
+	}
+}
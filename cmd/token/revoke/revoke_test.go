@@ -0,0 +1,55 @@
+package revoke
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fatih/color"
+	"proctor/daemon"
+	"proctor/io"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type TokenRevokeCmdTestSuite struct {
+	suite.Suite
+	mockPrinter        *io.MockPrinter
+	mockProctorDClient *daemon.MockClient
+	testTokenRevokeCmd *cobra.Command
+}
+
+func (s *TokenRevokeCmdTestSuite) SetupTest() {
+	s.mockPrinter = &io.MockPrinter{}
+	s.mockProctorDClient = &daemon.MockClient{}
+	s.testTokenRevokeCmd = NewCmd(s.mockPrinter, s.mockProctorDClient)
+}
+
+func (s *TokenRevokeCmdTestSuite) TestTokenRevokeCmdHelp() {
+	assert.Equal(s.T(), "Revoke access token", s.testTokenRevokeCmd.Short)
+	assert.Equal(s.T(), "This command helps to revoke an access token issued to you", s.testTokenRevokeCmd.Long)
+	assert.Equal(s.T(), "proctor token revoke 42", s.testTokenRevokeCmd.Example)
+}
+
+func (s *TokenRevokeCmdTestSuite) TestTokenRevokeCmdRun() {
+	s.mockProctorDClient.On("RevokeAccessToken", "42").Return(nil).Once()
+	s.mockPrinter.On("Println", "Successfully revoked access token ID: 42", color.FgGreen).Once()
+
+	s.testTokenRevokeCmd.Run(&cobra.Command{}, []string{"42"})
+
+	s.mockProctorDClient.AssertExpectations(s.T())
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func (s *TokenRevokeCmdTestSuite) TestTokenRevokeCmdRunProctorDClientFailure() {
+	s.mockProctorDClient.On("RevokeAccessToken", "42").Return(errors.New("Access token not found")).Once()
+	s.mockPrinter.On("Println", "Access token not found", color.FgRed).Once()
+
+	s.testTokenRevokeCmd.Run(&cobra.Command{}, []string{"42"})
+
+	s.mockPrinter.AssertExpectations(s.T())
+}
+
+func TestTokenRevokeCmdTestSuite(t *testing.T) {
+	suite.Run(t, new(TokenRevokeCmdTestSuite))
+}
@@ -0,0 +1,13 @@
+package token
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "token",
+		Short: "Manage access tokens",
+		Long:  "This command helps to list and revoke access tokens issued by proctord",
+	}
+}
@@ -2,13 +2,17 @@ package config
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sort"
 	"time"
 
 	"proctor/proctord/utility"
 	"github.com/pkg/errors"
 
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -39,6 +43,7 @@ func (c *ConfigError) RootError() error {
 
 type Loader interface {
 	Load() (ProctorConfig, ConfigError)
+	Save(map[string]string) error
 }
 
 type loader struct{}
@@ -83,6 +88,51 @@ func (loader *loader) Load() (ProctorConfig, ConfigError) {
 	return ProctorConfig{Host: proctorHost, Email: emailId, AccessToken: accessToken, ConnectionTimeoutSecs: connectionTimeout, ProcExecutionStatusPollCount: procExecutionStatusPollCount}, ConfigError{}
 }
 
+// Save merges the given config keys into the config file, keeping other keys as they are
+func (loader *loader) Save(values map[string]string) error {
+	configFile := filepath.Join(ConfigFileDir(), "proctor.yaml")
+
+	content := yaml.MapSlice{}
+	existingContent, err := ioutil.ReadFile(configFile)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	err = yaml.Unmarshal(existingContent, &content)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{}
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		updated := false
+		for i := range content {
+			if content[i].Key == key {
+				content[i].Value = values[key]
+				updated = true
+			}
+		}
+		if !updated {
+			content = append(content, yaml.MapItem{Key: key, Value: values[key]})
+		}
+	}
+
+	updatedContent, err := yaml.Marshal(content)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(ConfigFileDir(), 0755)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(configFile, updatedContent, 0600)
+}
+
 // Returns Config file directory
 // This allows to test on dev environment without conflicting with installed proctor config file
 func ConfigFileDir() string {
@@ -10,3 +10,8 @@ func (m *MockLoader) Load() (ProctorConfig, ConfigError) {
 	args := m.Called()
 	return args.Get(0).(ProctorConfig), args.Get(1).(ConfigError)
 }
+
+func (m *MockLoader) Save(values map[string]string) error {
+	args := m.Called(values)
+	return args.Error(0)
+}
@@ -114,3 +114,26 @@ func (s *ConfigTestSuite) TestShouldPrintInstructionsForConfigFileIfFileNotFound
 
 	assert.Equal(t, expectedMessage, err.Message)
 }
+
+func (s *ConfigTestSuite) TestSaveMergesValuesIntoConfigFile() {
+	t := s.T()
+	s.createProctorConfigFile("PROCTOR_HOST: file.example.com\nEMAIL_ID: file@example.com\nACCESS_TOKEN: file-token\n")
+
+	err := s.configLoader.Save(map[string]string{EmailId: "new@example.com", AccessToken: "new-token"})
+	assert.NoError(t, err)
+
+	content, err := ioutil.ReadFile(s.configFilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "PROCTOR_HOST: file.example.com\nEMAIL_ID: new@example.com\nACCESS_TOKEN: new-token\n", string(content))
+}
+
+func (s *ConfigTestSuite) TestSaveCreatesConfigFile() {
+	t := s.T()
+
+	err := s.configLoader.Save(map[string]string{ProctorHost: "file.example.com", AccessToken: "new-token"})
+	assert.NoError(t, err)
+
+	content, err := ioutil.ReadFile(s.configFilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "ACCESS_TOKEN: new-token\nPROCTOR_HOST: file.example.com\n", string(content))
+}
@@ -20,21 +20,44 @@ import (
 	"github.com/fatih/color"
 	"proctor/config"
 	"proctor/io"
+	"proctor/proctord/jobs/execution"
 	proc_metadata "proctor/proctord/jobs/metadata"
 	"proctor/proctord/jobs/schedule"
+	"proctor/proctord/tokens"
 	"proctor/proctord/utility"
 	"github.com/gorilla/websocket"
 )
 
 type Client interface {
 	ListProcs() ([]proc_metadata.Metadata, error)
-	ExecuteProc(string, map[string]string) (string, error)
-	StreamProcLogs(string) error
+	ExecuteProc(string, map[string]string, execution.Provenance) (string, error)
+	StreamProcLogs(string, bool) error
 	GetDefinitiveProcExecutionStatus(string) (string, error)
 	ScheduleJob(string, string, string, string,string, map[string]string) (string, error)
 	ListScheduledProcs() ([]schedule.ScheduledJob, error)
 	DescribeScheduledProc(string) (schedule.ScheduledJob, error)
 	RemoveScheduledProc(string) error
+	CreateAccessToken(string, string, tokens.NewAccessToken) (tokens.AccessToken, error)
+	ListAccessTokens() ([]tokens.AccessToken, error)
+	RevokeAccessToken(string) error
+	CancelExecution(string) error
+	GetExecution(string) (execution.Detail, error)
+	ListExecutions(execution.HistoryFilter) (execution.History, error)
+	RerunExecution(string, map[string]string) (string, error)
+	ListApprovals() ([]execution.Approval, error)
+	ApproveExecution(string) (string, error)
+	RejectExecution(string) error
+}
+
+var ErrStreamInterrupted = errors.New("user interrupt while streaming proc logs")
+
+// ApprovalPendingError is returned for executions proctord holds until an approver approves them
+type ApprovalPendingError struct {
+	ApprovalID string
+}
+
+func (err ApprovalPendingError) Error() string {
+	return fmt.Sprintf("Execution is pending approval, approval request: %s", err.ApprovalID)
 }
 
 type client struct {
@@ -51,6 +74,7 @@ type client struct {
 type ProcToExecute struct {
 	Name string            `json:"name"`
 	Args map[string]string `json:"args"`
+	execution.Provenance
 }
 
 type ScheduleJobPayload struct {
@@ -246,15 +270,246 @@ func (c *client) RemoveScheduledProc(jobID string) error {
 	return nil
 }
 
-func (c *client) ExecuteProc(name string, args map[string]string) (string, error) {
+// CreateAccessToken authenticates with the given credentials instead of the
+// configured ones, so that a token can be issued before the config has one
+func (c *client) CreateAccessToken(emailID, accessToken string, newAccessToken tokens.NewAccessToken) (tokens.AccessToken, error) {
+	err := c.loadProctorConfig()
+	if err != nil {
+		return tokens.AccessToken{}, err
+	}
+	c.emailId = emailID
+	c.accessToken = accessToken
+
+	requestBody, err := json.Marshal(newAccessToken)
+	if err != nil {
+		return tokens.AccessToken{}, err
+	}
+
+	client := &http.Client{
+		Timeout: c.connectionTimeoutSecs,
+	}
+	req, err := http.NewRequest("POST", "http://"+c.proctordHost+"/tokens", bytes.NewReader(requestBody))
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add(utility.UserEmailHeaderKey, c.emailId)
+	req.Header.Add(utility.AccessTokenHeaderKey, c.accessToken)
+	req.Header.Add(utility.ClientVersionHeaderKey, c.clientVersion)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return tokens.AccessToken{}, buildNetworkError(err)
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return tokens.AccessToken{}, buildHTTPError(c, resp)
+	}
+
+	var createdAccessToken tokens.AccessToken
+	err = json.NewDecoder(resp.Body).Decode(&createdAccessToken)
+	return createdAccessToken, err
+}
+
+func (c *client) ListAccessTokens() ([]tokens.AccessToken, error) {
+	err := c.loadProctorConfig()
+	if err != nil {
+		return []tokens.AccessToken{}, err
+	}
+
+	client := &http.Client{
+		Timeout: c.connectionTimeoutSecs,
+	}
+	req, err := http.NewRequest("GET", "http://"+c.proctordHost+"/tokens", nil)
+	req.Header.Add(utility.UserEmailHeaderKey, c.emailId)
+	req.Header.Add(utility.AccessTokenHeaderKey, c.accessToken)
+	req.Header.Add(utility.ClientVersionHeaderKey, c.clientVersion)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return []tokens.AccessToken{}, buildNetworkError(err)
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return []tokens.AccessToken{}, buildHTTPError(c, resp)
+	}
+
+	var accessTokens []tokens.AccessToken
+	err = json.NewDecoder(resp.Body).Decode(&accessTokens)
+	return accessTokens, err
+}
+
+func (c *client) RevokeAccessToken(accessTokenID string) error {
+	err := c.loadProctorConfig()
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: c.connectionTimeoutSecs,
+	}
+	url := fmt.Sprintf("http://"+c.proctordHost+"/tokens/%s", accessTokenID)
+	req, err := http.NewRequest("DELETE", url, nil)
+	req.Header.Add(utility.UserEmailHeaderKey, c.emailId)
+	req.Header.Add(utility.AccessTokenHeaderKey, c.accessToken)
+	req.Header.Add(utility.ClientVersionHeaderKey, c.clientVersion)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return buildNetworkError(err)
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf(utility.AccessTokenNotFoundError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return buildHTTPError(c, resp)
+	}
+
+	return nil
+}
+
+func (c *client) CancelExecution(executionID string) error {
+	err := c.loadProctorConfig()
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: c.connectionTimeoutSecs,
+	}
+	url := fmt.Sprintf("http://"+c.proctordHost+"/jobs/execute/%s", executionID)
+	req, err := http.NewRequest("DELETE", url, nil)
+	req.Header.Add(utility.UserEmailHeaderKey, c.emailId)
+	req.Header.Add(utility.AccessTokenHeaderKey, c.accessToken)
+	req.Header.Add(utility.ClientVersionHeaderKey, c.clientVersion)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return buildNetworkError(err)
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf(utility.JobNotFoundError)
+	}
+	if resp.StatusCode == http.StatusConflict {
+		return fmt.Errorf(utility.JobAlreadyFinishedClientError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return buildHTTPError(c, resp)
+	}
+
+	return nil
+}
+
+func (c *client) GetExecution(executionID string) (execution.Detail, error) {
+	err := c.loadProctorConfig()
+	if err != nil {
+		return execution.Detail{}, err
+	}
+
+	client := &http.Client{
+		Timeout: c.connectionTimeoutSecs,
+	}
+	url := fmt.Sprintf("http://"+c.proctordHost+"/jobs/execute/%s", executionID)
+	req, err := http.NewRequest("GET", url, nil)
+	req.Header.Add(utility.UserEmailHeaderKey, c.emailId)
+	req.Header.Add(utility.AccessTokenHeaderKey, c.accessToken)
+	req.Header.Add(utility.ClientVersionHeaderKey, c.clientVersion)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return execution.Detail{}, buildNetworkError(err)
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return execution.Detail{}, buildHTTPError(c, resp)
+	}
+
+	var executionDetail execution.Detail
+	err = json.NewDecoder(resp.Body).Decode(&executionDetail)
+	return executionDetail, err
+}
+
+func (c *client) ListExecutions(filter execution.HistoryFilter) (execution.History, error) {
+	err := c.loadProctorConfig()
+	if err != nil {
+		return execution.History{}, err
+	}
+
+	client := &http.Client{
+		Timeout: c.connectionTimeoutSecs,
+	}
+	url := "http://" + c.proctordHost + "/jobs/executions?" + filter.QueryParams().Encode()
+	req, err := http.NewRequest("GET", url, nil)
+	req.Header.Add(utility.UserEmailHeaderKey, c.emailId)
+	req.Header.Add(utility.AccessTokenHeaderKey, c.accessToken)
+	req.Header.Add(utility.ClientVersionHeaderKey, c.clientVersion)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return execution.History{}, buildNetworkError(err)
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return execution.History{}, buildHTTPError(c, resp)
+	}
+
+	var history execution.History
+	err = json.NewDecoder(resp.Body).Decode(&history)
+	return history, err
+}
+
+func (c *client) RerunExecution(executionID string, args map[string]string) (string, error) {
+	err := c.loadProctorConfig()
+	if err != nil {
+		return "", err
+	}
+
+	requestBody, err := json.Marshal(ProcToExecute{Args: args})
+	if err != nil {
+		return "", err
+	}
+
+	client := &http.Client{}
+	url := fmt.Sprintf("http://"+c.proctordHost+"/jobs/execute/%s/rerun", executionID)
+	req, err := http.NewRequest("POST", url, bytes.NewReader(requestBody))
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add(utility.UserEmailHeaderKey, c.emailId)
+	req.Header.Add(utility.AccessTokenHeaderKey, c.accessToken)
+	req.Header.Add(utility.ClientVersionHeaderKey, c.clientVersion)
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", buildNetworkError(err)
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusAccepted {
+		return "", buildApprovalPendingError(resp)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return "", buildHTTPError(c, resp)
+	}
+
+	var executedProc ProcToExecute
+	err = json.NewDecoder(resp.Body).Decode(&executedProc)
+
+	return executedProc.Name, err
+}
+
+func (c *client) ExecuteProc(name string, args map[string]string, provenance execution.Provenance) (string, error) {
 	err := c.loadProctorConfig()
 	if err != nil {
 		return "", err
 	}
 
 	procToExecute := ProcToExecute{
-		Name: name,
-		Args: args,
+		Name:       name,
+		Args:       args,
+		Provenance: provenance,
 	}
 
 	requestBody, err := json.Marshal(procToExecute)
@@ -274,6 +529,71 @@ func (c *client) ExecuteProc(name string, args map[string]string) (string, error
 	}
 
 	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusAccepted {
+		return "", buildApprovalPendingError(resp)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return "", buildHTTPError(c, resp)
+	}
+
+	var executedProc ProcToExecute
+	err = json.NewDecoder(resp.Body).Decode(&executedProc)
+
+	return executedProc.Name, err
+}
+
+func (c *client) ListApprovals() ([]execution.Approval, error) {
+	err := c.loadProctorConfig()
+	if err != nil {
+		return []execution.Approval{}, err
+	}
+
+	client := &http.Client{
+		Timeout: c.connectionTimeoutSecs,
+	}
+	req, err := http.NewRequest("GET", "http://"+c.proctordHost+"/jobs/approvals", nil)
+	req.Header.Add(utility.UserEmailHeaderKey, c.emailId)
+	req.Header.Add(utility.AccessTokenHeaderKey, c.accessToken)
+	req.Header.Add(utility.ClientVersionHeaderKey, c.clientVersion)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return []execution.Approval{}, buildNetworkError(err)
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return []execution.Approval{}, buildHTTPError(c, resp)
+	}
+
+	var approvals []execution.Approval
+	err = json.NewDecoder(resp.Body).Decode(&approvals)
+	return approvals, err
+}
+
+// ApproveExecution approves a pending approval request and returns the ID of the execution it submitted
+func (c *client) ApproveExecution(approvalID string) (string, error) {
+	err := c.loadProctorConfig()
+	if err != nil {
+		return "", err
+	}
+
+	client := &http.Client{}
+	url := fmt.Sprintf("http://"+c.proctordHost+"/jobs/approvals/%s/approve", approvalID)
+	req, err := http.NewRequest("POST", url, nil)
+	req.Header.Add(utility.UserEmailHeaderKey, c.emailId)
+	req.Header.Add(utility.AccessTokenHeaderKey, c.accessToken)
+	req.Header.Add(utility.ClientVersionHeaderKey, c.clientVersion)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", buildNetworkError(err)
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusConflict {
+		return "", getHttpResponseError(resp.Body)
+	}
 	if resp.StatusCode != http.StatusCreated {
 		return "", buildHTTPError(c, resp)
 	}
@@ -284,7 +604,39 @@ func (c *client) ExecuteProc(name string, args map[string]string) (string, error
 	return executedProc.Name, err
 }
 
-func (c *client) StreamProcLogs(name string) error {
+func (c *client) RejectExecution(approvalID string) error {
+	err := c.loadProctorConfig()
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: c.connectionTimeoutSecs,
+	}
+	url := fmt.Sprintf("http://"+c.proctordHost+"/jobs/approvals/%s/reject", approvalID)
+	req, err := http.NewRequest("POST", url, nil)
+	req.Header.Add(utility.UserEmailHeaderKey, c.emailId)
+	req.Header.Add(utility.AccessTokenHeaderKey, c.accessToken)
+	req.Header.Add(utility.ClientVersionHeaderKey, c.clientVersion)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return buildNetworkError(err)
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusConflict {
+		return getHttpResponseError(resp.Body)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return buildHTTPError(c, resp)
+	}
+
+	return nil
+}
+
+// StreamProcLogs prints the logs of an execution. Without follow it stops at the logs written so far
+func (c *client) StreamProcLogs(name string, follow bool) error {
 	err := c.loadProctorConfig()
 	if err != nil {
 		return err
@@ -296,9 +648,13 @@ func (c *client) StreamProcLogs(name string) error {
 
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
 
 	proctodWebsocketURL := url.URL{Scheme: "ws", Host: c.proctordHost, Path: "/jobs/logs"}
 	proctodWebsocketURLWithProcName := proctodWebsocketURL.String() + "?" + "job_name=" + name
+	if !follow {
+		proctodWebsocketURLWithProcName += "&follow=false"
+	}
 
 	headers := make(map[string][]string)
 	token := []string{c.accessToken}
@@ -311,12 +667,21 @@ func (c *client) StreamProcLogs(name string) error {
 	wsConn, response, err := websocket.DefaultDialer.Dial(proctodWebsocketURLWithProcName, headers)
 	if err != nil {
 		animation.Stop()
+		if response == nil {
+			return err
+		}
 		if response.StatusCode == http.StatusUnauthorized {
 			if c.emailId == "" || c.accessToken == "" {
 				return fmt.Errorf("%s\n%s", utility.UnauthorizedErrorHeader, utility.UnauthorizedErrorMissingConfig)
 			}
 			return fmt.Errorf("%s\n%s", utility.UnauthorizedErrorHeader, utility.UnauthorizedErrorInvalidConfig)
 		}
+		if response.StatusCode == http.StatusForbidden {
+			return fmt.Errorf(utility.JobForbiddenErrorHeader)
+		}
+		if response.StatusCode == http.StatusNotFound {
+			return fmt.Errorf(utility.JobNotFoundError)
+		}
 		return err
 	}
 	defer wsConn.Close()
@@ -340,7 +705,10 @@ func (c *client) StreamProcLogs(name string) error {
 		case <-interrupt:
 			color.New(color.FgRed).Println("User interrupt while streaming proc logs")
 			err := wsConn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
-			return err
+			if err != nil {
+				return err
+			}
+			return ErrStreamInterrupted
 		case <-logStreaming:
 			return nil
 		}
@@ -379,7 +747,7 @@ func (c *client) GetDefinitiveProcExecutionStatus(procName string) (string, erro
 		}
 
 		procExecutionStatus := string(body)
-		if procExecutionStatus == utility.JobSucceeded || procExecutionStatus == utility.JobFailed {
+		if procExecutionStatus == utility.JobSucceeded || procExecutionStatus == utility.JobFailed || procExecutionStatus == utility.JobCancelled {
 			return procExecutionStatus, nil
 		}
 
@@ -422,6 +790,15 @@ func buildHTTPError(c *client, resp *http.Response) error {
 	return fmt.Errorf("%s\nStatus Code: %d, %s", utility.GenericResponseErrorHeader, resp.StatusCode, http.StatusText(resp.StatusCode))
 }
 
+func buildApprovalPendingError(resp *http.Response) error {
+	var approval execution.Approval
+	err := json.NewDecoder(resp.Body).Decode(&approval)
+	if err != nil {
+		return err
+	}
+	return ApprovalPendingError{ApprovalID: approval.ID}
+}
+
 func getHttpResponseError(response io_reader.ReadCloser) error {
 	body, _ := ioutil.ReadAll(response)
 	bodyString := string(body)
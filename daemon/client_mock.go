@@ -1,8 +1,10 @@
 package daemon
 
 import (
+	"proctor/proctord/jobs/execution"
 	proc_metadata "proctor/proctord/jobs/metadata"
 	"proctor/proctord/jobs/schedule"
+	"proctor/proctord/tokens"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -20,13 +22,13 @@ func (m *MockClient) ListScheduledProcs() ([]schedule.ScheduledJob, error) {
 	return args.Get(0).([]schedule.ScheduledJob), args.Error(1)
 }
 
-func (m *MockClient) ExecuteProc(name string, procArgs map[string]string) (string, error) {
-	args := m.Called(name, procArgs)
+func (m *MockClient) ExecuteProc(name string, procArgs map[string]string, provenance execution.Provenance) (string, error) {
+	args := m.Called(name, procArgs, provenance)
 	return args.Get(0).(string), args.Error(1)
 }
 
-func (m *MockClient) StreamProcLogs(name string) error {
-	args := m.Called(name)
+func (m *MockClient) StreamProcLogs(name string, follow bool) error {
+	args := m.Called(name, follow)
 	return args.Error(0)
 }
 
@@ -49,3 +51,53 @@ func (m *MockClient) RemoveScheduledProc(jobID string) error {
 	args := m.Called(jobID)
 	return args.Error(0)
 }
+
+func (m *MockClient) CreateAccessToken(emailID, accessToken string, newAccessToken tokens.NewAccessToken) (tokens.AccessToken, error) {
+	args := m.Called(emailID, accessToken, newAccessToken)
+	return args.Get(0).(tokens.AccessToken), args.Error(1)
+}
+
+func (m *MockClient) ListAccessTokens() ([]tokens.AccessToken, error) {
+	args := m.Called()
+	return args.Get(0).([]tokens.AccessToken), args.Error(1)
+}
+
+func (m *MockClient) RevokeAccessToken(accessTokenID string) error {
+	args := m.Called(accessTokenID)
+	return args.Error(0)
+}
+
+func (m *MockClient) CancelExecution(executionID string) error {
+	args := m.Called(executionID)
+	return args.Error(0)
+}
+
+func (m *MockClient) GetExecution(executionID string) (execution.Detail, error) {
+	args := m.Called(executionID)
+	return args.Get(0).(execution.Detail), args.Error(1)
+}
+
+func (m *MockClient) ListExecutions(filter execution.HistoryFilter) (execution.History, error) {
+	args := m.Called(filter)
+	return args.Get(0).(execution.History), args.Error(1)
+}
+
+func (m *MockClient) RerunExecution(executionID string, procArgs map[string]string) (string, error) {
+	args := m.Called(executionID, procArgs)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockClient) ListApprovals() ([]execution.Approval, error) {
+	args := m.Called()
+	return args.Get(0).([]execution.Approval), args.Error(1)
+}
+
+func (m *MockClient) ApproveExecution(approvalID string) (string, error) {
+	args := m.Called(approvalID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockClient) RejectExecution(approvalID string) error {
+	args := m.Called(approvalID)
+	return args.Error(0)
+}
@@ -1,6 +1,7 @@
 package daemon
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -15,8 +16,10 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/thingful/httpmock"
 
+	"proctor/proctord/jobs/execution"
 	proc_metadata "proctor/proctord/jobs/metadata"
 	"proctor/proctord/jobs/metadata/env"
+	"proctor/proctord/tokens"
 	"proctor/proctord/utility"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -289,7 +292,7 @@ func (s *ClientTestSuite) TestExecuteProc() {
 
 	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
 
-	executeProcResponse, err := s.testClient.ExecuteProc(procName, procArgs)
+	executeProcResponse, err := s.testClient.ExecuteProc(procName, procArgs, execution.Provenance{})
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedProcResponse, executeProcResponse)
@@ -375,6 +378,39 @@ func (s *ClientTestSuite) TestSchedulingAlreadyExistedScheduledJob() {
 	s.mockConfigLoader.AssertExpectations(t)
 }
 
+func (s *ClientTestSuite) TestExecuteProcWithProvenance() {
+	t := s.T()
+
+	proctorConfig := config.ProctorConfig{Host: "proctor.example.com", Email: "proctor@example.com", AccessToken: "access-token"}
+	provenance := execution.Provenance{Labels: map[string]string{"ticket": "OPS-123"}, Reason: "Clearing the stuck payments queue"}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterStubRequest(
+		httpmock.NewStubRequest(
+			"POST",
+			"http://"+proctorConfig.Host+"/jobs/execute",
+			func(req *http.Request) (*http.Response, error) {
+				var procToExecute ProcToExecute
+				err := json.NewDecoder(req.Body).Decode(&procToExecute)
+				assert.NoError(t, err)
+				assert.Equal(t, provenance, procToExecute.Provenance)
+
+				return httpmock.NewStringResponse(201, `{ "name":"proctor-ipsum-lorem" }`), nil
+			},
+		),
+	)
+
+	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
+
+	executedProcName, err := s.testClient.ExecuteProc("run-sample", map[string]string{}, provenance)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "proctor-ipsum-lorem", executedProcName)
+	s.mockConfigLoader.AssertExpectations(t)
+}
+
 func (s *ClientTestSuite) TestExecuteProcInternalServerError() {
 	t := s.T()
 	proctorConfig := config.ProctorConfig{Host: "proctor.example.com", Email: "proctor@example.com", AccessToken: "access-token"}
@@ -402,7 +438,7 @@ func (s *ClientTestSuite) TestExecuteProcInternalServerError() {
 	)
 
 	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
-	executeProcResponse, err := s.testClient.ExecuteProc(procName, procArgs)
+	executeProcResponse, err := s.testClient.ExecuteProc(procName, procArgs, execution.Provenance{})
 
 	assert.Equal(t, "Server Error!!!\nStatus Code: 500, Internal Server Error", err.Error())
 	assert.Equal(t, expectedProcResponse, executeProcResponse)
@@ -434,7 +470,7 @@ func (s *ClientTestSuite) TestExecuteProcUnAuthorized() {
 
 	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
 
-	executeProcResponse, err := s.testClient.ExecuteProc("run-sample", map[string]string{"SAMPLE_ARG1": "sample-value"})
+	executeProcResponse, err := s.testClient.ExecuteProc("run-sample", map[string]string{"SAMPLE_ARG1": "sample-value"}, execution.Provenance{})
 
 	assert.Equal(t, "", executeProcResponse)
 	assert.Equal(t, "Unauthorized Access!!!\nPlease check the EMAIL_ID and ACCESS_TOKEN validity in proctor config file.", err.Error())
@@ -466,7 +502,7 @@ func (s *ClientTestSuite) TestExecuteProcUnAuthorizedWhenEmailAndAccessTokenNotS
 
 	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
 
-	executeProcResponse, err := s.testClient.ExecuteProc("run-sample", map[string]string{"SAMPLE_ARG1": "sample-value"})
+	executeProcResponse, err := s.testClient.ExecuteProc("run-sample", map[string]string{"SAMPLE_ARG1": "sample-value"}, execution.Provenance{})
 
 	assert.Equal(t, "", executeProcResponse)
 	assert.Equal(t, "Unauthorized Access!!!\nEMAIL_ID or ACCESS_TOKEN is not present in proctor config file.", err.Error())
@@ -498,7 +534,7 @@ func (s *ClientTestSuite) TestExecuteProcsReturnClientSideConnectionError() {
 
 	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
 
-	response, err := s.testClient.ExecuteProc("run-sample", map[string]string{"SAMPLE_ARG1": "sample-value"})
+	response, err := s.testClient.ExecuteProc("run-sample", map[string]string{"SAMPLE_ARG1": "sample-value"}, execution.Provenance{})
 
 	assert.Equal(t, "", response)
 	assert.Equal(t, errors.New("Network Error!!!\nPost \"http://proctor.example.com/jobs/execute\": Unknown Error"), err)
@@ -527,7 +563,7 @@ func (s *ClientTestSuite) TestLogStreamForAuthorizedUser() {
 
 	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
 
-	err := s.testClient.StreamProcLogs("test-job-id")
+	err := s.testClient.StreamProcLogs("test-job-id", true)
 	assert.NoError(t, err)
 	s.mockConfigLoader.AssertExpectations(t)
 }
@@ -543,7 +579,7 @@ func (s *ClientTestSuite) TestLogStreamForBadWebSocketHandshake() {
 
 	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
 
-	errStreamLogs := s.testClient.StreamProcLogs("test-job-id")
+	errStreamLogs := s.testClient.StreamProcLogs("test-job-id", true)
 	assert.Equal(t, errors.New("websocket: bad handshake"), errStreamLogs)
 	s.mockConfigLoader.AssertExpectations(t)
 }
@@ -561,12 +597,52 @@ func (s *ClientTestSuite) TestLogStreamForUnauthorizedUser() {
 
 	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
 
-	errStreamLogs := s.testClient.StreamProcLogs("test-job-id")
+	errStreamLogs := s.testClient.StreamProcLogs("test-job-id", true)
 	assert.Error(t, errors.New(http.StatusText(http.StatusUnauthorized)), errStreamLogs)
 	s.mockConfigLoader.AssertExpectations(t)
 
 }
 
+func (s *ClientTestSuite) TestLogStreamWithoutFollow() {
+	t := s.T()
+	logStreamHandler := func(t *testing.T) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "test-job-id", r.URL.Query().Get("job_name"))
+			assert.Equal(t, "false", r.URL.Query().Get("follow"))
+			upgrader := websocket.Upgrader{}
+			conn, _ := upgrader.Upgrade(w, r, nil)
+			defer conn.Close()
+		}
+	}
+	testServer := httptest.NewServer(logStreamHandler(t))
+	defer testServer.Close()
+	proctorConfig := config.ProctorConfig{Host: makeHostname(testServer.URL), Email: "proctor@example.com", AccessToken: "access-token"}
+
+	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
+
+	err := s.testClient.StreamProcLogs("test-job-id", false)
+	assert.NoError(t, err)
+	s.mockConfigLoader.AssertExpectations(t)
+}
+
+func (s *ClientTestSuite) TestLogStreamForUnknownExecution() {
+	t := s.T()
+	unknownExecutionHandler := func() http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+	testServer := httptest.NewServer(unknownExecutionHandler())
+	defer testServer.Close()
+	proctorConfig := config.ProctorConfig{Host: makeHostname(testServer.URL), Email: "proctor@example.com", AccessToken: "access-token"}
+
+	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
+
+	errStreamLogs := s.testClient.StreamProcLogs("test-job-id", true)
+	assert.EqualError(t, errStreamLogs, utility.JobNotFoundError)
+	s.mockConfigLoader.AssertExpectations(t)
+}
+
 func (s *ClientTestSuite) TestGetDefinitiveProcExecutionStatusForSucceededProcs() {
 	t := s.T()
 
@@ -1115,3 +1191,486 @@ func (s *ClientTestSuite) TestRemoveScheduledJobWitInternalServerError() {
 	assert.Equal(t, "Server Error!!!\nStatus Code: 500, Internal Server Error", err.Error())
 	s.mockConfigLoader.AssertExpectations(t)
 }
+
+func (s *ClientTestSuite) TestCreateAccessTokenUsesGivenCredentials() {
+	t := s.T()
+
+	proctorConfig := config.ProctorConfig{Host: "proctor.example.com", Email: "proctor@example.com", AccessToken: "access-token"}
+	body := `{"name":"laptop","token":"new-access-token","scopes":["execute"]}`
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterStubRequest(
+		httpmock.NewStubRequest(
+			"POST",
+			"http://"+proctorConfig.Host+"/tokens",
+			func(req *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(201, body), nil
+			},
+		).WithHeader(
+			&http.Header{
+				utility.UserEmailHeaderKey:     []string{"login@example.com"},
+				utility.AccessTokenHeaderKey:   []string{"login-access-token"},
+				utility.ClientVersionHeaderKey: []string{version.ClientVersion},
+			},
+		),
+	)
+
+	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
+
+	accessToken, err := s.testClient.CreateAccessToken("login@example.com", "login-access-token", tokens.NewAccessToken{Name: "laptop", Scopes: []string{"execute"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, tokens.AccessToken{Name: "laptop", Token: "new-access-token", Scopes: []string{"execute"}}, accessToken)
+	s.mockConfigLoader.AssertExpectations(t)
+}
+
+func (s *ClientTestSuite) TestListAccessTokens() {
+	t := s.T()
+
+	proctorConfig := config.ProctorConfig{Host: "proctor.example.com", Email: "proctor@example.com", AccessToken: "access-token"}
+	body := `[{"id":1,"name":"laptop","scopes":[]}]`
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterStubRequest(
+		httpmock.NewStubRequest(
+			"GET",
+			"http://"+proctorConfig.Host+"/tokens",
+			func(req *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(200, body), nil
+			},
+		).WithHeader(
+			&http.Header{
+				utility.UserEmailHeaderKey:     []string{"proctor@example.com"},
+				utility.AccessTokenHeaderKey:   []string{"access-token"},
+				utility.ClientVersionHeaderKey: []string{version.ClientVersion},
+			},
+		),
+	)
+
+	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
+
+	accessTokens, err := s.testClient.ListAccessTokens()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []tokens.AccessToken{{ID: 1, Name: "laptop", Scopes: []string{}}}, accessTokens)
+	s.mockConfigLoader.AssertExpectations(t)
+}
+
+func (s *ClientTestSuite) TestRevokeAccessTokenWhenNotFound() {
+	t := s.T()
+
+	proctorConfig := config.ProctorConfig{Host: "proctor.example.com", Email: "proctor@example.com", AccessToken: "access-token"}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterStubRequest(
+		httpmock.NewStubRequest(
+			"DELETE",
+			"http://"+proctorConfig.Host+"/tokens/7",
+			func(req *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(404, utility.AccessTokenNotFoundError), nil
+			},
+		).WithHeader(
+			&http.Header{
+				utility.UserEmailHeaderKey:     []string{"proctor@example.com"},
+				utility.AccessTokenHeaderKey:   []string{"access-token"},
+				utility.ClientVersionHeaderKey: []string{version.ClientVersion},
+			},
+		),
+	)
+
+	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
+
+	err := s.testClient.RevokeAccessToken("7")
+
+	assert.EqualError(t, err, utility.AccessTokenNotFoundError)
+	s.mockConfigLoader.AssertExpectations(t)
+}
+
+func (s *ClientTestSuite) TestCancelExecution() {
+	t := s.T()
+
+	proctorConfig := config.ProctorConfig{Host: "proctor.example.com", Email: "proctor@example.com", AccessToken: "access-token"}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterStubRequest(
+		httpmock.NewStubRequest(
+			"DELETE",
+			"http://"+proctorConfig.Host+"/jobs/execute/proctor-ipsum-lorem",
+			func(req *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(200, `{ "name":"proctor-ipsum-lorem", "status":"CANCELLED" }`), nil
+			},
+		).WithHeader(
+			&http.Header{
+				utility.UserEmailHeaderKey:     []string{"proctor@example.com"},
+				utility.AccessTokenHeaderKey:   []string{"access-token"},
+				utility.ClientVersionHeaderKey: []string{version.ClientVersion},
+			},
+		),
+	)
+
+	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
+
+	err := s.testClient.CancelExecution("proctor-ipsum-lorem")
+
+	assert.NoError(t, err)
+	s.mockConfigLoader.AssertExpectations(t)
+}
+
+func (s *ClientTestSuite) TestCancelExecutionWhenAlreadyFinished() {
+	t := s.T()
+
+	proctorConfig := config.ProctorConfig{Host: "proctor.example.com", Email: "proctor@example.com", AccessToken: "access-token"}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterStubRequest(
+		httpmock.NewStubRequest(
+			"DELETE",
+			"http://"+proctorConfig.Host+"/jobs/execute/proctor-ipsum-lorem",
+			func(req *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(409, utility.JobAlreadyFinishedClientError), nil
+			},
+		).WithHeader(
+			&http.Header{
+				utility.UserEmailHeaderKey:     []string{"proctor@example.com"},
+				utility.AccessTokenHeaderKey:   []string{"access-token"},
+				utility.ClientVersionHeaderKey: []string{version.ClientVersion},
+			},
+		),
+	)
+
+	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
+
+	err := s.testClient.CancelExecution("proctor-ipsum-lorem")
+
+	assert.EqualError(t, err, utility.JobAlreadyFinishedClientError)
+	s.mockConfigLoader.AssertExpectations(t)
+}
+
+func (s *ClientTestSuite) TestGetExecution() {
+	t := s.T()
+
+	proctorConfig := config.ProctorConfig{Host: "proctor.example.com", Email: "proctor@example.com", AccessToken: "access-token"}
+	body := `{"name":"proctor-ipsum-lorem","job_name":"say-hello-world","image_name":"img","user_email":"runner@example.com","args":{"SAMPLE_ARG":"any"},"submission_status":"success","execution_status":"SUCCEEDED","created_at":"2019-03-01T10:00:00Z","updated_at":"2019-03-01T10:01:30Z","duration_seconds":90}`
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterStubRequest(
+		httpmock.NewStubRequest(
+			"GET",
+			"http://"+proctorConfig.Host+"/jobs/execute/proctor-ipsum-lorem",
+			func(req *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(200, body), nil
+			},
+		).WithHeader(
+			&http.Header{
+				utility.UserEmailHeaderKey:     []string{"proctor@example.com"},
+				utility.AccessTokenHeaderKey:   []string{"access-token"},
+				utility.ClientVersionHeaderKey: []string{version.ClientVersion},
+			},
+		),
+	)
+
+	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
+
+	executionDetail, err := s.testClient.GetExecution("proctor-ipsum-lorem")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "say-hello-world", executionDetail.JobName)
+	assert.Equal(t, map[string]string{"SAMPLE_ARG": "any"}, executionDetail.Args)
+	assert.Equal(t, utility.JobSucceeded, executionDetail.ExecutionStatus)
+	assert.Equal(t, int64(90), executionDetail.DurationSeconds)
+	s.mockConfigLoader.AssertExpectations(t)
+}
+
+func (s *ClientTestSuite) TestGetExecutionForUnknownExecution() {
+	t := s.T()
+
+	proctorConfig := config.ProctorConfig{Host: "proctor.example.com", Email: "proctor@example.com", AccessToken: "access-token"}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterStubRequest(
+		httpmock.NewStubRequest(
+			"GET",
+			"http://"+proctorConfig.Host+"/jobs/execute/proctor-ipsum-lorem",
+			func(req *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(404, utility.JobNotFoundError), nil
+			},
+		).WithHeader(
+			&http.Header{
+				utility.UserEmailHeaderKey:     []string{"proctor@example.com"},
+				utility.AccessTokenHeaderKey:   []string{"access-token"},
+				utility.ClientVersionHeaderKey: []string{version.ClientVersion},
+			},
+		),
+	)
+
+	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
+
+	_, err := s.testClient.GetExecution("proctor-ipsum-lorem")
+
+	assert.EqualError(t, err, utility.JobNotFoundError)
+	s.mockConfigLoader.AssertExpectations(t)
+}
+
+func (s *ClientTestSuite) TestListExecutions() {
+	t := s.T()
+
+	proctorConfig := config.ProctorConfig{Host: "proctor.example.com", Email: "proctor@example.com", AccessToken: "access-token"}
+	body := `{"executions":[{"name":"proctor-ipsum-lorem","job_name":"say-hello-world","execution_status":"FAILED"}],"next_cursor":"41"}`
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterStubRequest(
+		httpmock.NewStubRequest(
+			"GET",
+			"http://"+proctorConfig.Host+"/jobs/executions?limit=20&proc=say-hello-world&status=FAILED",
+			func(req *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(200, body), nil
+			},
+		).WithHeader(
+			&http.Header{
+				utility.UserEmailHeaderKey:     []string{"proctor@example.com"},
+				utility.AccessTokenHeaderKey:   []string{"access-token"},
+				utility.ClientVersionHeaderKey: []string{version.ClientVersion},
+			},
+		),
+	)
+
+	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
+
+	history, err := s.testClient.ListExecutions(execution.HistoryFilter{JobName: "say-hello-world", ExecutionStatus: utility.JobFailed, Limit: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "41", history.NextCursor)
+	assert.Equal(t, "proctor-ipsum-lorem", history.Executions[0].Name)
+	s.mockConfigLoader.AssertExpectations(t)
+}
+
+func (s *ClientTestSuite) TestRerunExecution() {
+	t := s.T()
+
+	proctorConfig := config.ProctorConfig{Host: "proctor.example.com", Email: "proctor@example.com", AccessToken: "access-token"}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterStubRequest(
+		httpmock.NewStubRequest(
+			"POST",
+			"http://"+proctorConfig.Host+"/jobs/execute/proctor-ipsum-lorem/rerun",
+			func(req *http.Request) (*http.Response, error) {
+				var procToExecute ProcToExecute
+				err := json.NewDecoder(req.Body).Decode(&procToExecute)
+				assert.NoError(t, err)
+				assert.Equal(t, map[string]string{"SAMPLE_ARG": "override"}, procToExecute.Args)
+
+				return httpmock.NewStringResponse(201, `{ "name":"proctor-dolor-sit" }`), nil
+			},
+		).WithHeader(
+			&http.Header{
+				utility.UserEmailHeaderKey:     []string{"proctor@example.com"},
+				utility.AccessTokenHeaderKey:   []string{"access-token"},
+				utility.ClientVersionHeaderKey: []string{version.ClientVersion},
+			},
+		),
+	)
+
+	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
+
+	executedProcName, err := s.testClient.RerunExecution("proctor-ipsum-lorem", map[string]string{"SAMPLE_ARG": "override"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "proctor-dolor-sit", executedProcName)
+	s.mockConfigLoader.AssertExpectations(t)
+}
+
+func (s *ClientTestSuite) TestRerunExecutionForUnknownExecution() {
+	t := s.T()
+
+	proctorConfig := config.ProctorConfig{Host: "proctor.example.com", Email: "proctor@example.com", AccessToken: "access-token"}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterStubRequest(
+		httpmock.NewStubRequest(
+			"POST",
+			"http://"+proctorConfig.Host+"/jobs/execute/proctor-ipsum-lorem/rerun",
+			func(req *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(404, utility.JobNotFoundError), nil
+			},
+		),
+	)
+
+	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
+
+	_, err := s.testClient.RerunExecution("proctor-ipsum-lorem", map[string]string{})
+
+	assert.EqualError(t, err, utility.JobNotFoundError)
+	s.mockConfigLoader.AssertExpectations(t)
+}
+
+func (s *ClientTestSuite) TestExecuteProcRequiringApproval() {
+	t := s.T()
+
+	proctorConfig := config.ProctorConfig{Host: "proctor.example.com", Email: "proctor@example.com", AccessToken: "access-token"}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterStubRequest(
+		httpmock.NewStubRequest(
+			"POST",
+			"http://"+proctorConfig.Host+"/jobs/execute",
+			func(req *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(202, `{ "id":"approval-id", "status":"PENDING" }`), nil
+			},
+		),
+	)
+
+	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
+
+	executedProcName, err := s.testClient.ExecuteProc("refund", map[string]string{}, execution.Provenance{})
+
+	assert.Equal(t, ApprovalPendingError{ApprovalID: "approval-id"}, err)
+	assert.Equal(t, "", executedProcName)
+	s.mockConfigLoader.AssertExpectations(t)
+}
+
+func (s *ClientTestSuite) TestListApprovals() {
+	t := s.T()
+
+	proctorConfig := config.ProctorConfig{Host: "proctor.example.com", Email: "proctor@example.com", AccessToken: "access-token"}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterStubRequest(
+		httpmock.NewStubRequest(
+			"GET",
+			"http://"+proctorConfig.Host+"/jobs/approvals",
+			func(req *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(200, `[{"id":"approval-id","job_name":"refund","user_email":"mrproctor@example.com","args":{"ORDER":"42"},"status":"PENDING"}]`), nil
+			},
+		).WithHeader(
+			&http.Header{
+				utility.UserEmailHeaderKey:     []string{"proctor@example.com"},
+				utility.AccessTokenHeaderKey:   []string{"access-token"},
+				utility.ClientVersionHeaderKey: []string{version.ClientVersion},
+			},
+		),
+	)
+
+	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
+
+	approvals, err := s.testClient.ListApprovals()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []execution.Approval{{
+		ID:        "approval-id",
+		JobName:   "refund",
+		UserEmail: "mrproctor@example.com",
+		Args:      map[string]string{"ORDER": "42"},
+		Status:    utility.ApprovalPending,
+	}}, approvals)
+	s.mockConfigLoader.AssertExpectations(t)
+}
+
+func (s *ClientTestSuite) TestApproveExecution() {
+	t := s.T()
+
+	proctorConfig := config.ProctorConfig{Host: "proctor.example.com", Email: "proctor@example.com", AccessToken: "access-token"}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterStubRequest(
+		httpmock.NewStubRequest(
+			"POST",
+			"http://"+proctorConfig.Host+"/jobs/approvals/approval-id/approve",
+			func(req *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(201, `{ "name":"proctor-ipsum-lorem" }`), nil
+			},
+		).WithHeader(
+			&http.Header{
+				utility.UserEmailHeaderKey:     []string{"proctor@example.com"},
+				utility.AccessTokenHeaderKey:   []string{"access-token"},
+				utility.ClientVersionHeaderKey: []string{version.ClientVersion},
+			},
+		),
+	)
+
+	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
+
+	executedProcName, err := s.testClient.ApproveExecution("approval-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "proctor-ipsum-lorem", executedProcName)
+	s.mockConfigLoader.AssertExpectations(t)
+}
+
+func (s *ClientTestSuite) TestApproveExecutionOwnRequest() {
+	t := s.T()
+
+	proctorConfig := config.ProctorConfig{Host: "proctor.example.com", Email: "proctor@example.com", AccessToken: "access-token"}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterStubRequest(
+		httpmock.NewStubRequest(
+			"POST",
+			"http://"+proctorConfig.Host+"/jobs/approvals/approval-id/approve",
+			func(req *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(403, utility.SelfApprovalClientError), nil
+			},
+		),
+	)
+
+	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
+
+	_, err := s.testClient.ApproveExecution("approval-id")
+
+	assert.EqualError(t, err, utility.SelfApprovalClientError)
+	s.mockConfigLoader.AssertExpectations(t)
+}
+
+func (s *ClientTestSuite) TestRejectExecutionWhenAlreadyDecided() {
+	t := s.T()
+
+	proctorConfig := config.ProctorConfig{Host: "proctor.example.com", Email: "proctor@example.com", AccessToken: "access-token"}
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterStubRequest(
+		httpmock.NewStubRequest(
+			"POST",
+			"http://"+proctorConfig.Host+"/jobs/approvals/approval-id/reject",
+			func(req *http.Request) (*http.Response, error) {
+				return httpmock.NewStringResponse(409, utility.ApprovalRequestDecidedClientError), nil
+			},
+		),
+	)
+
+	s.mockConfigLoader.On("Load").Return(proctorConfig, config.ConfigError{}).Once()
+
+	err := s.testClient.RejectExecution("approval-id")
+
+	assert.EqualError(t, err, utility.ApprovalRequestDecidedClientError)
+	s.mockConfigLoader.AssertExpectations(t)
+}
@@ -14,5 +14,7 @@ func main() {
 	proctorDClient := daemon.NewClient(printer, proctorConfigLoader)
 	githubClient := github.NewClient()
 
-	cmd.Execute(printer, proctorDClient, githubClient)
+	prompter := io.GetPrompter()
+
+	cmd.Execute(printer, proctorDClient, githubClient, proctorConfigLoader, prompter)
 }
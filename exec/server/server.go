@@ -1,15 +1,22 @@
 package main
 
 import (
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/getsentry/raven-go"
 	"github.com/urfave/cli"
 
+	"proctor/proctord/backend/configured"
 	"proctor/proctord/config"
+	"proctor/proctord/jobs/gc"
+	"proctor/proctord/jobs/secrets"
 	"proctor/proctord/logger"
+	"proctor/proctord/redis"
 	"proctor/proctord/scheduler"
 	"proctor/proctord/server"
+	"proctor/proctord/storage"
 	"proctor/proctord/storage/postgres"
 )
 
@@ -44,6 +51,55 @@ func main() {
 				logger.Info("Rollback successful")
 			},
 		},
+		{
+			Name:        "scrub-secrets",
+			Description: "Redact values of current proc secrets from the args of past job executions",
+			Action: func(c *cli.Context) {
+				postgresClient := postgres.NewClient()
+				defer postgresClient.Close()
+				redisClient := redis.NewClient()
+
+				scrubber := secrets.NewScrubber(storage.New(postgresClient), secrets.NewStore(redisClient))
+				scrubbedCount, err := scrubber.ScrubJobsExecutionAuditLog()
+				if err != nil {
+					panic(err.Error())
+				}
+				logger.Info("Scrubbed secrets from", scrubbedCount, "job executions")
+			},
+		},
+		{
+			Name:        "gc",
+			Description: "Remove finished jobs and their pods which are past their ttl",
+			Flags: []cli.Flag{
+				cli.BoolFlag{Name: "dry-run", Usage: "only list the jobs which would be removed"},
+			},
+			Action: func(c *cli.Context) error {
+				postgresClient := postgres.NewClient()
+				defer postgresClient.Close()
+				executionBackend, err := configured.New()
+				if err != nil {
+					return err
+				}
+
+				collector := gc.NewCollector(executionBackend, storage.New(postgresClient),
+					time.Duration(config.SucceededJobTTLInMins())*time.Minute, time.Duration(config.FailedJobTTLInMins())*time.Minute, config.JobLogsArchiveDir())
+				dryRun := c.Bool("dry-run")
+				expiredJobs, err := collector.Collect(dryRun)
+				if err != nil {
+					return err
+				}
+
+				for _, expiredJob := range expiredJobs {
+					fmt.Printf("%s\t%s\tfinished at %s\n", expiredJob.Name, expiredJob.Status, expiredJob.FinishedAt.Format(time.RFC3339))
+				}
+				if dryRun {
+					logger.Info("Would remove", len(expiredJobs), "finished jobs")
+				} else {
+					logger.Info("Removed", len(expiredJobs), "finished jobs")
+				}
+				return nil
+			},
+		},
 		{
 			Name:    "start",
 			Aliases: []string{"s"},
@@ -39,6 +39,8 @@ require (
 	github.com/tylerb/graceful v1.2.15
 	github.com/urfave/cli v1.20.0
 	github.com/urfave/negroni v1.0.0
+	golang.org/x/crypto v0.0.0-20201002170205-7f63de1d35b0
+	gopkg.in/yaml.v2 v2.2.8
 	k8s.io/api v0.20.10
 	k8s.io/apimachinery v0.20.10
 	k8s.io/client-go v0.20.10
@@ -0,0 +1,55 @@
+package io
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+type Prompter interface {
+	Prompt(string) (string, error)
+	PromptSecret(string) (string, error)
+	IsInteractive() bool
+}
+
+type prompter struct {
+	reader *bufio.Reader
+}
+
+func GetPrompter() Prompter {
+	return &prompter{
+		reader: bufio.NewReader(os.Stdin),
+	}
+}
+
+// Prompt writes the prompt on stderr and reads a line from stdin
+func (p *prompter) Prompt(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+
+	answer, err := p.reader.ReadString('\n')
+	if err != nil && answer == "" {
+		return "", err
+	}
+	return strings.TrimSpace(answer), nil
+}
+
+// IsInteractive tells whether stdin is a terminal someone can answer prompts on
+func (p *prompter) IsInteractive() bool {
+	return terminal.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// PromptSecret behaves like Prompt, without echoing the input when stdin is a terminal
+func (p *prompter) PromptSecret(prompt string) (string, error) {
+	stdinFd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(stdinFd) {
+		return p.Prompt(prompt)
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	secret, err := terminal.ReadPassword(stdinFd)
+	fmt.Fprintln(os.Stderr)
+	return strings.TrimSpace(string(secret)), err
+}
@@ -0,0 +1,22 @@
+package io
+
+import "github.com/stretchr/testify/mock"
+
+type MockPrompter struct {
+	mock.Mock
+}
+
+func (m *MockPrompter) Prompt(prompt string) (string, error) {
+	args := m.Called(prompt)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPrompter) PromptSecret(prompt string) (string, error) {
+	args := m.Called(prompt)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPrompter) IsInteractive() bool {
+	args := m.Called()
+	return args.Bool(0)
+}
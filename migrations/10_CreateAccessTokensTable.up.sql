@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS access_tokens;
+CREATE TABLE access_tokens (
+  id serial not null primary key,
+  user_email text not null,
+  token_hash text not null,
+  created_at timestamp default now(),
+  updated_at timestamp default now()
+);
+
+DROP INDEX IF EXISTS access_tokens_token_hash_uniqueness;
+CREATE UNIQUE INDEX access_tokens_token_hash_uniqueness ON access_tokens (token_hash);
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS user_groups;
+CREATE TABLE user_groups (
+  id serial not null primary key,
+  user_email text not null,
+  group_name text not null,
+  created_at timestamp default now()
+);
+
+DROP INDEX IF EXISTS user_groups_user_email_group_name_uniqueness;
+CREATE UNIQUE INDEX user_groups_user_email_group_name_uniqueness ON user_groups (user_email, group_name);
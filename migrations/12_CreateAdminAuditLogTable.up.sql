@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS admin_audit_log;
+CREATE TABLE admin_audit_log (
+  id serial not null primary key,
+  actor text not null,
+  proc_name text not null,
+  action text not null,
+  diff text,
+  created_at timestamp default now()
+);
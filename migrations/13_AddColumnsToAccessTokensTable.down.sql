@@ -0,0 +1,4 @@
+alter table access_tokens drop column if exists name;
+alter table access_tokens drop column if exists scopes;
+alter table access_tokens drop column if exists expires_at;
+alter table access_tokens drop column if exists last_used_at;
@@ -0,0 +1,4 @@
+alter table access_tokens add column name text not null default '';
+alter table access_tokens add column scopes text not null default '';
+alter table access_tokens add column expires_at timestamp default NULL;
+alter table access_tokens add column last_used_at timestamp default NULL;
@@ -0,0 +1 @@
+alter table jobs_execution_audit_log drop column if exists cancelled_by;
@@ -0,0 +1 @@
+alter table jobs_execution_audit_log add column cancelled_by text default NULL;
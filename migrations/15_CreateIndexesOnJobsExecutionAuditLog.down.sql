@@ -0,0 +1,4 @@
+drop index if exists jobs_execution_audit_log_job_name_id_index;
+drop index if exists jobs_execution_audit_log_user_email_id_index;
+drop index if exists jobs_execution_audit_log_job_execution_status_id_index;
+drop index if exists jobs_execution_audit_log_created_at_index;
@@ -0,0 +1,4 @@
+create index if not exists jobs_execution_audit_log_job_name_id_index on jobs_execution_audit_log (job_name, id);
+create index if not exists jobs_execution_audit_log_user_email_id_index on jobs_execution_audit_log (user_email, id);
+create index if not exists jobs_execution_audit_log_job_execution_status_id_index on jobs_execution_audit_log (job_execution_status, id);
+create index if not exists jobs_execution_audit_log_created_at_index on jobs_execution_audit_log (created_at);
@@ -0,0 +1 @@
+alter table jobs_execution_audit_log add column parent_execution_id text default NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS status_callback_attempts;
+DROP TABLE IF EXISTS status_callbacks;
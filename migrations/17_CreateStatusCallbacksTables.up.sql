@@ -0,0 +1,29 @@
+DROP TABLE IF EXISTS status_callbacks;
+CREATE TABLE status_callbacks (
+  id serial not null primary key,
+  execution_id text not null,
+  url text not null,
+  secret text,
+  status text not null default 'PENDING',
+  attempts integer not null default 0,
+  next_attempt_at timestamp not null default now(),
+  created_at timestamp default now(),
+  updated_at timestamp default now()
+);
+
+DROP INDEX IF EXISTS status_callbacks_status_next_attempt_at_index;
+CREATE INDEX status_callbacks_status_next_attempt_at_index ON status_callbacks (status, next_attempt_at);
+DROP INDEX IF EXISTS status_callbacks_execution_id_index;
+CREATE INDEX status_callbacks_execution_id_index ON status_callbacks (execution_id);
+
+DROP TABLE IF EXISTS status_callback_attempts;
+CREATE TABLE status_callback_attempts (
+  id serial not null primary key,
+  callback_id integer not null references status_callbacks (id) on delete cascade,
+  response_code integer,
+  error text,
+  created_at timestamp default now()
+);
+
+DROP INDEX IF EXISTS status_callback_attempts_callback_id_index;
+CREATE INDEX status_callback_attempts_callback_id_index ON status_callback_attempts (callback_id);
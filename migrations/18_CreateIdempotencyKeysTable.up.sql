@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS idempotency_keys;
+CREATE TABLE idempotency_keys (
+  id serial not null primary key,
+  user_email text not null,
+  key text not null,
+  job_name text not null,
+  execution_id text,
+  expires_at timestamp not null,
+  created_at timestamp default now(),
+  unique (user_email, key)
+);
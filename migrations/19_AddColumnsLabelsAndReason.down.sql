@@ -0,0 +1,3 @@
+drop index if exists jobs_execution_audit_log_labels_index;
+alter table jobs_execution_audit_log drop column if exists reason;
+alter table jobs_execution_audit_log drop column if exists labels;
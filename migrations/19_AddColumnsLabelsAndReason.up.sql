@@ -0,0 +1,3 @@
+alter table jobs_execution_audit_log add column labels jsonb not null default '{}';
+alter table jobs_execution_audit_log add column reason text default NULL;
+create index if not exists jobs_execution_audit_log_labels_index on jobs_execution_audit_log using gin (labels);
@@ -0,0 +1,3 @@
+alter table idempotency_keys drop column if exists approval_id;
+alter table jobs_execution_audit_log drop column if exists approved_by;
+DROP TABLE IF EXISTS approval_requests;
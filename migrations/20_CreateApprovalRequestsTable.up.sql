@@ -0,0 +1,24 @@
+DROP TABLE IF EXISTS approval_requests;
+CREATE TABLE approval_requests (
+  id uuid not null primary key,
+  job_name text not null,
+  user_email text not null,
+  job text not null,
+  callback_secret text,
+  parent_execution_id text,
+  status text not null default 'PENDING',
+  decided_by text,
+  execution_id text,
+  expires_at timestamp not null,
+  created_at timestamp default now(),
+  updated_at timestamp default now()
+);
+
+DROP INDEX IF EXISTS approval_requests_status_expires_at_index;
+CREATE INDEX approval_requests_status_expires_at_index ON approval_requests (status, expires_at);
+
+alter table jobs_execution_audit_log drop column if exists approved_by;
+alter table jobs_execution_audit_log add column approved_by text default NULL;
+
+alter table idempotency_keys drop column if exists approval_id;
+alter table idempotency_keys add column approval_id text default NULL;
@@ -1,29 +1,40 @@
 package audit
 
 import (
+	"encoding/json"
+	"reflect"
+
 	"github.com/getsentry/raven-go"
-	"proctor/proctord/kubernetes"
+	"proctor/proctord/backend"
 	"proctor/proctord/logger"
 	"proctor/proctord/storage"
 	"proctor/proctord/storage/postgres"
 	"proctor/proctord/utility"
 )
 
+const (
+	MetadataCreated = "metadata_created"
+	MetadataUpdated = "metadata_updated"
+	SecretsCreated  = "secrets_created"
+	SecretsUpdated  = "secrets_updated"
+)
+
 type Auditor interface {
 	JobsExecutionAndStatus(*postgres.JobsExecutionAuditLog)
 	JobsExecution(*postgres.JobsExecutionAuditLog)
 	JobsExecutionStatus(string) (string, error)
+	AdminAction(string, string, string, interface{}, interface{})
 }
 
 type auditor struct {
-	store      storage.Store
-	kubeClient kubernetes.Client
+	store            storage.Store
+	executionBackend backend.ExecutionBackend
 }
 
-func New(store storage.Store, kubeClient kubernetes.Client) Auditor {
+func New(store storage.Store, executionBackend backend.ExecutionBackend) Auditor {
 	return &auditor{
-		store:      store,
-		kubeClient: kubeClient,
+		store:            store,
+		executionBackend: executionBackend,
 	}
 }
 
@@ -45,7 +56,7 @@ func (auditor *auditor) JobsExecution(jobsExecutionAuditLog *postgres.JobsExecut
 }
 
 func (auditor *auditor) JobsExecutionStatus(jobExecutionID string) (string, error) {
-	status, err := auditor.kubeClient.JobExecutionStatus(jobExecutionID)
+	status, err := auditor.executionBackend.JobExecutionStatus(jobExecutionID)
 	if err != nil {
 		logger.Error("Error getting job execution status", err)
 		raven.CaptureError(err, nil)
@@ -61,3 +72,69 @@ func (auditor *auditor) JobsExecutionStatus(jobExecutionID string) (string, erro
 
 	return status, err
 }
+
+type fieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// AdminAction records a change made by actor to a proc, the diff holds the
+// fields of before and after that differ, so callers must leave secret values out of both
+func (auditor *auditor) AdminAction(actor, procName, action string, before, after interface{}) {
+	diff, err := diff(before, after)
+	if err != nil {
+		logger.Error("Error computing admin action diff", err)
+		raven.CaptureError(err, nil)
+	}
+
+	err = auditor.store.AuditAdminAction(&postgres.AdminAuditLog{
+		Actor:    actor,
+		ProcName: procName,
+		Action:   action,
+		Diff:     diff,
+	})
+	if err != nil {
+		logger.Error("Error auditing admin action", err)
+		raven.CaptureError(err, nil)
+	}
+}
+
+func diff(before, after interface{}) (string, error) {
+	beforeFields, err := fields(before)
+	if err != nil {
+		return "", err
+	}
+	afterFields, err := fields(after)
+	if err != nil {
+		return "", err
+	}
+
+	changes := map[string]fieldChange{}
+	for name, value := range afterFields {
+		if !reflect.DeepEqual(beforeFields[name], value) {
+			changes[name] = fieldChange{From: beforeFields[name], To: value}
+		}
+	}
+	for name, value := range beforeFields {
+		if _, ok := afterFields[name]; !ok {
+			changes[name] = fieldChange{From: value}
+		}
+	}
+
+	encodedChanges, err := json.Marshal(changes)
+	return string(encodedChanges), err
+}
+
+func fields(value interface{}) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if value == nil || reflect.ValueOf(value).Kind() == reflect.Ptr && reflect.ValueOf(value).IsNil() {
+		return fields, nil
+	}
+
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(encodedValue, &fields)
+	return fields, err
+}
@@ -21,3 +21,7 @@ func (m *MockAuditor) JobsExecutionStatus(jobExecutionID string) (string, error)
 	args := m.Called(jobExecutionID)
 	return args.String(0), args.Error(1)
 }
+
+func (m *MockAuditor) AdminAction(actor, procName, action string, before, after interface{}) {
+	m.Called(actor, procName, action, before, after)
+}
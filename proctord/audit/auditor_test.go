@@ -3,7 +3,7 @@ package audit
 import (
 	"testing"
 
-	"proctor/proctord/kubernetes"
+	"proctor/proctord/backend"
 	"proctor/proctord/storage"
 	"proctor/proctord/storage/postgres"
 	"proctor/proctord/utility"
@@ -11,8 +11,8 @@ import (
 
 func TestJobsExecutionAuditing(t *testing.T) {
 	mockStore := &storage.MockStore{}
-	mockKubeClient := &kubernetes.MockClient{}
-	testAuditor := New(mockStore, mockKubeClient)
+	mockExecutionBackend := &backend.MockExecutionBackend{}
+	testAuditor := New(mockStore, mockExecutionBackend)
 	jobsExecutionAuditLog := &postgres.JobsExecutionAuditLog{
 		JobName: "any-job-name",
 	}
@@ -22,30 +22,30 @@ func TestJobsExecutionAuditing(t *testing.T) {
 	testAuditor.JobsExecution(jobsExecutionAuditLog)
 
 	mockStore.AssertExpectations(t)
-	mockKubeClient.AssertExpectations(t)
+	mockExecutionBackend.AssertExpectations(t)
 }
 
 func TestAuditJobsExecutionStatusAuditing(t *testing.T) {
 	mockStore := &storage.MockStore{}
-	mockKubeClient := &kubernetes.MockClient{}
-	testAuditor := New(mockStore, mockKubeClient)
+	mockExecutionBackend := &backend.MockExecutionBackend{}
+	testAuditor := New(mockStore, mockExecutionBackend)
 
 	jobExecutionID := "job-execution-id"
 	jobExecutionStatus := "job-execution-status"
 
-	mockKubeClient.On("JobExecutionStatus", jobExecutionID).Return(jobExecutionStatus, nil)
+	mockExecutionBackend.On("JobExecutionStatus", jobExecutionID).Return(jobExecutionStatus, nil)
 	mockStore.On("UpdateJobsExecutionAuditLog", jobExecutionID, jobExecutionStatus).Return(nil).Once()
 
 	testAuditor.JobsExecutionStatus(jobExecutionID)
 
 	mockStore.AssertExpectations(t)
-	mockKubeClient.AssertExpectations(t)
+	mockExecutionBackend.AssertExpectations(t)
 }
 
 func TestAuditJobsExecutionAndStatusAuditing(t *testing.T) {
 	mockStore := &storage.MockStore{}
-	mockKubeClient := &kubernetes.MockClient{}
-	testAuditor := New(mockStore, mockKubeClient)
+	mockExecutionBackend := &backend.MockExecutionBackend{}
+	testAuditor := New(mockStore, mockExecutionBackend)
 
 	jobExecutionID := "job-execution-id"
 	jobExecutionStatus := "job-execution-status"
@@ -57,11 +57,48 @@ func TestAuditJobsExecutionAndStatusAuditing(t *testing.T) {
 
 	mockStore.On("AuditJobsExecution", jobsExecutionAuditLog).Return(nil).Once()
 
-	mockKubeClient.On("JobExecutionStatus", jobExecutionID).Return(jobExecutionStatus, nil)
+	mockExecutionBackend.On("JobExecutionStatus", jobExecutionID).Return(jobExecutionStatus, nil)
 	mockStore.On("UpdateJobsExecutionAuditLog", jobExecutionID, jobExecutionStatus).Return(nil).Once()
 
 	testAuditor.JobsExecutionAndStatus(jobsExecutionAuditLog)
 
 	mockStore.AssertExpectations(t)
-	mockKubeClient.AssertExpectations(t)
+	mockExecutionBackend.AssertExpectations(t)
+}
+
+func TestAdminActionAuditing(t *testing.T) {
+	mockStore := &storage.MockStore{}
+	mockExecutionBackend := &backend.MockExecutionBackend{}
+	testAuditor := New(mockStore, mockExecutionBackend)
+
+	before := map[string]interface{}{"image_name": "image:1", "description": "sample", "env_vars": []string{"ARG"}}
+	after := map[string]interface{}{"image_name": "image:2", "description": "sample"}
+
+	mockStore.On("AuditAdminAction", &postgres.AdminAuditLog{
+		Actor:    "mrproctor@example.com",
+		ProcName: "sample-job",
+		Action:   "metadata_updated",
+		Diff:     `{"env_vars":{"from":["ARG"],"to":null},"image_name":{"from":"image:1","to":"image:2"}}`,
+	}).Return(nil).Once()
+
+	testAuditor.AdminAction("mrproctor@example.com", "sample-job", "metadata_updated", before, after)
+
+	mockStore.AssertExpectations(t)
+}
+
+func TestAdminActionAuditingForCreation(t *testing.T) {
+	mockStore := &storage.MockStore{}
+	mockExecutionBackend := &backend.MockExecutionBackend{}
+	testAuditor := New(mockStore, mockExecutionBackend)
+
+	mockStore.On("AuditAdminAction", &postgres.AdminAuditLog{
+		Actor:    "mrproctor@example.com",
+		ProcName: "sample-job",
+		Action:   "secrets_created",
+		Diff:     `{"secret_names":{"from":null,"to":["k1"]}}`,
+	}).Return(nil).Once()
+
+	testAuditor.AdminAction("mrproctor@example.com", "sample-job", "secrets_created", nil, map[string][]string{"secret_names": {"k1"}})
+
+	mockStore.AssertExpectations(t)
 }
@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"proctor/proctord/config"
+	"proctor/proctord/storage"
+)
+
+const FileStrategy = "file"
+const PostgresStrategy = "postgres"
+
+var ErrInvalidCredentials = errors.New("invalid email id or access token")
+
+type Authenticator interface {
+	Authenticate(string, string) (User, error)
+}
+
+// New builds the authenticator for the configured strategy, access tokens
+// issued by proctord are accepted irrespective of the strategy
+func New(store storage.Store) (Authenticator, error) {
+	switch config.AuthStrategy() {
+	case FileStrategy:
+		fileAuthenticator, err := NewFileAuthenticator(config.AuthTokensFile())
+		if err != nil {
+			return nil, err
+		}
+		return NewChainAuthenticator(fileAuthenticator, NewStoreAuthenticator(store)), nil
+	case PostgresStrategy:
+		return NewStoreAuthenticator(store), nil
+	default:
+		return nil, fmt.Errorf("unsupported auth strategy: %q", config.AuthStrategy())
+	}
+}
+
+func HashAccessToken(accessToken string) string {
+	hash := sha256.Sum256([]byte(accessToken))
+	return hex.EncodeToString(hash[:])
+}
+
+func GenerateAccessToken() (string, error) {
+	token := make([]byte, 32)
+	_, err := rand.Read(token)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
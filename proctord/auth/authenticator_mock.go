@@ -0,0 +1,12 @@
+package auth
+
+import "github.com/stretchr/testify/mock"
+
+type MockAuthenticator struct {
+	mock.Mock
+}
+
+func (m *MockAuthenticator) Authenticate(userEmail, accessToken string) (User, error) {
+	args := m.Called(userEmail, accessToken)
+	return args.Get(0).(User), args.Error(1)
+}
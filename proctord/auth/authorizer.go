@@ -0,0 +1,62 @@
+package auth
+
+import "proctor/proctord/config"
+
+type Authorizer interface {
+	Authorize(User, []string) (bool, error)
+	Role(User) (Role, error)
+}
+
+type authorizer struct {
+	groupResolver GroupResolver
+}
+
+func NewAuthorizer(groupResolver GroupResolver) Authorizer {
+	return &authorizer{
+		groupResolver: groupResolver,
+	}
+}
+
+// Authorize allows everyone when a proc has no authorized groups,
+// otherwise the user needs to be a member of at least one of them
+func (authorizer *authorizer) Authorize(user User, authorizedGroups []string) (bool, error) {
+	if len(authorizedGroups) == 0 {
+		return true, nil
+	}
+
+	userGroups, err := authorizer.groupResolver.Groups(user.Email)
+	if err != nil {
+		return false, err
+	}
+
+	for _, authorizedGroup := range authorizedGroups {
+		for _, userGroup := range userGroups {
+			if authorizedGroup == userGroup {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// Role is derived from membership of the configured admin and publisher groups,
+// users in neither of them get the user role
+func (authorizer *authorizer) Role(user User) (Role, error) {
+	userGroups, err := authorizer.groupResolver.Groups(user.Email)
+	if err != nil {
+		return UserRole, err
+	}
+
+	role := UserRole
+	for _, userGroup := range userGroups {
+		switch {
+		case userGroup == "":
+			continue
+		case userGroup == config.AdminGroup():
+			return AdminRole, nil
+		case userGroup == config.PublisherGroup():
+			role = PublisherRole
+		}
+	}
+	return role, nil
+}
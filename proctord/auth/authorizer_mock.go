@@ -0,0 +1,17 @@
+package auth
+
+import "github.com/stretchr/testify/mock"
+
+type MockAuthorizer struct {
+	mock.Mock
+}
+
+func (m *MockAuthorizer) Authorize(user User, authorizedGroups []string) (bool, error) {
+	args := m.Called(user, authorizedGroups)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAuthorizer) Role(user User) (Role, error) {
+	args := m.Called(user)
+	return args.Get(0).(Role), args.Error(1)
+}
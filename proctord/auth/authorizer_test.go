@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizeForMemberOfAuthorizedGroup(t *testing.T) {
+	mockGroupResolver := &MockGroupResolver{}
+	testAuthorizer := NewAuthorizer(mockGroupResolver)
+	user := User{Email: "mrproctor@example.com"}
+
+	mockGroupResolver.On("Groups", user.Email).Return([]string{"group_three", "group_two"}, nil).Once()
+
+	authorized, err := testAuthorizer.Authorize(user, []string{"group_one", "group_two"})
+
+	assert.NoError(t, err)
+	assert.True(t, authorized)
+	mockGroupResolver.AssertExpectations(t)
+}
+
+func TestAuthorizeForNonMemberOfAuthorizedGroups(t *testing.T) {
+	mockGroupResolver := &MockGroupResolver{}
+	testAuthorizer := NewAuthorizer(mockGroupResolver)
+	user := User{Email: "mrproctor@example.com"}
+
+	mockGroupResolver.On("Groups", user.Email).Return([]string{"group_three"}, nil).Once()
+
+	authorized, err := testAuthorizer.Authorize(user, []string{"group_one", "group_two"})
+
+	assert.NoError(t, err)
+	assert.False(t, authorized)
+	mockGroupResolver.AssertExpectations(t)
+}
+
+func TestAuthorizeForProcWithoutAuthorizedGroups(t *testing.T) {
+	mockGroupResolver := &MockGroupResolver{}
+	testAuthorizer := NewAuthorizer(mockGroupResolver)
+
+	authorized, err := testAuthorizer.Authorize(User{Email: "mrproctor@example.com"}, []string{})
+
+	assert.NoError(t, err)
+	assert.True(t, authorized)
+	mockGroupResolver.AssertNotCalled(t, "Groups", "mrproctor@example.com")
+}
+
+func TestAuthorizeForGroupResolverFailure(t *testing.T) {
+	mockGroupResolver := &MockGroupResolver{}
+	testAuthorizer := NewAuthorizer(mockGroupResolver)
+	user := User{Email: "mrproctor@example.com"}
+
+	mockGroupResolver.On("Groups", user.Email).Return([]string{}, errors.New("error")).Once()
+
+	authorized, err := testAuthorizer.Authorize(user, []string{"group_one"})
+
+	assert.EqualError(t, err, "error")
+	assert.False(t, authorized)
+	mockGroupResolver.AssertExpectations(t)
+}
+
+func TestRoleForMembersOfRoleGroups(t *testing.T) {
+	os.Setenv("PROCTOR_ADMIN_GROUP", "proctor-admins")
+	os.Setenv("PROCTOR_PUBLISHER_GROUP", "proctor-publishers")
+	viper.AutomaticEnv()
+
+	for groups, expectedRole := range map[string]Role{
+		"proctor-admins":                    AdminRole,
+		"proctor-publishers":                PublisherRole,
+		"proctor-publishers,proctor-admins": AdminRole,
+		"group_one":                         UserRole,
+	} {
+		mockGroupResolver := &MockGroupResolver{}
+		testAuthorizer := NewAuthorizer(mockGroupResolver)
+		user := User{Email: "mrproctor@example.com"}
+
+		mockGroupResolver.On("Groups", user.Email).Return(strings.Split(groups, ","), nil).Once()
+
+		role, err := testAuthorizer.Role(user)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedRole, role, groups)
+		mockGroupResolver.AssertExpectations(t)
+	}
+}
+
+func TestRoleForGroupResolverFailure(t *testing.T) {
+	mockGroupResolver := &MockGroupResolver{}
+	testAuthorizer := NewAuthorizer(mockGroupResolver)
+	user := User{Email: "mrproctor@example.com"}
+
+	mockGroupResolver.On("Groups", user.Email).Return([]string{}, errors.New("error")).Once()
+
+	role, err := testAuthorizer.Role(user)
+
+	assert.EqualError(t, err, "error")
+	assert.Equal(t, UserRole, role)
+	mockGroupResolver.AssertExpectations(t)
+}
+
+func TestRoleIncludes(t *testing.T) {
+	assert.True(t, AdminRole.Includes(PublisherRole))
+	assert.True(t, PublisherRole.Includes(PublisherRole))
+	assert.False(t, PublisherRole.Includes(AdminRole))
+	assert.False(t, UserRole.Includes(PublisherRole))
+}
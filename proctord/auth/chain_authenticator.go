@@ -0,0 +1,24 @@
+package auth
+
+type chainAuthenticator struct {
+	authenticators []Authenticator
+}
+
+// NewChainAuthenticator tries each authenticator in order until one of them
+// accepts the credentials
+func NewChainAuthenticator(authenticators ...Authenticator) Authenticator {
+	return &chainAuthenticator{
+		authenticators: authenticators,
+	}
+}
+
+func (authenticator *chainAuthenticator) Authenticate(userEmail, accessToken string) (User, error) {
+	for _, chainedAuthenticator := range authenticator.authenticators {
+		user, err := chainedAuthenticator.Authenticate(userEmail, accessToken)
+		if err != ErrInvalidCredentials {
+			return user, err
+		}
+	}
+
+	return User{}, ErrInvalidCredentials
+}
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"crypto/subtle"
+
+	"github.com/spf13/viper"
+)
+
+type fileUser struct {
+	Email           string `mapstructure:"email"`
+	AccessTokenHash string `mapstructure:"access_token_hash"`
+}
+
+type fileAuthenticator struct {
+	tokenHashes map[string][]string
+}
+
+// NewFileAuthenticator reads users from a yaml or json file of the form
+// users: [{email: ..., access_token_hash: <hex encoded sha256 of token>}]
+func NewFileAuthenticator(path string) (Authenticator, error) {
+	tokensFile := viper.New()
+	tokensFile.SetConfigFile(path)
+	err := tokensFile.ReadInConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var users []fileUser
+	err = tokensFile.UnmarshalKey("users", &users)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenHashes := make(map[string][]string)
+	for _, user := range users {
+		tokenHashes[user.Email] = append(tokenHashes[user.Email], user.AccessTokenHash)
+	}
+
+	return &fileAuthenticator{
+		tokenHashes: tokenHashes,
+	}, nil
+}
+
+func (authenticator *fileAuthenticator) Authenticate(userEmail, accessToken string) (User, error) {
+	if userEmail == "" || accessToken == "" {
+		return User{}, ErrInvalidCredentials
+	}
+
+	accessTokenHash := HashAccessToken(accessToken)
+	for _, tokenHash := range authenticator.tokenHashes[userEmail] {
+		if subtle.ConstantTimeCompare([]byte(tokenHash), []byte(accessTokenHash)) == 1 {
+			return User{Email: userEmail}, nil
+		}
+	}
+
+	return User{}, ErrInvalidCredentials
+}
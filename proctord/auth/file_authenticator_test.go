@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type FileAuthenticatorTestSuite struct {
+	suite.Suite
+	tokensDir         string
+	testAuthenticator Authenticator
+}
+
+func (s *FileAuthenticatorTestSuite) SetupTest() {
+	t := s.T()
+
+	tokensDir, err := ioutil.TempDir("", "proctord-auth")
+	assert.NoError(t, err)
+	s.tokensDir = tokensDir
+
+	tokensFile := filepath.Join(tokensDir, "tokens.yaml")
+	tokensFileContent := fmt.Sprintf("users:\n  - email: mrproctor@example.com\n    access_token_hash: %s\n", HashAccessToken("access-token"))
+	err = ioutil.WriteFile(tokensFile, []byte(tokensFileContent), 0600)
+	assert.NoError(t, err)
+
+	s.testAuthenticator, err = NewFileAuthenticator(tokensFile)
+	assert.NoError(t, err)
+}
+
+func (s *FileAuthenticatorTestSuite) TearDownTest() {
+	os.RemoveAll(s.tokensDir)
+}
+
+func (s *FileAuthenticatorTestSuite) TestAuthenticate() {
+	t := s.T()
+
+	user, err := s.testAuthenticator.Authenticate("mrproctor@example.com", "access-token")
+
+	assert.NoError(t, err)
+	assert.Equal(t, User{Email: "mrproctor@example.com"}, user)
+}
+
+func (s *FileAuthenticatorTestSuite) TestAuthenticateForInvalidAccessToken() {
+	t := s.T()
+
+	_, err := s.testAuthenticator.Authenticate("mrproctor@example.com", "invalid-access-token")
+
+	assert.Equal(t, ErrInvalidCredentials, err)
+}
+
+func (s *FileAuthenticatorTestSuite) TestAuthenticateForUnknownUser() {
+	t := s.T()
+
+	_, err := s.testAuthenticator.Authenticate("unknown@example.com", "access-token")
+
+	assert.Equal(t, ErrInvalidCredentials, err)
+}
+
+func (s *FileAuthenticatorTestSuite) TestAuthenticateForMissingCredentials() {
+	t := s.T()
+
+	_, err := s.testAuthenticator.Authenticate("", "")
+
+	assert.Equal(t, ErrInvalidCredentials, err)
+}
+
+func TestNewFileAuthenticatorForMissingFile(t *testing.T) {
+	_, err := NewFileAuthenticator("/non/existent/tokens.yaml")
+
+	assert.Error(t, err)
+}
+
+func TestFileAuthenticatorTestSuite(t *testing.T) {
+	suite.Run(t, new(FileAuthenticatorTestSuite))
+}
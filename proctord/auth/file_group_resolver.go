@@ -0,0 +1,44 @@
+package auth
+
+import "github.com/spf13/viper"
+
+type fileGroup struct {
+	Name    string   `mapstructure:"name"`
+	Members []string `mapstructure:"members"`
+}
+
+type fileGroupResolver struct {
+	userGroups map[string][]string
+}
+
+// NewFileGroupResolver reads group memberships from a yaml or json file of the form
+// groups: [{name: ..., members: [<email>, ...]}]
+func NewFileGroupResolver(path string) (GroupResolver, error) {
+	groupsFile := viper.New()
+	groupsFile.SetConfigFile(path)
+	err := groupsFile.ReadInConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []fileGroup
+	err = groupsFile.UnmarshalKey("groups", &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	userGroups := make(map[string][]string)
+	for _, group := range groups {
+		for _, member := range group.Members {
+			userGroups[member] = append(userGroups[member], group.Name)
+		}
+	}
+
+	return &fileGroupResolver{
+		userGroups: userGroups,
+	}, nil
+}
+
+func (resolver *fileGroupResolver) Groups(userEmail string) ([]string, error) {
+	return resolver.userGroups[userEmail], nil
+}
@@ -0,0 +1,23 @@
+package auth
+
+import (
+	"fmt"
+
+	"proctor/proctord/config"
+	"proctor/proctord/storage"
+)
+
+type GroupResolver interface {
+	Groups(string) ([]string, error)
+}
+
+func NewGroupResolver(store storage.Store) (GroupResolver, error) {
+	switch config.GroupsStrategy() {
+	case FileStrategy:
+		return NewFileGroupResolver(config.GroupsFile())
+	case PostgresStrategy:
+		return NewStoreGroupResolver(store), nil
+	default:
+		return nil, fmt.Errorf("unsupported groups strategy: %q", config.GroupsStrategy())
+	}
+}
@@ -0,0 +1,12 @@
+package auth
+
+import "github.com/stretchr/testify/mock"
+
+type MockGroupResolver struct {
+	mock.Mock
+}
+
+func (m *MockGroupResolver) Groups(userEmail string) ([]string, error) {
+	args := m.Called(userEmail)
+	return args.Get(0).([]string), args.Error(1)
+}
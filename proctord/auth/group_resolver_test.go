@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"proctor/proctord/storage"
+	"proctor/proctord/storage/postgres"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileGroupResolverGroups(t *testing.T) {
+	groupsDir, err := ioutil.TempDir("", "proctord-auth")
+	assert.NoError(t, err)
+	defer os.RemoveAll(groupsDir)
+
+	groupsFile := filepath.Join(groupsDir, "groups.json")
+	groupsFileContent := `{"groups": [{"name": "Group_One", "members": ["mrproctor@example.com"]}, {"name": "group_two", "members": ["mrproctor@example.com", "other@example.com"]}]}`
+	err = ioutil.WriteFile(groupsFile, []byte(groupsFileContent), 0600)
+	assert.NoError(t, err)
+
+	testGroupResolver, err := NewFileGroupResolver(groupsFile)
+	assert.NoError(t, err)
+
+	groups, err := testGroupResolver.Groups("mrproctor@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Group_One", "group_two"}, groups)
+
+	groups, err = testGroupResolver.Groups("unknown@example.com")
+	assert.NoError(t, err)
+	assert.Empty(t, groups)
+}
+
+func TestStoreGroupResolverGroups(t *testing.T) {
+	mockStore := &storage.MockStore{}
+	testGroupResolver := NewStoreGroupResolver(mockStore)
+
+	userGroups := []postgres.UserGroup{
+		{UserEmail: "mrproctor@example.com", GroupName: "group_one"},
+		{UserEmail: "mrproctor@example.com", GroupName: "group_two"},
+	}
+	mockStore.On("GetUserGroups", "mrproctor@example.com").Return(userGroups, nil).Once()
+
+	groups, err := testGroupResolver.Groups("mrproctor@example.com")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"group_one", "group_two"}, groups)
+	mockStore.AssertExpectations(t)
+}
+
+func TestStoreGroupResolverGroupsForStoreFailure(t *testing.T) {
+	mockStore := &storage.MockStore{}
+	testGroupResolver := NewStoreGroupResolver(mockStore)
+
+	mockStore.On("GetUserGroups", "mrproctor@example.com").Return([]postgres.UserGroup{}, errors.New("error")).Once()
+
+	_, err := testGroupResolver.Groups("mrproctor@example.com")
+
+	assert.EqualError(t, err, "error")
+	mockStore.AssertExpectations(t)
+}
@@ -0,0 +1,21 @@
+package auth
+
+type Role string
+
+const (
+	UserRole      Role = "user"
+	PublisherRole Role = "publisher"
+	AdminRole     Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	UserRole:      0,
+	PublisherRole: 1,
+	AdminRole:     2,
+}
+
+// Includes reports whether a user holding role is allowed
+// everything a user holding the required role is
+func (role Role) Includes(required Role) bool {
+	return roleRanks[role] >= roleRanks[required]
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"proctor/proctord/logger"
+	"proctor/proctord/storage"
+)
+
+type storeAuthenticator struct {
+	store storage.Store
+}
+
+func NewStoreAuthenticator(store storage.Store) Authenticator {
+	return &storeAuthenticator{
+		store: store,
+	}
+}
+
+func (authenticator *storeAuthenticator) Authenticate(userEmail, accessToken string) (User, error) {
+	if userEmail == "" || accessToken == "" {
+		return User{}, ErrInvalidCredentials
+	}
+
+	accessTokens, err := authenticator.store.GetAccessTokens(userEmail)
+	if err != nil {
+		return User{}, err
+	}
+
+	accessTokenHash := HashAccessToken(accessToken)
+	for _, token := range accessTokens {
+		if subtle.ConstantTimeCompare([]byte(token.TokenHash), []byte(accessTokenHash)) != 1 {
+			continue
+		}
+		if token.ExpiresAt.Valid && !token.ExpiresAt.Time.After(time.Now()) {
+			return User{}, ErrInvalidCredentials
+		}
+
+		err = authenticator.store.UpdateAccessTokenLastUsedAt(token.ID)
+		if err != nil {
+			logger.Error("Error updating last used time of access token", err.Error())
+		}
+
+		return User{Email: userEmail, Scopes: ParseScopes(token.Scopes)}, nil
+	}
+
+	return User{}, ErrInvalidCredentials
+}
+
+// ParseScopes reads the comma separated scopes access tokens are stored with
+func ParseScopes(scopes string) []string {
+	parsedScopes := []string{}
+	for _, scope := range strings.Split(scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			parsedScopes = append(parsedScopes, scope)
+		}
+	}
+	return parsedScopes
+}
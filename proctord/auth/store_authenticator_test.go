@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"proctor/proctord/storage"
+	"proctor/proctord/storage/postgres"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStoreAuthenticatorAuthenticate(t *testing.T) {
+	mockStore := &storage.MockStore{}
+	testAuthenticator := NewStoreAuthenticator(mockStore)
+
+	accessTokens := []postgres.AccessToken{
+		{UserEmail: "mrproctor@example.com", TokenHash: HashAccessToken("old-access-token")},
+		{ID: 2, UserEmail: "mrproctor@example.com", TokenHash: HashAccessToken("access-token")},
+	}
+	mockStore.On("GetAccessTokens", "mrproctor@example.com").Return(accessTokens, nil).Once()
+	mockStore.On("UpdateAccessTokenLastUsedAt", int64(2)).Return(nil).Once()
+
+	user, err := testAuthenticator.Authenticate("mrproctor@example.com", "access-token")
+
+	assert.NoError(t, err)
+	assert.Equal(t, User{Email: "mrproctor@example.com", Scopes: []string{}}, user)
+	mockStore.AssertExpectations(t)
+}
+
+func TestStoreAuthenticatorAuthenticateForScopedAccessToken(t *testing.T) {
+	mockStore := &storage.MockStore{}
+	testAuthenticator := NewStoreAuthenticator(mockStore)
+
+	accessTokens := []postgres.AccessToken{
+		{
+			ID:        3,
+			UserEmail: "mrproctor@example.com",
+			TokenHash: HashAccessToken("access-token"),
+			Scopes:    "execute,schedule",
+			ExpiresAt: pq.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+		},
+	}
+	mockStore.On("GetAccessTokens", "mrproctor@example.com").Return(accessTokens, nil).Once()
+	mockStore.On("UpdateAccessTokenLastUsedAt", int64(3)).Return(nil).Once()
+
+	user, err := testAuthenticator.Authenticate("mrproctor@example.com", "access-token")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{ExecuteScope, ScheduleScope}, user.Scopes)
+	mockStore.AssertExpectations(t)
+}
+
+func TestStoreAuthenticatorAuthenticateForExpiredAccessToken(t *testing.T) {
+	mockStore := &storage.MockStore{}
+	testAuthenticator := NewStoreAuthenticator(mockStore)
+
+	accessTokens := []postgres.AccessToken{
+		{
+			UserEmail: "mrproctor@example.com",
+			TokenHash: HashAccessToken("access-token"),
+			ExpiresAt: pq.NullTime{Time: time.Now().Add(-time.Hour), Valid: true},
+		},
+	}
+	mockStore.On("GetAccessTokens", "mrproctor@example.com").Return(accessTokens, nil).Once()
+
+	_, err := testAuthenticator.Authenticate("mrproctor@example.com", "access-token")
+
+	assert.Equal(t, ErrInvalidCredentials, err)
+	mockStore.AssertNotCalled(t, "UpdateAccessTokenLastUsedAt", mock.Anything)
+}
+
+func TestStoreAuthenticatorAuthenticateForInvalidAccessToken(t *testing.T) {
+	mockStore := &storage.MockStore{}
+	testAuthenticator := NewStoreAuthenticator(mockStore)
+
+	accessTokens := []postgres.AccessToken{
+		{UserEmail: "mrproctor@example.com", TokenHash: HashAccessToken("access-token")},
+	}
+	mockStore.On("GetAccessTokens", "mrproctor@example.com").Return(accessTokens, nil).Once()
+
+	_, err := testAuthenticator.Authenticate("mrproctor@example.com", "invalid-access-token")
+
+	assert.Equal(t, ErrInvalidCredentials, err)
+	mockStore.AssertExpectations(t)
+}
+
+func TestStoreAuthenticatorAuthenticateForMissingCredentials(t *testing.T) {
+	mockStore := &storage.MockStore{}
+	testAuthenticator := NewStoreAuthenticator(mockStore)
+
+	_, err := testAuthenticator.Authenticate("mrproctor@example.com", "")
+
+	assert.Equal(t, ErrInvalidCredentials, err)
+	mockStore.AssertNotCalled(t, "GetAccessTokens", "mrproctor@example.com")
+}
+
+func TestStoreAuthenticatorAuthenticateForStoreFailure(t *testing.T) {
+	mockStore := &storage.MockStore{}
+	testAuthenticator := NewStoreAuthenticator(mockStore)
+
+	mockStore.On("GetAccessTokens", "mrproctor@example.com").Return([]postgres.AccessToken{}, errors.New("error")).Once()
+
+	_, err := testAuthenticator.Authenticate("mrproctor@example.com", "access-token")
+
+	assert.EqualError(t, err, "error")
+	mockStore.AssertExpectations(t)
+}
+
+func TestChainAuthenticatorAuthenticate(t *testing.T) {
+	firstAuthenticator := &MockAuthenticator{}
+	secondAuthenticator := &MockAuthenticator{}
+	testAuthenticator := NewChainAuthenticator(firstAuthenticator, secondAuthenticator)
+
+	firstAuthenticator.On("Authenticate", "mrproctor@example.com", "access-token").Return(User{}, ErrInvalidCredentials).Once()
+	secondAuthenticator.On("Authenticate", "mrproctor@example.com", "access-token").Return(User{Email: "mrproctor@example.com"}, nil).Once()
+
+	user, err := testAuthenticator.Authenticate("mrproctor@example.com", "access-token")
+
+	assert.NoError(t, err)
+	assert.Equal(t, User{Email: "mrproctor@example.com"}, user)
+	firstAuthenticator.AssertExpectations(t)
+	secondAuthenticator.AssertExpectations(t)
+}
+
+func TestChainAuthenticatorAuthenticateForFailure(t *testing.T) {
+	firstAuthenticator := &MockAuthenticator{}
+	secondAuthenticator := &MockAuthenticator{}
+	testAuthenticator := NewChainAuthenticator(firstAuthenticator, secondAuthenticator)
+
+	firstAuthenticator.On("Authenticate", "mrproctor@example.com", "access-token").Return(User{}, errors.New("error")).Once()
+
+	_, err := testAuthenticator.Authenticate("mrproctor@example.com", "access-token")
+
+	assert.EqualError(t, err, "error")
+	secondAuthenticator.AssertNotCalled(t, "Authenticate", mock.Anything, mock.Anything)
+}
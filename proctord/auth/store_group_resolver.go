@@ -0,0 +1,26 @@
+package auth
+
+import "proctor/proctord/storage"
+
+type storeGroupResolver struct {
+	store storage.Store
+}
+
+func NewStoreGroupResolver(store storage.Store) GroupResolver {
+	return &storeGroupResolver{
+		store: store,
+	}
+}
+
+func (resolver *storeGroupResolver) Groups(userEmail string) ([]string, error) {
+	userGroups, err := resolver.store.GetUserGroups(userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]string, len(userGroups))
+	for i, userGroup := range userGroups {
+		groups[i] = userGroup.GroupName
+	}
+	return groups, nil
+}
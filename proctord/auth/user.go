@@ -0,0 +1,43 @@
+package auth
+
+import "context"
+
+type contextKey string
+
+const userContextKey = contextKey("user")
+
+const (
+	ExecuteScope  = "execute"
+	ScheduleScope = "schedule"
+	PublishScope  = "publish"
+)
+
+var Scopes = []string{ExecuteScope, ScheduleScope, PublishScope}
+
+type User struct {
+	Email string
+	// Scopes the user authenticated with, no scopes means unrestricted access
+	Scopes []string
+}
+
+func (user User) HasScope(scope string) bool {
+	if len(user.Scopes) == 0 {
+		return true
+	}
+
+	for _, userScope := range user.Scopes {
+		if userScope == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func NewContext(ctx context.Context, user User) context.Context {
+	return context.WithValue(ctx, userContextKey, user)
+}
+
+func FromContext(ctx context.Context) (User, bool) {
+	user, ok := ctx.Value(userContextKey).(User)
+	return user, ok
+}
@@ -0,0 +1,52 @@
+package backend
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"proctor/proctord/jobs/metadata/pod"
+	"proctor/proctord/jobs/metadata/resources"
+)
+
+const Kubernetes = "kubernetes"
+const Local = "local"
+
+// ErrNoLogs is returned for the logs of a finished job which has none left, e.g. when its pod was evicted
+var ErrNoLogs = errors.New("no logs left for the job")
+
+type JobOptions struct {
+	Resources             resources.Requirements
+	ActiveDeadlineSeconds *int64
+	BackoffLimit          *int32
+	Secrets               map[string]string
+	Labels                map[string]string
+	Reason                string
+	Pod                   pod.Spec
+}
+
+// JobStatus is the execution status of a job as of one of its changes
+type JobStatus struct {
+	JobExecutionID string
+	Status         string
+}
+
+// FinishedJob is a job created by proctor which has succeeded or failed
+type FinishedJob struct {
+	Name       string
+	Status     string
+	FinishedAt time.Time
+}
+
+// ExecutionBackend runs procs and reports on their executions, statuses are
+// the utility.Job* ones and a job which isn't known comes back as utility.JobNotFound
+type ExecutionBackend interface {
+	ExecuteJob(string, map[string]string, JobOptions) (string, error)
+	StreamJobLogs(string, bool) (io.ReadCloser, error)
+	JobExecutionStatus(string) (string, error)
+	GetJobStatus(string) (string, error)
+	WatchJobStatuses(chan<- JobStatus, <-chan struct{})
+	ListFinishedJobs() ([]FinishedJob, error)
+	CancelJob(string) error
+	DeleteJob(string) error
+}
@@ -0,0 +1,51 @@
+package backend
+
+import (
+	"io"
+
+	"github.com/stretchr/testify/mock"
+	"proctor/proctord/utility"
+)
+
+type MockExecutionBackend struct {
+	mock.Mock
+}
+
+func (m *MockExecutionBackend) ExecuteJob(jobName string, envMap map[string]string, jobOptions JobOptions) (string, error) {
+	args := m.Called(jobName, envMap, jobOptions)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockExecutionBackend) StreamJobLogs(jobName string, follow bool) (io.ReadCloser, error) {
+	args := m.Called(jobName, follow)
+	return args.Get(0).(*utility.Buffer), args.Error(1)
+}
+
+func (m *MockExecutionBackend) JobExecutionStatus(jobExecutionID string) (string, error) {
+	args := m.Called(jobExecutionID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockExecutionBackend) GetJobStatus(jobExecutionID string) (string, error) {
+	args := m.Called(jobExecutionID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockExecutionBackend) WatchJobStatuses(statusChan chan<- JobStatus, stopChan <-chan struct{}) {
+	m.Called(statusChan, stopChan)
+}
+
+func (m *MockExecutionBackend) ListFinishedJobs() ([]FinishedJob, error) {
+	args := m.Called()
+	return args.Get(0).([]FinishedJob), args.Error(1)
+}
+
+func (m *MockExecutionBackend) DeleteJob(jobExecutionID string) error {
+	args := m.Called(jobExecutionID)
+	return args.Error(0)
+}
+
+func (m *MockExecutionBackend) CancelJob(jobExecutionID string) error {
+	args := m.Called(jobExecutionID)
+	return args.Error(0)
+}
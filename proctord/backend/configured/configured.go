@@ -0,0 +1,27 @@
+package configured
+
+import (
+	"fmt"
+
+	"proctor/proctord/backend"
+	"proctor/proctord/backend/local"
+	"proctor/proctord/config"
+	http_client "proctor/proctord/http"
+	"proctor/proctord/kubernetes"
+)
+
+// New returns the execution backend chosen by PROCTOR_EXECUTION_BACKEND, kubernetes unless set
+func New() (backend.ExecutionBackend, error) {
+	switch config.ExecutionBackend() {
+	case backend.Kubernetes, "":
+		httpClient, err := http_client.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		return kubernetes.NewClient(kubernetes.KubeConfig(), httpClient), nil
+	case backend.Local:
+		return local.NewRunner(config.LocalBackendLogsDir())
+	default:
+		return nil, fmt.Errorf("unsupported execution backend: %q", config.ExecutionBackend())
+	}
+}
@@ -0,0 +1,306 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"proctor/proctord/backend"
+	"proctor/proctord/logger"
+	"proctor/proctord/utility"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+const logsPollInterval = 200 * time.Millisecond
+
+type process struct {
+	status     string
+	finishedAt time.Time
+	cancel     context.CancelFunc
+	cancelled  bool
+	done       chan struct{}
+}
+
+type watcher struct {
+	statusChan chan<- backend.JobStatus
+	stopChan   <-chan struct{}
+}
+
+// runner executes the image name of a proc as a shell command on the host running proctord,
+// with only the args and secrets of the execution and PATH in its environment. Logs of an execution
+// are written to <logsDir>/<execution-id>.log and executions are forgotten when proctord stops
+type runner struct {
+	logsDir   string
+	mutex     sync.Mutex
+	processes map[string]*process
+	watchers  map[*watcher]bool
+}
+
+func NewRunner(logsDir string) (backend.ExecutionBackend, error) {
+	if logsDir == "" {
+		logsDir = filepath.Join(os.TempDir(), "proctor")
+	}
+	err := os.MkdirAll(logsDir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	return &runner{
+		logsDir:   logsDir,
+		processes: make(map[string]*process),
+		watchers:  make(map[*watcher]bool),
+	}, nil
+}
+
+func (runner *runner) ExecuteJob(imageName string, envMap map[string]string, jobOptions backend.JobOptions) (string, error) {
+	jobExecutionID := "proctor" + "-" + uuid.NewV4().String()
+
+	logFile, err := os.Create(runner.logPath(jobExecutionID))
+	if err != nil {
+		return "", err
+	}
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if jobOptions.ActiveDeadlineSeconds != nil {
+		ctx, cancel = context.WithTimeout(context.Background(), time.Duration(*jobOptions.ActiveDeadlineSeconds)*time.Second)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+	attempts := 1
+	if jobOptions.BackoffLimit != nil {
+		attempts += int(*jobOptions.BackoffLimit)
+	}
+	env := []string{"PATH=" + os.Getenv("PATH")}
+	for key, value := range utility.MergeMaps(envMap, jobOptions.Secrets) {
+		env = append(env, key+"="+value)
+	}
+
+	command := runner.command(ctx, imageName, env, logFile)
+	err = command.Start()
+	if err != nil {
+		cancel()
+		logFile.Close()
+		return "", err
+	}
+
+	jobProcess := &process{status: utility.JobRunning, cancel: cancel, done: make(chan struct{})}
+	runner.mutex.Lock()
+	runner.processes[jobExecutionID] = jobProcess
+	runner.mutex.Unlock()
+	runner.notify(backend.JobStatus{JobExecutionID: jobExecutionID, Status: utility.JobRunning})
+
+	go func() {
+		defer logFile.Close()
+		err := command.Wait()
+		for attempt := 1; err != nil && attempt < attempts && ctx.Err() == nil; attempt++ {
+			command = runner.command(ctx, imageName, env, logFile)
+			err = command.Run()
+		}
+		cancel()
+		runner.finish(jobExecutionID, err)
+	}()
+
+	return jobExecutionID, nil
+}
+
+func (runner *runner) command(ctx context.Context, imageName string, env []string, logFile *os.File) *exec.Cmd {
+	command := exec.CommandContext(ctx, "sh", "-c", imageName)
+	command.Env = env
+	command.Stdout = logFile
+	command.Stderr = logFile
+	return command
+}
+
+func (runner *runner) finish(jobExecutionID string, err error) {
+	runner.mutex.Lock()
+	jobProcess := runner.processes[jobExecutionID]
+	switch {
+	case jobProcess.cancelled:
+		jobProcess.status = utility.JobCancelled
+		// like a deleted kubernetes job, a cancelled execution is gone once it stops
+		delete(runner.processes, jobExecutionID)
+	case err != nil:
+		logger.Info("Execution ", jobExecutionID, " failed: ", err)
+		jobProcess.status = utility.JobFailed
+	default:
+		jobProcess.status = utility.JobSucceeded
+	}
+	jobProcess.finishedAt = time.Now()
+	status := jobProcess.status
+	close(jobProcess.done)
+	runner.mutex.Unlock()
+
+	runner.notify(backend.JobStatus{JobExecutionID: jobExecutionID, Status: status})
+}
+
+func (runner *runner) notify(jobStatus backend.JobStatus) {
+	runner.mutex.Lock()
+	watchers := []*watcher{}
+	for watcher := range runner.watchers {
+		watchers = append(watchers, watcher)
+	}
+	runner.mutex.Unlock()
+
+	for _, watcher := range watchers {
+		select {
+		case watcher.statusChan <- jobStatus:
+		case <-watcher.stopChan:
+		}
+	}
+}
+
+func (runner *runner) process(jobExecutionID string) *process {
+	runner.mutex.Lock()
+	defer runner.mutex.Unlock()
+	return runner.processes[jobExecutionID]
+}
+
+func (runner *runner) logPath(jobExecutionID string) string {
+	return filepath.Join(runner.logsDir, jobExecutionID+".log")
+}
+
+func (runner *runner) StreamJobLogs(jobExecutionID string, follow bool) (io.ReadCloser, error) {
+	logFile, err := os.Open(runner.logPath(jobExecutionID))
+	if os.IsNotExist(err) {
+		return nil, backend.ErrNoLogs
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	jobProcess := runner.process(jobExecutionID)
+	if !follow || jobProcess == nil {
+		return logFile, nil
+	}
+	return &followingReader{logFile: logFile, done: jobProcess.done}, nil
+}
+
+func (runner *runner) JobExecutionStatus(jobExecutionID string) (string, error) {
+	jobProcess := runner.process(jobExecutionID)
+	if jobProcess == nil {
+		return utility.JobNotFound, nil
+	}
+
+	<-jobProcess.done
+	runner.mutex.Lock()
+	defer runner.mutex.Unlock()
+	return jobProcess.status, nil
+}
+
+func (runner *runner) GetJobStatus(jobExecutionID string) (string, error) {
+	runner.mutex.Lock()
+	defer runner.mutex.Unlock()
+
+	jobProcess, ok := runner.processes[jobExecutionID]
+	if !ok {
+		return utility.JobNotFound, nil
+	}
+	return jobProcess.status, nil
+}
+
+func (runner *runner) WatchJobStatuses(statusChan chan<- backend.JobStatus, stopChan <-chan struct{}) {
+	statusWatcher := &watcher{statusChan: statusChan, stopChan: stopChan}
+
+	runner.mutex.Lock()
+	runner.watchers[statusWatcher] = true
+	jobStatuses := []backend.JobStatus{}
+	for jobExecutionID, jobProcess := range runner.processes {
+		jobStatuses = append(jobStatuses, backend.JobStatus{JobExecutionID: jobExecutionID, Status: jobProcess.status})
+	}
+	runner.mutex.Unlock()
+
+	for _, jobStatus := range jobStatuses {
+		select {
+		case statusChan <- jobStatus:
+		case <-stopChan:
+		}
+	}
+
+	<-stopChan
+	runner.mutex.Lock()
+	delete(runner.watchers, statusWatcher)
+	runner.mutex.Unlock()
+}
+
+func (runner *runner) ListFinishedJobs() ([]backend.FinishedJob, error) {
+	runner.mutex.Lock()
+	defer runner.mutex.Unlock()
+
+	finishedJobs := []backend.FinishedJob{}
+	for jobExecutionID, jobProcess := range runner.processes {
+		if jobProcess.status == utility.JobSucceeded || jobProcess.status == utility.JobFailed {
+			finishedJobs = append(finishedJobs, backend.FinishedJob{
+				Name:       jobExecutionID,
+				Status:     jobProcess.status,
+				FinishedAt: jobProcess.finishedAt,
+			})
+		}
+	}
+	return finishedJobs, nil
+}
+
+func (runner *runner) CancelJob(jobExecutionID string) error {
+	runner.mutex.Lock()
+	defer runner.mutex.Unlock()
+
+	jobProcess, ok := runner.processes[jobExecutionID]
+	if !ok {
+		return fmt.Errorf("execution %s not found", jobExecutionID)
+	}
+	if jobProcess.status == utility.JobRunning {
+		jobProcess.cancelled = true
+		jobProcess.cancel()
+	}
+	return nil
+}
+
+// DeleteJob stops an execution if it's still running and removes it along with its logs
+func (runner *runner) DeleteJob(jobExecutionID string) error {
+	jobProcess := runner.process(jobExecutionID)
+	if jobProcess == nil {
+		return fmt.Errorf("execution %s not found", jobExecutionID)
+	}
+	err := runner.CancelJob(jobExecutionID)
+	if err != nil {
+		return err
+	}
+	<-jobProcess.done
+
+	runner.mutex.Lock()
+	delete(runner.processes, jobExecutionID)
+	runner.mutex.Unlock()
+
+	return os.Remove(runner.logPath(jobExecutionID))
+}
+
+// followingReader reads a log file as it's written until its execution finishes
+type followingReader struct {
+	logFile *os.File
+	done    <-chan struct{}
+}
+
+func (reader *followingReader) Read(p []byte) (int, error) {
+	for {
+		n, err := reader.logFile.Read(p)
+		if n > 0 || err != io.EOF {
+			return n, err
+		}
+
+		select {
+		case <-reader.done:
+			return reader.logFile.Read(p)
+		case <-time.After(logsPollInterval):
+		}
+	}
+}
+
+func (reader *followingReader) Close() error {
+	return reader.logFile.Close()
+}
@@ -0,0 +1,156 @@
+package local
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"proctor/proctord/backend"
+	"proctor/proctord/utility"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type RunnerTestSuite struct {
+	suite.Suite
+	logsDir string
+	runner  backend.ExecutionBackend
+}
+
+func (suite *RunnerTestSuite) SetupTest() {
+	logsDir, err := ioutil.TempDir("", "proctor-local-runner")
+	suite.Require().NoError(err)
+	suite.logsDir = logsDir
+
+	suite.runner, err = NewRunner(logsDir)
+	suite.Require().NoError(err)
+}
+
+func (suite *RunnerTestSuite) TearDownTest() {
+	os.RemoveAll(suite.logsDir)
+}
+
+func (suite *RunnerTestSuite) logs(jobExecutionID string, follow bool) string {
+	logStream, err := suite.runner.StreamJobLogs(jobExecutionID, follow)
+	suite.Require().NoError(err)
+	defer logStream.Close()
+
+	logs, err := ioutil.ReadAll(logStream)
+	suite.Require().NoError(err)
+	return string(logs)
+}
+
+func (suite *RunnerTestSuite) TestExecuteJob() {
+	t := suite.T()
+
+	jobExecutionID, err := suite.runner.ExecuteJob(`echo "$GREETING $NAME, home is [$HOME]"`, map[string]string{"GREETING": "hello"}, backend.JobOptions{
+		Secrets: map[string]string{"NAME": "proctor"},
+	})
+	assert.NoError(t, err)
+
+	status, err := suite.runner.JobExecutionStatus(jobExecutionID)
+	assert.NoError(t, err)
+	assert.Equal(t, utility.JobSucceeded, status)
+	assert.Equal(t, "hello proctor, home is []\n", suite.logs(jobExecutionID, false))
+	assert.FileExists(t, filepath.Join(suite.logsDir, jobExecutionID+".log"))
+
+	finishedJobs, err := suite.runner.ListFinishedJobs()
+	assert.NoError(t, err)
+	assert.Len(t, finishedJobs, 1)
+	assert.Equal(t, jobExecutionID, finishedJobs[0].Name)
+	assert.Equal(t, utility.JobSucceeded, finishedJobs[0].Status)
+}
+
+func (suite *RunnerTestSuite) TestExecuteJobFailure() {
+	t := suite.T()
+
+	backoffLimit := int32(2)
+	jobExecutionID, err := suite.runner.ExecuteJob("echo trying; exit 3", map[string]string{}, backend.JobOptions{BackoffLimit: &backoffLimit})
+	assert.NoError(t, err)
+
+	status, err := suite.runner.JobExecutionStatus(jobExecutionID)
+	assert.NoError(t, err)
+	assert.Equal(t, utility.JobFailed, status)
+	assert.Equal(t, "trying\ntrying\ntrying\n", suite.logs(jobExecutionID, false))
+}
+
+func (suite *RunnerTestSuite) TestExecuteJobPastDeadline() {
+	t := suite.T()
+
+	activeDeadlineSeconds := int64(1)
+	jobExecutionID, err := suite.runner.ExecuteJob("sleep 10", map[string]string{}, backend.JobOptions{ActiveDeadlineSeconds: &activeDeadlineSeconds})
+	assert.NoError(t, err)
+
+	status, err := suite.runner.JobExecutionStatus(jobExecutionID)
+	assert.NoError(t, err)
+	assert.Equal(t, utility.JobFailed, status)
+}
+
+func (suite *RunnerTestSuite) TestStreamJobLogsFollowsUntilJobFinishes() {
+	t := suite.T()
+
+	jobExecutionID, err := suite.runner.ExecuteJob("echo one; sleep 1; echo two", map[string]string{}, backend.JobOptions{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "one\ntwo\n", suite.logs(jobExecutionID, true))
+}
+
+func (suite *RunnerTestSuite) TestStreamJobLogsOfUnknownJob() {
+	_, err := suite.runner.StreamJobLogs("proctor-unknown", false)
+
+	assert.Equal(suite.T(), backend.ErrNoLogs, err)
+}
+
+func (suite *RunnerTestSuite) TestWatchJobStatuses() {
+	t := suite.T()
+
+	statusChan := make(chan backend.JobStatus)
+	stopChan := make(chan struct{})
+	defer close(stopChan)
+	go suite.runner.WatchJobStatuses(statusChan, stopChan)
+
+	jobExecutionID, err := suite.runner.ExecuteJob("sleep 10", map[string]string{}, backend.JobOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, backend.JobStatus{JobExecutionID: jobExecutionID, Status: utility.JobRunning}, <-statusChan)
+
+	status, err := suite.runner.GetJobStatus(jobExecutionID)
+	assert.NoError(t, err)
+	assert.Equal(t, utility.JobRunning, status)
+
+	assert.NoError(t, suite.runner.CancelJob(jobExecutionID))
+	assert.Equal(t, backend.JobStatus{JobExecutionID: jobExecutionID, Status: utility.JobCancelled}, <-statusChan)
+
+	status, err = suite.runner.GetJobStatus(jobExecutionID)
+	assert.NoError(t, err)
+	assert.Equal(t, utility.JobNotFound, status)
+}
+
+func (suite *RunnerTestSuite) TestCancelUnknownJob() {
+	assert.EqualError(suite.T(), suite.runner.CancelJob("proctor-unknown"), "execution proctor-unknown not found")
+}
+
+func (suite *RunnerTestSuite) TestDeleteJob() {
+	t := suite.T()
+
+	jobExecutionID, err := suite.runner.ExecuteJob("echo done", map[string]string{}, backend.JobOptions{})
+	assert.NoError(t, err)
+	_, err = suite.runner.JobExecutionStatus(jobExecutionID)
+	assert.NoError(t, err)
+
+	assert.NoError(t, suite.runner.DeleteJob(jobExecutionID))
+
+	status, err := suite.runner.GetJobStatus(jobExecutionID)
+	assert.NoError(t, err)
+	assert.Equal(t, utility.JobNotFound, status)
+	_, err = os.Stat(filepath.Join(suite.logsDir, jobExecutionID+".log"))
+	assert.True(t, os.IsNotExist(err))
+	finishedJobs, err := suite.runner.ListFinishedJobs()
+	assert.NoError(t, err)
+	assert.Empty(t, finishedJobs)
+}
+
+func TestRunnerTestSuite(t *testing.T) {
+	suite.Run(t, new(RunnerTestSuite))
+}
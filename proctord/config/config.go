@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"github.com/spf13/viper"
 )
 
@@ -23,6 +24,14 @@ func AppPort() string {
 	return viper.GetString("APP_PORT")
 }
 
+func ExecutionBackend() string {
+	return viper.GetString("EXECUTION_BACKEND")
+}
+
+func LocalBackendLogsDir() string {
+	return viper.GetString("LOCAL_BACKEND_LOGS_DIR")
+}
+
 func DefaultNamespace() string {
 	return viper.GetString("DEFAULT_NAMESPACE")
 }
@@ -69,6 +78,68 @@ func KubeJobRetries() *int32 {
 	return &kubeJobRetries
 }
 
+func KubeJobMaxActiveDeadlineSeconds() int64 {
+	return viper.GetInt64("KUBE_JOB_MAX_ACTIVE_DEADLINE_SECONDS")
+}
+
+func KubeJobMaxRetries() int32 {
+	return int32(viper.GetInt("KUBE_JOB_MAX_RETRIES"))
+}
+
+func KubeJobDefaultCPURequest() string {
+	return viper.GetString("KUBE_JOB_DEFAULT_CPU_REQUEST")
+}
+
+func KubeJobDefaultCPULimit() string {
+	return viper.GetString("KUBE_JOB_DEFAULT_CPU_LIMIT")
+}
+
+func KubeJobDefaultMemoryRequest() string {
+	return viper.GetString("KUBE_JOB_DEFAULT_MEMORY_REQUEST")
+}
+
+func KubeJobDefaultMemoryLimit() string {
+	return viper.GetString("KUBE_JOB_DEFAULT_MEMORY_LIMIT")
+}
+
+func KubeJobMaxCPU() string {
+	return viper.GetString("KUBE_JOB_MAX_CPU")
+}
+
+func KubeJobMaxMemory() string {
+	return viper.GetString("KUBE_JOB_MAX_MEMORY")
+}
+
+func KubeJobAllowedServiceAccounts() []string {
+	return commaSeparated("KUBE_JOB_ALLOWED_SERVICE_ACCOUNTS")
+}
+
+func KubeJobAllowedNodeSelectorKeys() []string {
+	return commaSeparated("KUBE_JOB_ALLOWED_NODE_SELECTOR_KEYS")
+}
+
+func KubeJobAllowedTolerationKeys() []string {
+	return commaSeparated("KUBE_JOB_ALLOWED_TOLERATION_KEYS")
+}
+
+func KubeJobAllowedConfigMaps() []string {
+	return commaSeparated("KUBE_JOB_ALLOWED_CONFIG_MAPS")
+}
+
+func KubeJobAllowedSecrets() []string {
+	return commaSeparated("KUBE_JOB_ALLOWED_SECRETS")
+}
+
+func commaSeparated(key string) []string {
+	values := []string{}
+	for _, value := range strings.Split(viper.GetString(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 func PostgresUser() string {
 	return viper.GetString("POSTGRES_USER")
 }
@@ -147,4 +218,85 @@ func SentryDSN() string {
 
 func DocsPath() string {
 	return viper.GetString("DOCS_PATH")
-}
\ No newline at end of file
+}
+
+func AuthStrategy() string {
+	return viper.GetString("AUTH_STRATEGY")
+}
+
+func AuthTokensFile() string {
+	return viper.GetString("AUTH_TOKENS_FILE")
+}
+
+func GroupsStrategy() string {
+	return viper.GetString("GROUPS_STRATEGY")
+}
+
+func GroupsFile() string {
+	return viper.GetString("GROUPS_FILE")
+}
+
+func AdminGroup() string {
+	return viper.GetString("ADMIN_GROUP")
+}
+
+func PublisherGroup() string {
+	return viper.GetString("PUBLISHER_GROUP")
+}
+
+func AccessTokenTTLDays() int {
+	return viper.GetInt("ACCESS_TOKEN_TTL_DAYS")
+}
+
+// positiveInt falls back to defaultValue when the setting is missing or not positive,
+// such settings are intervals of tickers, which panic on zero, timeouts or counts
+func positiveInt(key string, defaultValue int) int {
+	if value := viper.GetInt(key); value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+func StatusCallbackDispatchIntervalInSeconds() int {
+	return positiveInt("STATUS_CALLBACK_DISPATCH_INTERVAL_IN_SECONDS", 5)
+}
+
+func StatusCallbackMaxAttempts() int {
+	return positiveInt("STATUS_CALLBACK_MAX_ATTEMPTS", 8)
+}
+
+func StatusCallbackBackoffInSeconds() int {
+	return positiveInt("STATUS_CALLBACK_BACKOFF_IN_SECONDS", 10)
+}
+
+func StatusCallbackTimeoutInSeconds() int {
+	return positiveInt("STATUS_CALLBACK_TIMEOUT_IN_SECONDS", 10)
+}
+
+func ExecutionStatusReconcileIntervalInMins() int {
+	return positiveInt("EXECUTION_STATUS_RECONCILE_INTERVAL_IN_MINS", 5)
+}
+
+func JobGCIntervalInMins() int {
+	return positiveInt("JOB_GC_INTERVAL_IN_MINS", 10)
+}
+
+func SucceededJobTTLInMins() int {
+	return viper.GetInt("SUCCEEDED_JOB_TTL_IN_MINS")
+}
+
+func FailedJobTTLInMins() int {
+	return viper.GetInt("FAILED_JOB_TTL_IN_MINS")
+}
+
+func JobLogsArchiveDir() string {
+	return viper.GetString("JOB_LOGS_ARCHIVE_DIR")
+}
+
+func IdempotencyKeyTTLInMins() int {
+	return positiveInt("IDEMPOTENCY_KEY_TTL_IN_MINS", 1440)
+}
+
+func ApprovalRequestTTLInMins() int {
+	return positiveInt("APPROVAL_REQUEST_TTL_IN_MINS", 1440)
+}
@@ -32,6 +32,22 @@ func TestAppPort(t *testing.T) {
 	assert.Equal(t, "3000", AppPort())
 }
 
+func TestExecutionBackend(t *testing.T) {
+	os.Setenv("PROCTOR_EXECUTION_BACKEND", "local")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, "local", ExecutionBackend())
+}
+
+func TestLocalBackendLogsDir(t *testing.T) {
+	os.Setenv("PROCTOR_LOCAL_BACKEND_LOGS_DIR", "/path/to/logs")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, "/path/to/logs", LocalBackendLogsDir())
+}
+
 func TestDefaultNamespace(t *testing.T) {
 	os.Setenv("PROCTOR_DEFAULT_NAMESPACE", "default")
 
@@ -114,6 +130,110 @@ func TestKubeJobRetries(t *testing.T) {
 	assert.Equal(t, &expectedValue, KubeJobRetries())
 }
 
+func TestKubeJobMaxActiveDeadlineSeconds(t *testing.T) {
+	os.Setenv("PROCTOR_KUBE_JOB_MAX_ACTIVE_DEADLINE_SECONDS", "14400")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, int64(14400), KubeJobMaxActiveDeadlineSeconds())
+}
+
+func TestKubeJobMaxRetries(t *testing.T) {
+	os.Setenv("PROCTOR_KUBE_JOB_MAX_RETRIES", "5")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, int32(5), KubeJobMaxRetries())
+}
+
+func TestKubeJobDefaultCPURequest(t *testing.T) {
+	os.Setenv("PROCTOR_KUBE_JOB_DEFAULT_CPU_REQUEST", "100m")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, "100m", KubeJobDefaultCPURequest())
+}
+
+func TestKubeJobDefaultCPULimit(t *testing.T) {
+	os.Setenv("PROCTOR_KUBE_JOB_DEFAULT_CPU_LIMIT", "500m")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, "500m", KubeJobDefaultCPULimit())
+}
+
+func TestKubeJobDefaultMemoryRequest(t *testing.T) {
+	os.Setenv("PROCTOR_KUBE_JOB_DEFAULT_MEMORY_REQUEST", "128Mi")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, "128Mi", KubeJobDefaultMemoryRequest())
+}
+
+func TestKubeJobDefaultMemoryLimit(t *testing.T) {
+	os.Setenv("PROCTOR_KUBE_JOB_DEFAULT_MEMORY_LIMIT", "512Mi")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, "512Mi", KubeJobDefaultMemoryLimit())
+}
+
+func TestKubeJobMaxCPU(t *testing.T) {
+	os.Setenv("PROCTOR_KUBE_JOB_MAX_CPU", "2")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, "2", KubeJobMaxCPU())
+}
+
+func TestKubeJobMaxMemory(t *testing.T) {
+	os.Setenv("PROCTOR_KUBE_JOB_MAX_MEMORY", "4Gi")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, "4Gi", KubeJobMaxMemory())
+}
+
+func TestKubeJobAllowedServiceAccounts(t *testing.T) {
+	os.Setenv("PROCTOR_KUBE_JOB_ALLOWED_SERVICE_ACCOUNTS", "reports, backups")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, []string{"reports", "backups"}, KubeJobAllowedServiceAccounts())
+}
+
+func TestKubeJobAllowedNodeSelectorKeys(t *testing.T) {
+	os.Setenv("PROCTOR_KUBE_JOB_ALLOWED_NODE_SELECTOR_KEYS", "cloud.google.com/gke-nodepool")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, []string{"cloud.google.com/gke-nodepool"}, KubeJobAllowedNodeSelectorKeys())
+}
+
+func TestKubeJobAllowedTolerationKeys(t *testing.T) {
+	os.Setenv("PROCTOR_KUBE_JOB_ALLOWED_TOLERATION_KEYS", "dedicated")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, []string{"dedicated"}, KubeJobAllowedTolerationKeys())
+}
+
+func TestKubeJobAllowedConfigMaps(t *testing.T) {
+	os.Setenv("PROCTOR_KUBE_JOB_ALLOWED_CONFIG_MAPS", "ca-bundle,")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, []string{"ca-bundle"}, KubeJobAllowedConfigMaps())
+}
+
+func TestKubeJobAllowedSecrets(t *testing.T) {
+	os.Setenv("PROCTOR_KUBE_JOB_ALLOWED_SECRETS", "")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, []string{}, KubeJobAllowedSecrets())
+}
+
 func TestPostgresUser(t *testing.T) {
 	os.Setenv("PROCTOR_POSTGRES_USER", "postgres")
 
@@ -256,4 +376,193 @@ func TestDocsPath(t *testing.T) {
 	viper.AutomaticEnv()
 
 	assert.Equal(t, "path1", DocsPath())
-}
\ No newline at end of file
+}
+func TestAuthStrategy(t *testing.T) {
+	os.Setenv("PROCTOR_AUTH_STRATEGY", "file")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, "file", AuthStrategy())
+}
+
+func TestAuthTokensFile(t *testing.T) {
+	os.Setenv("PROCTOR_AUTH_TOKENS_FILE", "/path/to/tokens.yaml")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, "/path/to/tokens.yaml", AuthTokensFile())
+}
+
+func TestGroupsStrategy(t *testing.T) {
+	os.Setenv("PROCTOR_GROUPS_STRATEGY", "postgres")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, "postgres", GroupsStrategy())
+}
+
+func TestGroupsFile(t *testing.T) {
+	os.Setenv("PROCTOR_GROUPS_FILE", "/path/to/groups.yaml")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, "/path/to/groups.yaml", GroupsFile())
+}
+
+func TestAdminGroup(t *testing.T) {
+	os.Setenv("PROCTOR_ADMIN_GROUP", "proctor-admins")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, "proctor-admins", AdminGroup())
+}
+
+func TestPublisherGroup(t *testing.T) {
+	os.Setenv("PROCTOR_PUBLISHER_GROUP", "proctor-publishers")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, "proctor-publishers", PublisherGroup())
+}
+
+func TestAccessTokenTTLDays(t *testing.T) {
+	os.Setenv("PROCTOR_ACCESS_TOKEN_TTL_DAYS", "90")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, 90, AccessTokenTTLDays())
+}
+
+func TestStatusCallbackDispatchIntervalInSeconds(t *testing.T) {
+	os.Setenv("PROCTOR_STATUS_CALLBACK_DISPATCH_INTERVAL_IN_SECONDS", "5")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, 5, StatusCallbackDispatchIntervalInSeconds())
+}
+
+func TestStatusCallbackMaxAttempts(t *testing.T) {
+	os.Setenv("PROCTOR_STATUS_CALLBACK_MAX_ATTEMPTS", "8")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, 8, StatusCallbackMaxAttempts())
+}
+
+func TestStatusCallbackBackoffInSeconds(t *testing.T) {
+	os.Setenv("PROCTOR_STATUS_CALLBACK_BACKOFF_IN_SECONDS", "10")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, 10, StatusCallbackBackoffInSeconds())
+}
+
+func TestStatusCallbackTimeoutInSeconds(t *testing.T) {
+	os.Setenv("PROCTOR_STATUS_CALLBACK_TIMEOUT_IN_SECONDS", "10")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, 10, StatusCallbackTimeoutInSeconds())
+}
+
+func TestStatusCallbackSettingsDefaultWhenUnsetOrNotPositive(t *testing.T) {
+	os.Unsetenv("PROCTOR_STATUS_CALLBACK_DISPATCH_INTERVAL_IN_SECONDS")
+	os.Unsetenv("PROCTOR_STATUS_CALLBACK_MAX_ATTEMPTS")
+	os.Setenv("PROCTOR_STATUS_CALLBACK_BACKOFF_IN_SECONDS", "0")
+	os.Setenv("PROCTOR_STATUS_CALLBACK_TIMEOUT_IN_SECONDS", "-1")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, 5, StatusCallbackDispatchIntervalInSeconds())
+	assert.Equal(t, 8, StatusCallbackMaxAttempts())
+	assert.Equal(t, 10, StatusCallbackBackoffInSeconds())
+	assert.Equal(t, 10, StatusCallbackTimeoutInSeconds())
+}
+
+func TestExecutionStatusReconcileIntervalInMins(t *testing.T) {
+	os.Setenv("PROCTOR_EXECUTION_STATUS_RECONCILE_INTERVAL_IN_MINS", "5")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, 5, ExecutionStatusReconcileIntervalInMins())
+}
+
+func TestExecutionStatusReconcileIntervalInMinsDefaultsWhenUnset(t *testing.T) {
+	os.Unsetenv("PROCTOR_EXECUTION_STATUS_RECONCILE_INTERVAL_IN_MINS")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, 5, ExecutionStatusReconcileIntervalInMins())
+}
+
+func TestJobGCIntervalInMins(t *testing.T) {
+	os.Setenv("PROCTOR_JOB_GC_INTERVAL_IN_MINS", "10")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, 10, JobGCIntervalInMins())
+}
+
+func TestJobGCIntervalInMinsDefaultsWhenNotPositive(t *testing.T) {
+	os.Setenv("PROCTOR_JOB_GC_INTERVAL_IN_MINS", "0")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, 10, JobGCIntervalInMins())
+}
+
+func TestSucceededJobTTLInMins(t *testing.T) {
+	os.Setenv("PROCTOR_SUCCEEDED_JOB_TTL_IN_MINS", "60")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, 60, SucceededJobTTLInMins())
+}
+
+func TestFailedJobTTLInMins(t *testing.T) {
+	os.Setenv("PROCTOR_FAILED_JOB_TTL_IN_MINS", "1440")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, 1440, FailedJobTTLInMins())
+}
+
+func TestJobLogsArchiveDir(t *testing.T) {
+	os.Setenv("PROCTOR_JOB_LOGS_ARCHIVE_DIR", "/path/to/logs")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, "/path/to/logs", JobLogsArchiveDir())
+}
+
+func TestIdempotencyKeyTTLInMins(t *testing.T) {
+	os.Setenv("PROCTOR_IDEMPOTENCY_KEY_TTL_IN_MINS", "1440")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, 1440, IdempotencyKeyTTLInMins())
+}
+
+func TestIdempotencyKeyTTLInMinsDefaultsWhenUnset(t *testing.T) {
+	os.Unsetenv("PROCTOR_IDEMPOTENCY_KEY_TTL_IN_MINS")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, 1440, IdempotencyKeyTTLInMins())
+}
+
+func TestApprovalRequestTTLInMins(t *testing.T) {
+	os.Setenv("PROCTOR_APPROVAL_REQUEST_TTL_IN_MINS", "1440")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, 1440, ApprovalRequestTTLInMins())
+}
+
+func TestApprovalRequestTTLInMinsDefaultsWhenUnset(t *testing.T) {
+	os.Unsetenv("PROCTOR_APPROVAL_REQUEST_TTL_IN_MINS")
+
+	viper.AutomaticEnv()
+
+	assert.Equal(t, 1440, ApprovalRequestTTLInMins())
+}
@@ -0,0 +1,307 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"proctor/proctord/config"
+
+	"github.com/stretchr/testify/require"
+	batch_v1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	core_v1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	restclient "k8s.io/client-go/rest"
+	fakerest "k8s.io/client-go/rest/fake"
+	k8s_testing "k8s.io/client-go/testing"
+)
+
+// cluster is a fake kubernetes cluster without controllers: jobs created by proctord stay as they are
+// until a test moves them through their phases, and pods have the logs the test emits
+type cluster struct {
+	t         *testing.T
+	clientSet *clientSet
+}
+
+// clientSet is the fake clientset of client-go, with label selectors applied to watches
+// and pod logs served from what tests emit rather than a fixed "fake logs"
+type clientSet struct {
+	*fake.Clientset
+	podLogs *podLogs
+}
+
+type coreV1 struct {
+	core_v1.CoreV1Interface
+	podLogs *podLogs
+}
+
+type pods struct {
+	core_v1.PodInterface
+	namespace string
+	podLogs   *podLogs
+}
+
+func newCluster(t *testing.T) *cluster {
+	fakeClientSet := fake.NewSimpleClientset()
+	fakeClientSet.PrependWatchReactor("*", func(action k8s_testing.Action) (bool, watch.Interface, error) {
+		watcher, err := fakeClientSet.Tracker().Watch(action.GetResource(), action.GetNamespace())
+		if err != nil {
+			return true, nil, err
+		}
+
+		selector := action.(k8s_testing.WatchAction).GetWatchRestrictions().Labels
+		if selector == nil {
+			return true, watcher, nil
+		}
+		return true, watch.Filter(watcher, func(event watch.Event) (watch.Event, bool) {
+			object, err := meta.Accessor(event.Object)
+			return event, err == nil && selector.Matches(labels.Set(object.GetLabels()))
+		}), nil
+	})
+
+	return &cluster{
+		t:         t,
+		clientSet: &clientSet{Clientset: fakeClientSet, podLogs: newPodLogs()},
+	}
+}
+
+func (clientSet *clientSet) CoreV1() core_v1.CoreV1Interface {
+	return &coreV1{CoreV1Interface: clientSet.Clientset.CoreV1(), podLogs: clientSet.podLogs}
+}
+
+func (coreV1 *coreV1) Pods(namespace string) core_v1.PodInterface {
+	return &pods{PodInterface: coreV1.CoreV1Interface.Pods(namespace), namespace: namespace, podLogs: coreV1.podLogs}
+}
+
+func (pods *pods) GetLogs(name string, opts *v1.PodLogOptions) *restclient.Request {
+	fakeClient := &fakerest.RESTClient{
+		Client: fakerest.CreateHTTPClient(func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       pods.podLogs.reader(name, opts.Follow),
+			}, nil
+		}),
+		NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+		GroupVersion:         v1.SchemeGroupVersion,
+		VersionedAPIPath:     fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/log", pods.namespace, name),
+	}
+	return fakeClient.Request()
+}
+
+func podName(jobExecutionID string) string {
+	return jobExecutionID + "-pod"
+}
+
+func (cluster *cluster) job(jobExecutionID string) *batch_v1.Job {
+	job, err := cluster.clientSet.BatchV1().Jobs(config.DefaultNamespace()).Get(context.Background(), jobExecutionID, meta_v1.GetOptions{})
+	require.NoError(cluster.t, err)
+	return job
+}
+
+func (cluster *cluster) updateJob(job *batch_v1.Job) {
+	_, err := cluster.clientSet.BatchV1().Jobs(config.DefaultNamespace()).Update(context.Background(), job, meta_v1.UpdateOptions{})
+	require.NoError(cluster.t, err)
+}
+
+func (cluster *cluster) setPodPhase(jobExecutionID string, phase v1.PodPhase) {
+	pods := cluster.clientSet.Clientset.CoreV1().Pods(config.DefaultNamespace())
+	pod, err := pods.Get(context.Background(), podName(jobExecutionID), meta_v1.GetOptions{})
+	require.NoError(cluster.t, err)
+
+	pod.Status.Phase = phase
+	_, err = pods.Update(context.Background(), pod, meta_v1.UpdateOptions{})
+	require.NoError(cluster.t, err)
+}
+
+// StartJob schedules the pod of a job and marks the job active, as the job controller and kubelet would
+func (cluster *cluster) StartJob(jobExecutionID string) {
+	job := cluster.job(jobExecutionID)
+
+	_, err := cluster.clientSet.Clientset.CoreV1().Pods(config.DefaultNamespace()).Create(context.Background(), &v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      podName(jobExecutionID),
+			Namespace: config.DefaultNamespace(),
+			Labels:    job.Labels,
+		},
+		Spec:   job.Spec.Template.Spec,
+		Status: v1.PodStatus{Phase: v1.PodRunning},
+	}, meta_v1.CreateOptions{})
+	require.NoError(cluster.t, err)
+
+	now := meta_v1.Now()
+	job.Status.StartTime = &now
+	job.Status.Active = 1
+	cluster.updateJob(job)
+}
+
+// EmitLogs appends lines to the logs of the pod of a started job
+func (cluster *cluster) EmitLogs(jobExecutionID string, lines ...string) {
+	for _, line := range lines {
+		cluster.clientSet.podLogs.write(podName(jobExecutionID), line+"\n")
+	}
+}
+
+// SucceedJob completes the pod of a started job and the job along with it
+func (cluster *cluster) SucceedJob(jobExecutionID string) {
+	cluster.finishJob(jobExecutionID, v1.PodSucceeded, batch_v1.JobComplete)
+}
+
+// FailJob fails the pod of a started job, with no retries left for the job
+func (cluster *cluster) FailJob(jobExecutionID string) {
+	cluster.finishJob(jobExecutionID, v1.PodFailed, batch_v1.JobFailed)
+}
+
+func (cluster *cluster) finishJob(jobExecutionID string, podPhase v1.PodPhase, conditionType batch_v1.JobConditionType) {
+	cluster.setPodPhase(jobExecutionID, podPhase)
+	cluster.clientSet.podLogs.close(podName(jobExecutionID))
+
+	job := cluster.job(jobExecutionID)
+	now := meta_v1.Now()
+	job.Status.Active = 0
+	job.Status.Conditions = append(job.Status.Conditions, batch_v1.JobCondition{
+		Type:               conditionType,
+		Status:             v1.ConditionTrue,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+	})
+	if conditionType == batch_v1.JobComplete {
+		job.Status.Succeeded = 1
+		job.Status.CompletionTime = &now
+	} else {
+		job.Status.Failed = 1
+	}
+	cluster.updateJob(job)
+}
+
+// WaitForJob waits till proctord has created the job of an execution
+func (cluster *cluster) WaitForJob(jobExecutionID string) {
+	require.Eventually(cluster.t, func() bool {
+		_, err := cluster.clientSet.BatchV1().Jobs(config.DefaultNamespace()).Get(context.Background(), jobExecutionID, meta_v1.GetOptions{})
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// ScheduledJob is the ID of the execution whose job proctord created on its own, if there's one yet
+func (cluster *cluster) ScheduledJob() string {
+	jobs, err := cluster.clientSet.BatchV1().Jobs(config.DefaultNamespace()).List(context.Background(), meta_v1.ListOptions{
+		LabelSelector: "app.kubernetes.io/managed-by=proctor",
+	})
+	require.NoError(cluster.t, err)
+	if len(jobs.Items) == 0 {
+		return ""
+	}
+	return jobs.Items[0].Name
+}
+
+// WaitForWatch waits till something in proctord watches the job of an execution alone,
+// like the auditor does for the final status of an execution
+func (cluster *cluster) WaitForWatch(jobExecutionID string) {
+	require.Eventually(cluster.t, func() bool {
+		for _, action := range cluster.clientSet.Actions() {
+			watchAction, ok := action.(k8s_testing.WatchAction)
+			if !ok || !watchAction.Matches("watch", "jobs") {
+				continue
+			}
+			selector := watchAction.GetWatchRestrictions().Labels
+			if selector != nil && !selector.Empty() && selector.Matches(labels.Set{"job": jobExecutionID}) {
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// JobExists tells whether the job of an execution is still in the cluster
+func (cluster *cluster) JobExists(jobExecutionID string) bool {
+	jobs, err := cluster.clientSet.BatchV1().Jobs(config.DefaultNamespace()).List(context.Background(), meta_v1.ListOptions{
+		LabelSelector: "job=" + jobExecutionID,
+	})
+	require.NoError(cluster.t, err)
+	return len(jobs.Items) > 0
+}
+
+type podLogs struct {
+	mutex   sync.Mutex
+	written *sync.Cond
+	logs    map[string]*podLog
+}
+
+type podLog struct {
+	content []byte
+	closed  bool
+}
+
+func newPodLogs() *podLogs {
+	podLogs := &podLogs{logs: make(map[string]*podLog)}
+	podLogs.written = sync.NewCond(&podLogs.mutex)
+	return podLogs
+}
+
+func (podLogs *podLogs) podLog(podName string) *podLog {
+	log, ok := podLogs.logs[podName]
+	if !ok {
+		log = &podLog{}
+		podLogs.logs[podName] = log
+	}
+	return log
+}
+
+func (podLogs *podLogs) write(podName, text string) {
+	podLogs.mutex.Lock()
+	defer podLogs.mutex.Unlock()
+
+	log := podLogs.podLog(podName)
+	log.content = append(log.content, text...)
+	podLogs.written.Broadcast()
+}
+
+func (podLogs *podLogs) close(podName string) {
+	podLogs.mutex.Lock()
+	defer podLogs.mutex.Unlock()
+
+	podLogs.podLog(podName).closed = true
+	podLogs.written.Broadcast()
+}
+
+func (podLogs *podLogs) reader(podName string, follow bool) io.ReadCloser {
+	return &podLogReader{podLogs: podLogs, podName: podName, follow: follow}
+}
+
+// podLogReader reads the logs of a pod written so far, or with follow until the pod finishes
+type podLogReader struct {
+	podLogs *podLogs
+	podName string
+	follow  bool
+	offset  int
+}
+
+func (reader *podLogReader) Read(p []byte) (int, error) {
+	reader.podLogs.mutex.Lock()
+	defer reader.podLogs.mutex.Unlock()
+
+	log := reader.podLogs.podLog(reader.podName)
+	for reader.follow && !log.closed && reader.offset == len(log.content) {
+		reader.podLogs.written.Wait()
+	}
+	if reader.offset == len(log.content) {
+		return 0, io.EOF
+	}
+
+	n := copy(p, log.content[reader.offset:])
+	reader.offset += n
+	return n, nil
+}
+
+func (reader *podLogReader) Close() error {
+	return nil
+}
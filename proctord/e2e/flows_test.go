@@ -0,0 +1,204 @@
+package e2e
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"proctor/proctord/jobs/callback"
+	"proctor/proctord/jobs/execution"
+	"proctor/proctord/jobs/metadata"
+	"proctor/proctord/jobs/metadata/env"
+	"proctor/proctord/jobs/schedule"
+	"proctor/proctord/utility"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sayHello() metadata.Metadata {
+	return metadata.Metadata{
+		Name:        "say-hello",
+		Description: "says hello",
+		ImageName:   "proctor/say-hello",
+		EnvVars: env.Vars{
+			Args: []env.VarMetadata{{Name: "NAME", Description: "who to greet"}},
+		},
+		Author: "e2e",
+	}
+}
+
+func TestExecuteFollowLogsAndSucceed(t *testing.T) {
+	harness := newHarness(t)
+	defer harness.Stop()
+	harness.AddProc(sayHello())
+
+	jobExecutionID := harness.Execute(execution.Job{Name: "say-hello", Args: map[string]string{"NAME": "proctor"}}, nil)
+	harness.WaitForStatus(jobExecutionID, utility.JobWaiting)
+
+	harness.cluster.StartJob(jobExecutionID)
+	harness.WaitForStatus(jobExecutionID, utility.JobRunning)
+	harness.cluster.EmitLogs(jobExecutionID, "hello", "proctor")
+
+	type logsResult struct {
+		lines []string
+		err   error
+	}
+	logsChan := make(chan logsResult, 1)
+	go func() {
+		lines, err := harness.Logs(jobExecutionID, true)
+		logsChan <- logsResult{lines: lines, err: err}
+	}()
+
+	harness.cluster.EmitLogs(jobExecutionID, "bye")
+	harness.cluster.SucceedJob(jobExecutionID)
+	harness.WaitForStatus(jobExecutionID, utility.JobSucceeded)
+
+	logs := <-logsChan
+	require.NoError(t, logs.err)
+	assert.Equal(t, []string{"hello", "proctor", "bye"}, logs.lines)
+
+	response := harness.Request("GET", "/jobs/execute/"+jobExecutionID+"/status", nil, nil)
+	defer response.Body.Close()
+	status, err := ioutil.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, utility.JobSucceeded, string(status))
+
+	detail := harness.Detail(jobExecutionID)
+	assert.Equal(t, "say-hello", detail.JobName)
+	assert.Equal(t, userEmail, detail.UserEmail)
+	assert.Equal(t, "proctor", detail.Args["NAME"])
+}
+
+func TestExecuteAndFail(t *testing.T) {
+	harness := newHarness(t)
+	defer harness.Stop()
+	harness.AddProc(sayHello())
+
+	jobExecutionID := harness.Execute(execution.Job{Name: "say-hello", Args: map[string]string{"NAME": "proctor"}}, nil)
+	harness.cluster.StartJob(jobExecutionID)
+	harness.cluster.EmitLogs(jobExecutionID, "no one to greet")
+	harness.cluster.FailJob(jobExecutionID)
+	harness.WaitForStatus(jobExecutionID, utility.JobFailed)
+
+	lines, err := harness.Logs(jobExecutionID, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"no one to greet"}, lines)
+}
+
+func TestCancelRunningExecution(t *testing.T) {
+	harness := newHarness(t)
+	defer harness.Stop()
+	harness.AddProc(sayHello())
+
+	jobExecutionID := harness.Execute(execution.Job{Name: "say-hello", Args: map[string]string{"NAME": "proctor"}}, nil)
+	harness.cluster.StartJob(jobExecutionID)
+	harness.WaitForStatus(jobExecutionID, utility.JobRunning)
+
+	response := harness.Request("DELETE", "/jobs/execute/"+jobExecutionID, nil, nil)
+	response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	harness.WaitForStatus(jobExecutionID, utility.JobCancelled)
+	assert.Equal(t, userEmail, harness.Detail(jobExecutionID).CancelledBy)
+	assert.False(t, harness.cluster.JobExists(jobExecutionID))
+}
+
+type callbackReceiver struct {
+	mutex      sync.Mutex
+	bodies     []map[string]string
+	signatures []string
+}
+
+func (receiver *callbackReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	body := map[string]string{}
+	json.NewDecoder(req.Body).Decode(&body)
+	receiver.bodies = append(receiver.bodies, body)
+	receiver.signatures = append(receiver.signatures, req.Header.Get(utility.CallbackSignatureHeaderKey))
+}
+
+func (receiver *callbackReceiver) received() ([]map[string]string, []string) {
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+
+	return append([]map[string]string{}, receiver.bodies...), append([]string{}, receiver.signatures...)
+}
+
+func TestStatusCallbackOnCompletion(t *testing.T) {
+	harness := newHarness(t)
+	defer harness.Stop()
+	harness.AddProc(sayHello())
+
+	receiver := &callbackReceiver{}
+	callbackServer := httptest.NewServer(receiver)
+	defer callbackServer.Close()
+
+	jobExecutionID := harness.Execute(execution.Job{
+		Name:        "say-hello",
+		Args:        map[string]string{"NAME": "proctor"},
+		CallbackURL: callbackServer.URL,
+	}, map[string]string{utility.CallbackSecretHeaderKey: "e2e-callback-secret"})
+	harness.cluster.StartJob(jobExecutionID)
+	harness.cluster.SucceedJob(jobExecutionID)
+	harness.WaitForStatus(jobExecutionID, utility.JobSucceeded)
+
+	require.Eventually(t, func() bool {
+		bodies, _ := receiver.received()
+		return len(bodies) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	bodies, signatures := receiver.received()
+	assert.Equal(t, []map[string]string{{"name": jobExecutionID, "status": utility.JobSucceeded}}, bodies)
+	assert.True(t, strings.HasPrefix(signatures[0], "sha256="), signatures[0])
+
+	require.Eventually(t, func() bool {
+		var deliveries []callback.Delivery
+		harness.Decode(harness.Request("GET", "/jobs/execute/"+jobExecutionID+"/callbacks", nil, nil), http.StatusOK, &deliveries)
+		return len(deliveries) == 1 && deliveries[0].Status == utility.StatusCallbackDelivered
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
+func TestScheduledExecutionNotifiesOnCompletion(t *testing.T) {
+	harness := newHarness(t)
+	defer harness.Stop()
+	harness.AddProc(sayHello())
+
+	var scheduled schedule.ScheduledJob
+	harness.Decode(harness.Request("POST", "/jobs/schedule", schedule.ScheduledJob{
+		Name:               "say-hello",
+		Args:               map[string]string{"NAME": "proctor"},
+		NotificationEmails: "team@example.com",
+		Time:               "* * * * *",
+		Tags:               "e2e",
+		Group:              "e2e",
+	}, nil), http.StatusCreated, &scheduled)
+
+	// schedules fire on the minute, so the first execution can take as long to start
+	var jobExecutionID string
+	require.Eventually(t, func() bool {
+		jobExecutionID = harness.cluster.ScheduledJob()
+		return jobExecutionID != ""
+	}, 70*time.Second, 100*time.Millisecond)
+	harness.cluster.WaitForWatch(jobExecutionID)
+
+	harness.cluster.StartJob(jobExecutionID)
+	harness.cluster.SucceedJob(jobExecutionID)
+
+	require.Eventually(t, func() bool {
+		return len(harness.mailer.Mails()) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []mail{{
+		JobName:        "say-hello",
+		JobExecutionID: jobExecutionID,
+		Status:         utility.JobSucceeded,
+		Recipients:     []string{"team@example.com"},
+	}}, harness.mailer.Mails())
+}
@@ -0,0 +1,248 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"proctor/proctord/audit"
+	"proctor/proctord/auth"
+	"proctor/proctord/instrumentation"
+	"proctor/proctord/jobs/callback"
+	"proctor/proctord/jobs/execution"
+	"proctor/proctord/jobs/metadata"
+	"proctor/proctord/jobs/schedule"
+	"proctor/proctord/jobs/secrets"
+	"proctor/proctord/kubernetes"
+	"proctor/proctord/redis"
+	"proctor/proctord/server"
+	"proctor/proctord/storage"
+	"proctor/proctord/storage/postgres"
+	"proctor/proctord/utility"
+
+	"github.com/gorilla/websocket"
+	"github.com/newrelic/go-agent"
+	"github.com/stretchr/testify/require"
+)
+
+const userEmail = "e2e@example.com"
+const accessToken = "e2e-access-token"
+
+// harness runs proctord as `proctord start` and `proctord start-scheduler` do, against a fake cluster
+// and stores in memory. Its background loops tick every few milliseconds
+type harness struct {
+	t             *testing.T
+	cluster       *cluster
+	server        *httptest.Server
+	store         storage.Store
+	metadataStore metadata.Store
+	secretsStore  secrets.Store
+	mailer        *mailer
+	signalsChans  []chan os.Signal
+	stopped       sync.WaitGroup
+}
+
+// disableNewRelic gives the routes an application which reports nowhere, proctord initialises it on start
+var disableNewRelic sync.Once
+
+func newHarness(t *testing.T) *harness {
+	disableNewRelic.Do(func() {
+		newRelicConfig := newrelic.NewConfig("proctord-e2e", "")
+		newRelicConfig.Enabled = false
+		app, err := newrelic.NewApplication(newRelicConfig)
+		require.NoError(t, err)
+		instrumentation.NewRelicApp = app
+	})
+
+	store := storage.NewInMemoryStore()
+	redisClient := redis.NewInMemoryClient()
+	cluster := newCluster(t)
+	executionBackend := kubernetes.NewClientForClientSet(cluster.clientSet)
+
+	router, err := server.NewRouter(store, redisClient, executionBackend)
+	require.NoError(t, err)
+
+	harness := &harness{
+		t:             t,
+		cluster:       cluster,
+		server:        httptest.NewServer(router),
+		store:         store,
+		metadataStore: metadata.NewStore(redisClient),
+		secretsStore:  secrets.NewStore(redisClient),
+		mailer:        &mailer{},
+	}
+
+	err = harness.store.InsertAccessToken(&postgres.AccessToken{
+		UserEmail: userEmail,
+		TokenHash: auth.HashAccessToken(accessToken),
+		Name:      "e2e",
+	})
+	require.NoError(t, err)
+
+	statusController := execution.NewStatusController(harness.store, executionBackend)
+	harness.run(statusController.Run, time.Second)
+
+	callbackDispatcher := callback.NewDispatcher(harness.store, &http.Client{Timeout: 5 * time.Second}, 3, 10*time.Millisecond)
+	harness.run(callbackDispatcher.Run, 10*time.Millisecond)
+
+	executioner := execution.NewExecutioner(executionBackend, harness.metadataStore, harness.secretsStore)
+	worker := schedule.NewWorker(harness.store, executioner, audit.New(harness.store, executionBackend), harness.mailer)
+	harness.run(worker.Run, 10*time.Millisecond)
+
+	return harness
+}
+
+// Stop stops the background loops and the server, tests defer it
+func (harness *harness) Stop() {
+	for _, signalsChan := range harness.signalsChans {
+		signalsChan <- syscall.SIGTERM
+	}
+	harness.stopped.Wait()
+	harness.server.Close()
+}
+
+func (harness *harness) run(loop func(<-chan time.Time, <-chan os.Signal), interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	signalsChan := make(chan os.Signal, 1)
+	harness.signalsChans = append(harness.signalsChans, signalsChan)
+
+	harness.stopped.Add(1)
+	go func() {
+		defer harness.stopped.Done()
+		defer ticker.Stop()
+		loop(ticker.C, signalsChan)
+	}()
+}
+
+// AddProc publishes a proc straight to the metadata store
+func (harness *harness) AddProc(jobMetadata metadata.Metadata) {
+	require.NoError(harness.t, harness.metadataStore.CreateOrUpdateJobMetadata(jobMetadata))
+}
+
+func (harness *harness) Request(method, path string, body interface{}, headers map[string]string) *http.Response {
+	var requestBody io.Reader
+	if body != nil {
+		encodedBody, err := json.Marshal(body)
+		require.NoError(harness.t, err)
+		requestBody = bytes.NewReader(encodedBody)
+	}
+
+	req, err := http.NewRequest(method, harness.server.URL+path, requestBody)
+	require.NoError(harness.t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(utility.UserEmailHeaderKey, userEmail)
+	req.Header.Set(utility.AccessTokenHeaderKey, accessToken)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	response, err := http.DefaultClient.Do(req)
+	require.NoError(harness.t, err)
+	return response
+}
+
+// Decode reads the json body of a response which must have the expected status code
+func (harness *harness) Decode(response *http.Response, expectedStatusCode int, v interface{}) {
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	require.NoError(harness.t, err)
+	require.Equal(harness.t, expectedStatusCode, response.StatusCode, string(body))
+	require.NoError(harness.t, json.Unmarshal(body, v))
+}
+
+// Execute submits an execution and returns its ID once its job is in the cluster
+func (harness *harness) Execute(job execution.Job, headers map[string]string) string {
+	var executed struct {
+		Name string `json:"name"`
+	}
+	harness.Decode(harness.Request("POST", "/jobs/execute", job, headers), http.StatusCreated, &executed)
+
+	harness.cluster.WaitForJob(executed.Name)
+	return executed.Name
+}
+
+func (harness *harness) Detail(jobExecutionID string) execution.Detail {
+	var detail execution.Detail
+	harness.Decode(harness.Request("GET", "/jobs/execute/"+jobExecutionID, nil, nil), http.StatusOK, &detail)
+	return detail
+}
+
+// WaitForStatus waits till the execution is recorded with the status
+func (harness *harness) WaitForStatus(jobExecutionID, status string) {
+	var lastStatus string
+	require.Eventually(harness.t, func() bool {
+		lastStatus = harness.Detail(jobExecutionID).ExecutionStatus
+		return lastStatus == status
+	}, 5*time.Second, 20*time.Millisecond, "execution %s is %s, not %s", jobExecutionID, lastStatus, status)
+}
+
+// Logs streams the logs of an execution over the websocket of /jobs/logs till proctord closes it.
+// It returns an error rather than failing the test so that it can follow logs in a goroutine
+func (harness *harness) Logs(jobExecutionID string, follow bool) ([]string, error) {
+	logsURL, err := url.Parse(harness.server.URL)
+	if err != nil {
+		return nil, err
+	}
+	logsURL.Scheme = "ws"
+	logsURL.Path = "/jobs/logs"
+	logsURL.RawQuery = url.Values{"job_name": {jobExecutionID}, "follow": {strconv.FormatBool(follow)}}.Encode()
+
+	headers := http.Header{}
+	headers.Set(utility.UserEmailHeaderKey, userEmail)
+	headers.Set(utility.AccessTokenHeaderKey, accessToken)
+	conn, _, err := websocket.DefaultDialer.Dial(logsURL.String(), headers)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	lines := []string{}
+	for {
+		_, message, err := conn.ReadMessage()
+		if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+			return lines, nil
+		}
+		if err != nil {
+			return lines, err
+		}
+		lines = append(lines, string(message))
+	}
+}
+
+type mail struct {
+	JobName        string
+	JobExecutionID string
+	Status         string
+	Recipients     []string
+}
+
+// mailer records the notifications of scheduled executions instead of sending them
+type mailer struct {
+	mutex sync.Mutex
+	mails []mail
+}
+
+func (mailer *mailer) Send(jobName, jobExecutionID, jobExecutionStatus string, jobArgs map[string]string, recipients []string) error {
+	mailer.mutex.Lock()
+	defer mailer.mutex.Unlock()
+
+	mailer.mails = append(mailer.mails, mail{JobName: jobName, JobExecutionID: jobExecutionID, Status: jobExecutionStatus, Recipients: recipients})
+	return nil
+}
+
+func (mailer *mailer) Mails() []mail {
+	mailer.mutex.Lock()
+	defer mailer.mutex.Unlock()
+
+	return append([]mail{}, mailer.mails...)
+}
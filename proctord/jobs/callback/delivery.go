@@ -0,0 +1,49 @@
+package callback
+
+import (
+	"time"
+
+	"proctor/proctord/storage/postgres"
+)
+
+type Delivery struct {
+	URL           string    `json:"url"`
+	Status        string    `json:"status"`
+	Attempts      []Attempt `json:"attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type Attempt struct {
+	ResponseCode int64     `json:"response_code,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	AttemptedAt  time.Time `json:"attempted_at"`
+}
+
+// NewDeliveries groups attempts under their callbacks, secrets are left out
+func NewDeliveries(statusCallbacks []postgres.StatusCallback, statusCallbackAttempts []postgres.StatusCallbackAttempt) []Delivery {
+	deliveries := []Delivery{}
+	for _, statusCallback := range statusCallbacks {
+		attempts := []Attempt{}
+		for _, statusCallbackAttempt := range statusCallbackAttempts {
+			if statusCallbackAttempt.CallbackID == statusCallback.ID {
+				attempts = append(attempts, Attempt{
+					ResponseCode: statusCallbackAttempt.ResponseCode.Int64,
+					Error:        statusCallbackAttempt.Error,
+					AttemptedAt:  statusCallbackAttempt.CreatedAt,
+				})
+			}
+		}
+
+		deliveries = append(deliveries, Delivery{
+			URL:           statusCallback.URL,
+			Status:        statusCallback.Status,
+			Attempts:      attempts,
+			NextAttemptAt: statusCallback.NextAttemptAt,
+			CreatedAt:     statusCallback.CreatedAt,
+			UpdatedAt:     statusCallback.UpdatedAt,
+		})
+	}
+	return deliveries
+}
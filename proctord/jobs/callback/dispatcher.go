@@ -0,0 +1,163 @@
+package callback
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/getsentry/raven-go"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"proctor/proctord/logger"
+	"proctor/proctord/storage"
+	"proctor/proctord/storage/postgres"
+	"proctor/proctord/utility"
+)
+
+const batchSize = 50
+const maxBackoff = time.Hour
+
+type dispatcher struct {
+	store       storage.Store
+	httpClient  *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	lease       time.Duration
+}
+
+type Dispatcher interface {
+	Run(<-chan time.Time, <-chan os.Signal)
+}
+
+// NewDispatcher delivers status callbacks of finished executions, retrying failed deliveries
+// with exponential backoff starting at backoff until maxAttempts are made.
+// Callbacks of a claimed batch are delivered concurrently, each within the timeout of httpClient,
+// so that the batch is done before its lease ends and no other dispatcher claims it again
+func NewDispatcher(store storage.Store, httpClient *http.Client, maxAttempts int, backoff time.Duration) Dispatcher {
+	return &dispatcher{
+		store:       store,
+		httpClient:  httpClient,
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+		lease:       2*httpClient.Timeout + time.Minute,
+	}
+}
+
+func (dispatcher *dispatcher) Run(tickerChan <-chan time.Time, signalsChan <-chan os.Signal) {
+	for {
+		select {
+		case <-tickerChan:
+			dispatcher.dispatch()
+		case <-signalsChan:
+			return
+		}
+	}
+}
+
+func (dispatcher *dispatcher) dispatch() {
+	statusCallbacks, err := dispatcher.store.ClaimDueStatusCallbacks(time.Now().Add(dispatcher.lease), batchSize)
+	if err != nil {
+		logger.Error("Error claiming due status callbacks from store: ", err.Error())
+		raven.CaptureError(err, nil)
+		return
+	}
+
+	var delivered sync.WaitGroup
+	for _, statusCallback := range statusCallbacks {
+		delivered.Add(1)
+		go func(statusCallback postgres.StatusCallback) {
+			defer delivered.Done()
+			dispatcher.deliver(statusCallback)
+		}(statusCallback)
+	}
+	delivered.Wait()
+}
+
+func (dispatcher *dispatcher) deliver(statusCallback postgres.StatusCallback) {
+	responseCode, err := dispatcher.post(statusCallback)
+
+	statusCallbackAttempt := &postgres.StatusCallbackAttempt{CallbackID: statusCallback.ID}
+	if responseCode != 0 {
+		statusCallbackAttempt.ResponseCode = sql.NullInt64{Int64: int64(responseCode), Valid: true}
+	}
+
+	statusCallback.Attempts++
+	statusCallback.UpdatedAt = time.Now()
+	if err == nil {
+		statusCallback.Status = utility.StatusCallbackDelivered
+	} else {
+		logger.Info(fmt.Sprintf("StatusCallback: attempt %d of callback %d of execution %s failed: %s", statusCallback.Attempts, statusCallback.ID, statusCallback.ExecutionID, err.Error()))
+
+		statusCallbackAttempt.Error = err.Error()
+		if statusCallback.Attempts >= dispatcher.maxAttempts {
+			statusCallback.Status = utility.StatusCallbackFailed
+		} else {
+			statusCallback.NextAttemptAt = statusCallback.UpdatedAt.Add(dispatcher.backoffAfter(statusCallback.Attempts))
+		}
+	}
+
+	err = dispatcher.store.InsertStatusCallbackAttempt(statusCallbackAttempt)
+	if err != nil {
+		logger.Error(fmt.Sprintf("StatusCallback: Error recording attempt of callback %d", statusCallback.ID), err.Error())
+		raven.CaptureError(err, map[string]string{"job_id": statusCallback.ExecutionID})
+	}
+
+	err = dispatcher.store.UpdateStatusCallback(&statusCallback)
+	if err != nil {
+		logger.Error(fmt.Sprintf("StatusCallback: Error updating callback %d", statusCallback.ID), err.Error())
+		raven.CaptureError(err, map[string]string{"job_id": statusCallback.ExecutionID})
+	}
+}
+
+func (dispatcher *dispatcher) backoffAfter(attempts int) time.Duration {
+	backoff := dispatcher.backoff
+	for i := 1; i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
+
+func (dispatcher *dispatcher) post(statusCallback postgres.StatusCallback) (int, error) {
+	payload, err := json.Marshal(map[string]string{"name": statusCallback.ExecutionID, "status": statusCallback.ExecutionStatus})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequest("POST", statusCallback.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(utility.CallbackDeliveryHeaderKey, strconv.FormatInt(statusCallback.ID, 10))
+	if statusCallback.Secret != "" {
+		req.Header.Set(utility.CallbackSignatureHeaderKey, Sign(statusCallback.Secret, payload))
+	}
+
+	resp, err := dispatcher.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("callback url responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign is the value of the signature header of a callback payload, callers verify it with the secret they passed
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
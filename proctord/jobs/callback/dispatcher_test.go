@@ -0,0 +1,189 @@
+package callback
+
+import (
+	"database/sql"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"proctor/proctord/storage"
+	"proctor/proctord/storage/postgres"
+	"proctor/proctord/utility"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type DispatcherTestSuite struct {
+	suite.Suite
+	mockStore      *storage.MockStore
+	testDispatcher *dispatcher
+}
+
+func (suite *DispatcherTestSuite) SetupTest() {
+	suite.mockStore = &storage.MockStore{}
+	suite.testDispatcher = NewDispatcher(suite.mockStore, &http.Client{Timeout: time.Second}, 3, 10*time.Second).(*dispatcher)
+}
+
+func (suite *DispatcherTestSuite) TestDeliverSignsPayloadAndMarksCallbackDelivered() {
+	t := suite.T()
+
+	var receivedBody []byte
+	var receivedSignature, receivedDelivery string
+	callbackServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		receivedBody, _ = ioutil.ReadAll(req.Body)
+		receivedSignature = req.Header.Get(utility.CallbackSignatureHeaderKey)
+		receivedDelivery = req.Header.Get(utility.CallbackDeliveryHeaderKey)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer callbackServer.Close()
+
+	statusCallback := postgres.StatusCallback{
+		ID:              7,
+		ExecutionID:     "any-execution-id",
+		URL:             callbackServer.URL,
+		Secret:          "any-secret",
+		Status:          utility.StatusCallbackPending,
+		ExecutionStatus: utility.JobSucceeded,
+	}
+
+	suite.mockStore.On("InsertStatusCallbackAttempt", &postgres.StatusCallbackAttempt{
+		CallbackID:   7,
+		ResponseCode: sql.NullInt64{Int64: 200, Valid: true},
+	}).Return(nil).Once()
+	suite.mockStore.On("UpdateStatusCallback", mock.MatchedBy(func(updated *postgres.StatusCallback) bool {
+		return updated.Status == utility.StatusCallbackDelivered && updated.Attempts == 1
+	})).Return(nil).Once()
+
+	suite.testDispatcher.deliver(statusCallback)
+
+	assert.Equal(t, `{"name":"any-execution-id","status":"SUCCEEDED"}`, string(receivedBody))
+	assert.Equal(t, Sign("any-secret", receivedBody), receivedSignature)
+	assert.Equal(t, "7", receivedDelivery)
+	suite.mockStore.AssertExpectations(t)
+}
+
+func (suite *DispatcherTestSuite) TestDeliverWithoutSecretIsNotSigned() {
+	t := suite.T()
+
+	signed := true
+	callbackServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, signed = req.Header[utility.CallbackSignatureHeaderKey]
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer callbackServer.Close()
+
+	suite.mockStore.On("InsertStatusCallbackAttempt", mock.Anything).Return(nil).Once()
+	suite.mockStore.On("UpdateStatusCallback", mock.Anything).Return(nil).Once()
+
+	suite.testDispatcher.deliver(postgres.StatusCallback{ID: 7, URL: callbackServer.URL, ExecutionStatus: utility.JobFailed})
+
+	assert.False(t, signed)
+	suite.mockStore.AssertExpectations(t)
+}
+
+func (suite *DispatcherTestSuite) TestDeliverBacksOffOnFailure() {
+	t := suite.T()
+
+	callbackServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer callbackServer.Close()
+
+	suite.mockStore.On("InsertStatusCallbackAttempt", &postgres.StatusCallbackAttempt{
+		CallbackID:   7,
+		ResponseCode: sql.NullInt64{Int64: 503, Valid: true},
+		Error:        "callback url responded with status 503",
+	}).Return(nil).Once()
+	suite.mockStore.On("UpdateStatusCallback", mock.MatchedBy(func(updated *postgres.StatusCallback) bool {
+		return updated.Status == utility.StatusCallbackPending && updated.Attempts == 2 &&
+			updated.NextAttemptAt.Sub(updated.UpdatedAt) == 20*time.Second
+	})).Return(nil).Once()
+
+	suite.testDispatcher.deliver(postgres.StatusCallback{ID: 7, URL: callbackServer.URL, Status: utility.StatusCallbackPending, Attempts: 1})
+
+	suite.mockStore.AssertExpectations(t)
+}
+
+func (suite *DispatcherTestSuite) TestDeliverGivesUpAfterMaxAttempts() {
+	t := suite.T()
+
+	suite.mockStore.On("InsertStatusCallbackAttempt", mock.MatchedBy(func(attempt *postgres.StatusCallbackAttempt) bool {
+		return !attempt.ResponseCode.Valid && attempt.Error != ""
+	})).Return(nil).Once()
+	suite.mockStore.On("UpdateStatusCallback", mock.MatchedBy(func(updated *postgres.StatusCallback) bool {
+		return updated.Status == utility.StatusCallbackFailed && updated.Attempts == 3
+	})).Return(nil).Once()
+
+	suite.testDispatcher.deliver(postgres.StatusCallback{ID: 7, URL: "http://127.0.0.1:0", Status: utility.StatusCallbackPending, Attempts: 2})
+
+	suite.mockStore.AssertExpectations(t)
+}
+
+func (suite *DispatcherTestSuite) TestBackoffIsCapped() {
+	t := suite.T()
+
+	assert.Equal(t, 10*time.Second, suite.testDispatcher.backoffAfter(1))
+	assert.Equal(t, 40*time.Second, suite.testDispatcher.backoffAfter(3))
+	assert.Equal(t, maxBackoff, suite.testDispatcher.backoffAfter(100))
+}
+
+func (suite *DispatcherTestSuite) TestRunDispatchesOnTick() {
+	t := suite.T()
+
+	tickerChan := make(chan time.Time)
+	signalsChan := make(chan os.Signal, 1)
+
+	suite.mockStore.On("ClaimDueStatusCallbacks", mock.AnythingOfType("time.Time"), batchSize).Return([]postgres.StatusCallback{}, errors.New("error")).Once()
+
+	go func() {
+		tickerChan <- time.Now()
+		signalsChan <- syscall.SIGTERM
+	}()
+
+	suite.testDispatcher.Run(tickerChan, signalsChan)
+
+	suite.mockStore.AssertExpectations(t)
+}
+
+func (suite *DispatcherTestSuite) TestDispatchDeliversBatchOutlastingTimeoutWithinLease() {
+	t := suite.T()
+
+	timeout := 400 * time.Millisecond
+	callbackServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer callbackServer.Close()
+
+	testDispatcher := NewDispatcher(suite.mockStore, &http.Client{Timeout: timeout}, 3, 10*time.Second).(*dispatcher)
+	statusCallbacks := []postgres.StatusCallback{}
+	for id := int64(1); id <= 5; id++ {
+		statusCallbacks = append(statusCallbacks, postgres.StatusCallback{ID: id, ExecutionID: "any-execution-id", URL: callbackServer.URL, Status: utility.StatusCallbackPending})
+	}
+
+	var leaseUntil time.Time
+	suite.mockStore.On("ClaimDueStatusCallbacks", mock.AnythingOfType("time.Time"), batchSize).Return(statusCallbacks, nil).Run(
+		func(args mock.Arguments) { leaseUntil = args.Get(0).(time.Time) },
+	).Once()
+	suite.mockStore.On("InsertStatusCallbackAttempt", mock.Anything).Return(nil).Times(5)
+	suite.mockStore.On("UpdateStatusCallback", mock.MatchedBy(func(updated *postgres.StatusCallback) bool {
+		return updated.Status == utility.StatusCallbackDelivered
+	})).Return(nil).Times(5)
+
+	started := time.Now()
+	testDispatcher.dispatch()
+
+	suite.mockStore.AssertExpectations(t)
+	assert.True(t, time.Since(started) < 2*timeout, "the batch took %s, delivered one by one it takes 1.5s", time.Since(started))
+	assert.True(t, time.Now().Before(leaseUntil))
+}
+
+func TestDispatcherTestSuite(t *testing.T) {
+	suite.Run(t, new(DispatcherTestSuite))
+}
@@ -0,0 +1,336 @@
+package execution
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"proctor/proctord/auth"
+	"proctor/proctord/config"
+	"proctor/proctord/jobs/metadata"
+	"proctor/proctord/logger"
+	"proctor/proctord/storage/postgres"
+	"proctor/proctord/utility"
+
+	"github.com/getsentry/raven-go"
+	"github.com/gorilla/mux"
+)
+
+// Approval is a request to execute a proc requiring approval, as shown to its requester and approvers
+type Approval struct {
+	ID            string            `json:"id"`
+	JobName       string            `json:"job_name"`
+	UserEmail     string            `json:"user_email"`
+	Args          map[string]string `json:"args"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	Reason        string            `json:"reason,omitempty"`
+	ParentName    string            `json:"parent_name,omitempty"`
+	Status        string            `json:"status"`
+	DecidedBy     string            `json:"decided_by,omitempty"`
+	ExecutionName string            `json:"execution_name,omitempty"`
+	ExpiresAt     time.Time         `json:"expires_at"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+func NewApproval(approvalRequest postgres.ApprovalRequest, job Job) Approval {
+	return Approval{
+		ID:            approvalRequest.ID,
+		JobName:       approvalRequest.JobName,
+		UserEmail:     approvalRequest.UserEmail,
+		Args:          job.Args,
+		Labels:        job.Labels,
+		Reason:        job.Reason,
+		ParentName:    approvalRequest.ParentExecutionID.String,
+		Status:        approvalRequest.Status,
+		DecidedBy:     approvalRequest.DecidedBy,
+		ExecutionName: approvalRequest.ExecutionID.String,
+		ExpiresAt:     approvalRequest.ExpiresAt,
+		CreatedAt:     approvalRequest.CreatedAt,
+	}
+}
+
+func decodeApprovalRequestJob(approvalRequest postgres.ApprovalRequest) (Job, error) {
+	var job Job
+	err := json.Unmarshal([]byte(approvalRequest.Job), &job)
+	return job, err
+}
+
+// requestApproval holds a validated execution until an approver decides on it and returns the ID
+// of the approval request, which is empty when it couldn't be saved
+func (handler *executionHandler) requestApproval(w http.ResponseWriter, user auth.User, job Job, callbackSecret string, jobsExecutionAuditLog *postgres.JobsExecutionAuditLog) string {
+	jobInJSON, err := json.Marshal(job)
+	if err != nil {
+		logger.Error(fmt.Sprintf("%s: User %s: Error marshalling job to request approval: ", job.Name, user.Email), err.Error())
+		raven.CaptureError(err, map[string]string{"user_email": user.Email, "job_name": job.Name})
+
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(utility.ServerError))
+		return ""
+	}
+
+	approvalID, err := handler.store.InsertApprovalRequest(&postgres.ApprovalRequest{
+		JobName:           job.Name,
+		UserEmail:         user.Email,
+		Job:               string(jobInJSON),
+		CallbackSecret:    callbackSecret,
+		ParentExecutionID: jobsExecutionAuditLog.ParentExecutionID,
+		ExpiresAt:         time.Now().Add(time.Duration(config.ApprovalRequestTTLInMins()) * time.Minute),
+	})
+	if err != nil {
+		logger.Error(fmt.Sprintf("%s: User %s: Error saving approval request: ", job.Name, user.Email), err.Error())
+		raven.CaptureError(err, map[string]string{"user_email": user.Email, "job_name": job.Name})
+
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(utility.ServerError))
+		return ""
+	}
+	logger.Info(fmt.Sprintf("%s: User %s: Execution is pending approval: %s", job.Name, user.Email, approvalID))
+
+	w.WriteHeader(http.StatusAccepted)
+	w.Write([]byte(fmt.Sprintf("{ \"id\":\"%s\", \"status\":\"%s\" }", approvalID, utility.ApprovalPending)))
+	return approvalID
+}
+
+// canApprove is false for procs without approver groups, so that requests outliving requires_approval
+// on their proc can only expire
+func (handler *executionHandler) canApprove(user auth.User, jobMetadata *metadata.Metadata) (bool, error) {
+	if len(jobMetadata.ApproverGroups) == 0 {
+		return false, nil
+	}
+	return handler.authorizer.Authorize(user, jobMetadata.ApproverGroups)
+}
+
+// Approvals lists pending approval requests which the user has made or can approve
+func (handler *executionHandler) Approvals() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		user, _ := auth.FromContext(req.Context())
+
+		approvalRequests, err := handler.store.GetPendingApprovalRequests()
+		if err != nil {
+			logger.Error(fmt.Sprintf("User %s: Error fetching approval requests", user.Email), err.Error())
+			raven.CaptureError(err, map[string]string{"user_email": user.Email})
+
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+
+		approverOf := make(map[string]bool)
+		approvals := []Approval{}
+		for _, approvalRequest := range approvalRequests {
+			canApprove, checked := approverOf[approvalRequest.JobName]
+			if !checked {
+				jobMetadata, err := handler.metadataStore.GetJobMetadata(approvalRequest.JobName)
+				if err == nil {
+					canApprove, err = handler.canApprove(user, jobMetadata)
+				}
+				if err != nil && err.Error() != "redigo: nil returned" {
+					logger.Error(fmt.Sprintf("%s: User %s: Error checking approver groups: ", approvalRequest.JobName, user.Email), err.Error())
+					raven.CaptureError(err, map[string]string{"user_email": user.Email, "job_name": approvalRequest.JobName})
+
+					w.WriteHeader(http.StatusInternalServerError)
+					w.Write([]byte(utility.ServerError))
+					return
+				}
+				approverOf[approvalRequest.JobName] = canApprove
+			}
+			if !canApprove && approvalRequest.UserEmail != user.Email {
+				continue
+			}
+
+			job, err := decodeApprovalRequestJob(approvalRequest)
+			if err != nil {
+				logger.Error("Error decoding job of approval request", approvalRequest.ID, err.Error())
+			}
+			approvals = append(approvals, NewApproval(approvalRequest, job))
+		}
+
+		approvalsInJSON, err := json.Marshal(approvals)
+		if err != nil {
+			logger.Error(fmt.Sprintf("User %s: Error marshalling approval requests", user.Email), err.Error())
+			raven.CaptureError(err, map[string]string{"user_email": user.Email})
+
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(approvalsInJSON)
+	}
+}
+
+// decidableApprovalRequest responds with an error and returns false unless the request is pending
+// and the user, other than its requester, is an approver of its proc
+func (handler *executionHandler) decidableApprovalRequest(w http.ResponseWriter, user auth.User, approvalID, action string) (postgres.ApprovalRequest, *metadata.Metadata, Job, bool) {
+	approvalRequests, err := handler.store.GetApprovalRequest(approvalID)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid input syntax") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("Invalid approval request ID"))
+			return postgres.ApprovalRequest{}, nil, Job{}, false
+		}
+		logger.Error(fmt.Sprintf("User %s: Error fetching approval request: %s", user.Email, approvalID), err.Error())
+		raven.CaptureError(err, map[string]string{"user_email": user.Email, "approval_id": approvalID})
+
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(utility.ServerError))
+		return postgres.ApprovalRequest{}, nil, Job{}, false
+	}
+	if len(approvalRequests) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(utility.ApprovalRequestNotFoundError))
+		return postgres.ApprovalRequest{}, nil, Job{}, false
+	}
+	approvalRequest := approvalRequests[0]
+	jobName := approvalRequest.JobName
+
+	if approvalRequest.Status != utility.ApprovalPending {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(utility.ApprovalRequestDecidedClientError))
+		return postgres.ApprovalRequest{}, nil, Job{}, false
+	}
+
+	if approvalRequest.UserEmail == user.Email {
+		logger.Info(fmt.Sprintf("%s: User %s: Not allowed to %s own approval request: %s", jobName, user.Email, action, approvalID))
+
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(utility.SelfApprovalClientError))
+		return postgres.ApprovalRequest{}, nil, Job{}, false
+	}
+
+	jobMetadata, err := handler.metadataStore.GetJobMetadata(jobName)
+	if err != nil {
+		if err.Error() == "redigo: nil returned" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(utility.NonExistentProcClientError))
+			return postgres.ApprovalRequest{}, nil, Job{}, false
+		}
+		logger.Error(fmt.Sprintf("%s: User %s: Error fetching metadata: ", jobName, user.Email), err.Error())
+		raven.CaptureError(err, map[string]string{"user_email": user.Email, "job_name": jobName})
+
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(utility.ServerError))
+		return postgres.ApprovalRequest{}, nil, Job{}, false
+	}
+
+	authorized, err := handler.canApprove(user, jobMetadata)
+	if err != nil {
+		logger.Error(fmt.Sprintf("%s: User %s: Error authorizing approver: ", jobName, user.Email), err.Error())
+		raven.CaptureError(err, map[string]string{"user_email": user.Email, "job_name": jobName})
+
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(utility.ServerError))
+		return postgres.ApprovalRequest{}, nil, Job{}, false
+	}
+	if !authorized {
+		logger.Info(fmt.Sprintf("%s: User %s: Not authorized to %s approval request: %s", jobName, user.Email, action, approvalID))
+
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(utility.UnauthorizedApproverClientError))
+		return postgres.ApprovalRequest{}, nil, Job{}, false
+	}
+
+	job, err := decodeApprovalRequestJob(approvalRequest)
+	if err != nil {
+		logger.Error(fmt.Sprintf("%s: User %s: Error decoding job of approval request: %s", jobName, user.Email, approvalID), err.Error())
+		raven.CaptureError(err, map[string]string{"user_email": user.Email, "approval_id": approvalID})
+
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(utility.ServerError))
+		return postgres.ApprovalRequest{}, nil, Job{}, false
+	}
+
+	return approvalRequest, jobMetadata, job, true
+}
+
+// decide records the decision and responds with a conflict when another one got recorded first
+func (handler *executionHandler) decide(w http.ResponseWriter, user auth.User, approvalRequest postgres.ApprovalRequest, status string) bool {
+	rowsAffected, err := handler.store.DecideApprovalRequest(approvalRequest.ID, status, user.Email)
+	if err != nil {
+		logger.Error(fmt.Sprintf("%s: User %s: Error recording decision on approval request: %s", approvalRequest.JobName, user.Email, approvalRequest.ID), err.Error())
+		raven.CaptureError(err, map[string]string{"user_email": user.Email, "approval_id": approvalRequest.ID})
+
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(utility.ServerError))
+		return false
+	}
+	if rowsAffected == 0 {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(utility.ApprovalRequestDecidedClientError))
+		return false
+	}
+	return true
+}
+
+// Approve submits the held execution on behalf of its requester, with the approver in its audit log.
+// The approval is taken back when the execution fails to submit, so that the request can be approved again
+func (handler *executionHandler) Approve() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		approvalID := mux.Vars(req)["id"]
+		user, _ := auth.FromContext(req.Context())
+
+		approvalRequest, jobMetadata, job, found := handler.decidableApprovalRequest(w, user, approvalID, "approve")
+		if !found {
+			return
+		}
+		if !handler.decide(w, user, approvalRequest, utility.ApprovalApproved) {
+			return
+		}
+
+		jobsExecutionAuditLog := &postgres.JobsExecutionAuditLog{
+			JobName:            job.Name,
+			UserEmail:          approvalRequest.UserEmail,
+			JobExecutionStatus: "WAITING",
+			ParentExecutionID:  approvalRequest.ParentExecutionID,
+			ApprovedBy:         user.Email,
+		}
+		jobExecutionID, _ := handler.submit(w, auth.User{Email: approvalRequest.UserEmail}, jobMetadata, job, approvalRequest.CallbackSecret, jobsExecutionAuditLog)
+		if jobExecutionID == "" {
+			err := handler.store.ReopenApprovalRequest(approvalID)
+			if err != nil {
+				logger.Error(fmt.Sprintf("%s: User %s: Error reopening approval request: %s", job.Name, user.Email, approvalID), err.Error())
+				raven.CaptureError(err, map[string]string{"user_email": user.Email, "approval_id": approvalID})
+			}
+			return
+		}
+
+		err := handler.store.UpdateApprovalRequestExecutionID(approvalID, jobExecutionID)
+		if err != nil {
+			logger.Error(fmt.Sprintf("%s: User %s: Error saving execution of approval request: %s", job.Name, user.Email, approvalID), err.Error())
+			raven.CaptureError(err, map[string]string{"user_email": user.Email, "approval_id": approvalID, "job_id": jobExecutionID})
+		}
+	}
+}
+
+func (handler *executionHandler) Reject() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		approvalID := mux.Vars(req)["id"]
+		user, _ := auth.FromContext(req.Context())
+
+		approvalRequest, _, job, found := handler.decidableApprovalRequest(w, user, approvalID, "reject")
+		if !found {
+			return
+		}
+		if !handler.decide(w, user, approvalRequest, utility.ApprovalRejected) {
+			return
+		}
+		logger.Info(fmt.Sprintf("%s: User %s: Rejected approval request: %s", job.Name, user.Email, approvalID))
+
+		approvalRequest.Status = utility.ApprovalRejected
+		approvalRequest.DecidedBy = user.Email
+		approvalInJSON, err := json.Marshal(NewApproval(approvalRequest, job))
+		if err != nil {
+			logger.Error(fmt.Sprintf("User %s: Error marshalling approval request: %s", user.Email, approvalID), err.Error())
+
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(approvalInJSON)
+	}
+}
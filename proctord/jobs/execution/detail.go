@@ -0,0 +1,77 @@
+package execution
+
+import (
+	"encoding/json"
+	"time"
+
+	"proctor/proctord/logger"
+	"proctor/proctord/storage/postgres"
+	"proctor/proctord/utility"
+)
+
+// Detail is the audit record of an execution as shown to users, secret args are redacted at execution time
+type Detail struct {
+	Name             string            `json:"name"`
+	JobName          string            `json:"job_name"`
+	ImageName        string            `json:"image_name"`
+	UserEmail        string            `json:"user_email"`
+	Args             map[string]string `json:"args"`
+	SubmissionStatus string            `json:"submission_status"`
+	ExecutionStatus  string            `json:"execution_status"`
+	CancelledBy      string            `json:"cancelled_by,omitempty"`
+	ApprovedBy       string            `json:"approved_by,omitempty"`
+	ParentName       string            `json:"parent_name,omitempty"`
+	Labels           map[string]string `json:"labels,omitempty"`
+	Reason           string            `json:"reason,omitempty"`
+	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+	DurationSeconds  int64             `json:"duration_seconds"`
+}
+
+func NewDetail(jobsExecutionAuditLog postgres.JobsExecutionAuditLog) Detail {
+	args, err := utility.DeserializeMap(jobsExecutionAuditLog.JobArgs)
+	if err != nil {
+		logger.Error("Error decoding job args of execution", jobsExecutionAuditLog.ExecutionID.String, err.Error())
+	}
+
+	finishedAt := time.Now()
+	if IsFinished(jobsExecutionAuditLog.JobExecutionStatus) {
+		finishedAt = jobsExecutionAuditLog.UpdatedAt
+	}
+
+	return Detail{
+		Name:             jobsExecutionAuditLog.ExecutionID.String,
+		JobName:          jobsExecutionAuditLog.JobName,
+		ImageName:        jobsExecutionAuditLog.ImageName,
+		UserEmail:        jobsExecutionAuditLog.UserEmail,
+		Args:             args,
+		SubmissionStatus: jobsExecutionAuditLog.JobSubmissionStatus,
+		ExecutionStatus:  jobsExecutionAuditLog.JobExecutionStatus,
+		CancelledBy:      jobsExecutionAuditLog.CancelledBy,
+		ApprovedBy:       jobsExecutionAuditLog.ApprovedBy,
+		ParentName:       jobsExecutionAuditLog.ParentExecutionID.String,
+		Labels:           decodeLabels(jobsExecutionAuditLog),
+		Reason:           jobsExecutionAuditLog.Reason,
+		CreatedAt:        jobsExecutionAuditLog.CreatedAt,
+		UpdatedAt:        jobsExecutionAuditLog.UpdatedAt,
+		DurationSeconds:  int64(finishedAt.Sub(jobsExecutionAuditLog.CreatedAt).Seconds()),
+	}
+}
+
+func decodeLabels(jobsExecutionAuditLog postgres.JobsExecutionAuditLog) map[string]string {
+	if jobsExecutionAuditLog.Labels == "" {
+		return nil
+	}
+
+	var labels map[string]string
+	err := json.Unmarshal([]byte(jobsExecutionAuditLog.Labels), &labels)
+	if err != nil {
+		logger.Error("Error decoding labels of execution", jobsExecutionAuditLog.ExecutionID.String, err.Error())
+	}
+	return labels
+}
+
+func IsFinished(jobExecutionStatus string) bool {
+	return jobExecutionStatus == utility.JobSucceeded || jobExecutionStatus == utility.JobFailed || jobExecutionStatus == utility.JobCancelled ||
+		jobExecutionStatus == utility.JobNotFound
+}
@@ -4,32 +4,33 @@ import (
 	"errors"
 	"fmt"
 
+	"proctor/proctord/backend"
 	"proctor/proctord/jobs/metadata"
 	"proctor/proctord/jobs/secrets"
-	"proctor/proctord/kubernetes"
 	"proctor/proctord/storage/postgres"
 	"proctor/proctord/utility"
 )
 
 type executioner struct {
-	kubeClient    kubernetes.Client
-	metadataStore metadata.Store
-	secretsStore  secrets.Store
+	executionBackend backend.ExecutionBackend
+	metadataStore    metadata.Store
+	secretsStore     secrets.Store
 }
 
 type Executioner interface {
-	Execute(*postgres.JobsExecutionAuditLog, string, map[string]string) (string, error)
+	Execute(*postgres.JobsExecutionAuditLog, string, map[string]string, Limits, Provenance) (string, error)
+	Cancel(string) error
 }
 
-func NewExecutioner(kubeClient kubernetes.Client, metadataStore metadata.Store, secretsStore secrets.Store) Executioner {
+func NewExecutioner(executionBackend backend.ExecutionBackend, metadataStore metadata.Store, secretsStore secrets.Store) Executioner {
 	return &executioner{
-		kubeClient:    kubeClient,
-		metadataStore: metadataStore,
-		secretsStore:  secretsStore,
+		executionBackend: executionBackend,
+		metadataStore:    metadataStore,
+		secretsStore:     secretsStore,
 	}
 }
 
-func (executioner *executioner) Execute(jobsExecutionAuditLog *postgres.JobsExecutionAuditLog, jobName string, jobArgs map[string]string) (string, error) {
+func (executioner *executioner) Execute(jobsExecutionAuditLog *postgres.JobsExecutionAuditLog, jobName string, jobArgs map[string]string, limits Limits, provenance Provenance) (string, error) {
 	jobsExecutionAuditLog.JobName = jobName
 
 	jobMetadata, err := executioner.metadataStore.GetJobMetadata(jobName)
@@ -39,20 +40,57 @@ func (executioner *executioner) Execute(jobsExecutionAuditLog *postgres.JobsExec
 	imageName := jobMetadata.ImageName
 	jobsExecutionAuditLog.ImageName = imageName
 
+	// e.g. schedules made before the proc started requiring approval
+	if jobMetadata.RequiresApproval && jobsExecutionAuditLog.ApprovedBy == "" {
+		return "", errors.New(fmt.Sprintf("Job: %s requires approval to execute", jobName))
+	}
+
+	// the allowlist can have been narrowed since the proc was published
+	err = jobMetadata.Spec.Validate(metadata.PodAllowlist())
+	if err != nil {
+		return "", errors.New(fmt.Sprintf("Pod spec of job: %s is no longer allowed. Error: %s", jobName, err.Error()))
+	}
+
 	jobSecrets, err := executioner.secretsStore.GetJobSecrets(jobName)
 	if err != nil && err.Error() != "redigo: nil returned" {
 		return "", errors.New(fmt.Sprintf("Error retrieving secrets for job: %s. Error: %s", jobName, err.Error()))
 	}
 
-	envVars := utility.MergeMaps(jobArgs, jobSecrets)
-	jobsExecutionAuditLog.AddJobArgs(envVars)
+	jobArgs = jobMetadata.EnvVars.WithDefaults(jobArgs)
+	jobsExecutionAuditLog.AddJobArgs(utility.MergeMaps(jobArgs, secrets.Redact(jobSecrets)))
+	jobsExecutionAuditLog.AddLabels(provenance.Labels)
+	jobsExecutionAuditLog.Reason = provenance.Reason
 
-	jobExecutionID, err := executioner.kubeClient.ExecuteJob(imageName, envVars)
+	jobOptions := backend.JobOptions{
+		Resources:             jobMetadata.Resources,
+		ActiveDeadlineSeconds: jobMetadata.TimeoutSeconds,
+		BackoffLimit:          jobMetadata.Retries,
+		Secrets:               jobSecrets,
+		Labels:                provenance.Labels,
+		Reason:                provenance.Reason,
+		Pod:                   jobMetadata.Spec,
+	}
+	if limits.TimeoutSeconds != nil {
+		jobOptions.ActiveDeadlineSeconds = limits.TimeoutSeconds
+	}
+	if limits.Retries != nil {
+		jobOptions.BackoffLimit = limits.Retries
+	}
+
+	jobExecutionID, err := executioner.executionBackend.ExecuteJob(imageName, jobArgs, jobOptions)
 	if err != nil {
-		return "", errors.New(fmt.Sprintf("Error submitting job to kube: %s. Error: %s", jobName, err.Error()))
+		return "", errors.New(fmt.Sprintf("Error submitting job: %s. Error: %s", jobName, err.Error()))
 	}
 	jobsExecutionAuditLog.AddExecutionID(jobExecutionID)
 	jobsExecutionAuditLog.JobSubmissionStatus = utility.JobSubmissionSuccess
 
 	return jobExecutionID, nil
 }
+
+func (executioner *executioner) Cancel(jobExecutionID string) error {
+	err := executioner.executionBackend.CancelJob(jobExecutionID)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error cancelling job: %s. Error: %s", jobExecutionID, err.Error()))
+	}
+	return nil
+}
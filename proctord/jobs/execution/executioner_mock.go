@@ -9,7 +9,12 @@ type MockExecutioner struct {
 	mock.Mock
 }
 
-func (m *MockExecutioner) Execute(jobExecutionAuditLog *postgres.JobsExecutionAuditLog, jobName string, jobArgs map[string]string) (string, error) {
-	args := m.Called(jobExecutionAuditLog, jobName, jobArgs)
+func (m *MockExecutioner) Execute(jobExecutionAuditLog *postgres.JobsExecutionAuditLog, jobName string, jobArgs map[string]string, limits Limits, provenance Provenance) (string, error) {
+	args := m.Called(jobExecutionAuditLog, jobName, jobArgs, limits, provenance)
 	return args.String(0), args.Error(1)
 }
+
+func (m *MockExecutioner) Cancel(jobExecutionID string) error {
+	args := m.Called(jobExecutionID)
+	return args.Error(0)
+}
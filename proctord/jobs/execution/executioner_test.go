@@ -2,11 +2,15 @@ package execution
 
 import (
 	"errors"
+	"os"
 	"testing"
 
+	"proctor/proctord/backend"
 	"proctor/proctord/jobs/metadata"
+	"proctor/proctord/jobs/metadata/env"
+	"proctor/proctord/jobs/metadata/pod"
+	"proctor/proctord/jobs/metadata/resources"
 	"proctor/proctord/jobs/secrets"
-	"proctor/proctord/kubernetes"
 	"proctor/proctord/storage/postgres"
 	"proctor/proctord/utility"
 	"github.com/stretchr/testify/assert"
@@ -16,17 +20,17 @@ import (
 
 type ExecutionerTestSuite struct {
 	suite.Suite
-	mockKubeClient    kubernetes.MockClient
-	mockMetadataStore *metadata.MockStore
-	mockSecretsStore  *secrets.MockStore
-	testExecutioner   Executioner
+	mockExecutionBackend backend.MockExecutionBackend
+	mockMetadataStore    *metadata.MockStore
+	mockSecretsStore     *secrets.MockStore
+	testExecutioner      Executioner
 }
 
 func (suite *ExecutionerTestSuite) SetupTest() {
-	suite.mockKubeClient = kubernetes.MockClient{}
+	suite.mockExecutionBackend = backend.MockExecutionBackend{}
 	suite.mockMetadataStore = &metadata.MockStore{}
 	suite.mockSecretsStore = &secrets.MockStore{}
-	suite.testExecutioner = NewExecutioner(&suite.mockKubeClient, suite.mockMetadataStore, suite.mockSecretsStore)
+	suite.testExecutioner = NewExecutioner(&suite.mockExecutionBackend, suite.mockMetadataStore, suite.mockSecretsStore)
 }
 
 func (suite *ExecutionerTestSuite) TestSuccessfulJobExecution() {
@@ -40,6 +44,7 @@ func (suite *ExecutionerTestSuite) TestSuccessfulJobExecution() {
 
 	jobMetadata := metadata.Metadata{
 		ImageName: "img",
+		Resources: resources.Requirements{Limits: resources.Quantities{CPU: "1"}},
 	}
 	suite.mockMetadataStore.On("GetJobMetadata", jobName).Return(&jobMetadata, nil).Once()
 
@@ -49,18 +54,134 @@ func (suite *ExecutionerTestSuite) TestSuccessfulJobExecution() {
 	suite.mockSecretsStore.On("GetJobSecrets", jobName).Return(jobSecrets, nil).Once()
 
 	jobExecutionID := "proctor-ipsum-lorem"
-	envVarsForJob := utility.MergeMaps(jobArgs, jobSecrets)
-	suite.mockKubeClient.On("ExecuteJob", jobMetadata.ImageName, envVarsForJob).Return(jobExecutionID, nil).Once()
+	jobOptions := backend.JobOptions{Resources: jobMetadata.Resources, Secrets: jobSecrets}
+	suite.mockExecutionBackend.On("ExecuteJob", jobMetadata.ImageName, jobArgs, jobOptions).Return(jobExecutionID, nil).Once()
 
-	executedJobName, err := suite.testExecutioner.Execute(jobsExecutionAuditLog, jobName, jobArgs)
+	executedJobName, err := suite.testExecutioner.Execute(jobsExecutionAuditLog, jobName, jobArgs, Limits{}, Provenance{})
 	assert.NoError(t, err)
 
 	suite.mockMetadataStore.AssertExpectations(t)
 	suite.mockSecretsStore.AssertExpectations(t)
-	suite.mockKubeClient.AssertExpectations(t)
+	suite.mockExecutionBackend.AssertExpectations(t)
 
 	assert.Equal(t, jobExecutionID, executedJobName)
 	assert.Equal(t, jobsExecutionAuditLog.JobName, jobName)
+
+	auditedArgs, err := utility.DeserializeMap(jobsExecutionAuditLog.JobArgs)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"argOne": "sample-arg", "secretOne": utility.RedactedSecretValue}, auditedArgs)
+}
+
+func (suite *ExecutionerTestSuite) TestJobExecutionWithLimits() {
+	t := suite.T()
+
+	procTimeoutSeconds := int64(3600)
+	procRetries := int32(2)
+	jobMetadata := metadata.Metadata{ImageName: "img", TimeoutSeconds: &procTimeoutSeconds, Retries: &procRetries}
+	suite.mockMetadataStore.On("GetJobMetadata", "any-job").Return(&jobMetadata, nil).Once()
+	suite.mockSecretsStore.On("GetJobSecrets", "any-job").Return(map[string]string{}, nil).Once()
+
+	timeoutSeconds := int64(60)
+	jobOptions := backend.JobOptions{ActiveDeadlineSeconds: &timeoutSeconds, BackoffLimit: &procRetries, Secrets: map[string]string{}}
+	suite.mockExecutionBackend.On("ExecuteJob", "img", map[string]string{}, jobOptions).Return("proctor-ipsum-lorem", nil).Once()
+
+	_, err := suite.testExecutioner.Execute(&postgres.JobsExecutionAuditLog{}, "any-job", map[string]string{}, Limits{TimeoutSeconds: &timeoutSeconds}, Provenance{})
+	assert.NoError(t, err)
+
+	suite.mockExecutionBackend.AssertExpectations(t)
+}
+
+func (suite *ExecutionerTestSuite) TestJobExecutionWithProvenance() {
+	t := suite.T()
+
+	suite.mockMetadataStore.On("GetJobMetadata", "any-job").Return(&metadata.Metadata{ImageName: "img"}, nil).Once()
+	suite.mockSecretsStore.On("GetJobSecrets", "any-job").Return(map[string]string{}, nil).Once()
+
+	provenance := Provenance{Labels: map[string]string{"ticket": "OPS-123"}, Reason: "Clearing the stuck payments queue"}
+	jobOptions := backend.JobOptions{Secrets: map[string]string{}, Labels: provenance.Labels, Reason: provenance.Reason}
+	suite.mockExecutionBackend.On("ExecuteJob", "img", map[string]string{}, jobOptions).Return("proctor-ipsum-lorem", nil).Once()
+
+	jobsExecutionAuditLog := &postgres.JobsExecutionAuditLog{}
+	_, err := suite.testExecutioner.Execute(jobsExecutionAuditLog, "any-job", map[string]string{}, Limits{}, provenance)
+	assert.NoError(t, err)
+
+	suite.mockExecutionBackend.AssertExpectations(t)
+	assert.Equal(t, "{\"ticket\":\"OPS-123\"}", jobsExecutionAuditLog.Labels)
+	assert.Equal(t, provenance.Reason, jobsExecutionAuditLog.Reason)
+}
+
+func (suite *ExecutionerTestSuite) TestJobExecutionWithPodSpec() {
+	t := suite.T()
+	os.Setenv("PROCTOR_KUBE_JOB_ALLOWED_CONFIG_MAPS", "ca-bundle")
+	defer os.Setenv("PROCTOR_KUBE_JOB_ALLOWED_CONFIG_MAPS", "")
+
+	podSpec := pod.Spec{Volumes: []pod.Volume{{Name: "ca-bundle", MountPath: "/etc/ssl/certs", ConfigMap: "ca-bundle"}}}
+	suite.mockMetadataStore.On("GetJobMetadata", "any-job").Return(&metadata.Metadata{ImageName: "img", Spec: podSpec}, nil).Once()
+	suite.mockSecretsStore.On("GetJobSecrets", "any-job").Return(map[string]string{}, nil).Once()
+
+	jobOptions := backend.JobOptions{Secrets: map[string]string{}, Pod: podSpec}
+	suite.mockExecutionBackend.On("ExecuteJob", "img", map[string]string{}, jobOptions).Return("proctor-ipsum-lorem", nil).Once()
+
+	_, err := suite.testExecutioner.Execute(&postgres.JobsExecutionAuditLog{}, "any-job", map[string]string{}, Limits{}, Provenance{})
+	assert.NoError(t, err)
+
+	suite.mockExecutionBackend.AssertExpectations(t)
+}
+
+func (suite *ExecutionerTestSuite) TestJobExecutionWithPodSpecNoLongerAllowed() {
+	t := suite.T()
+
+	podSpec := pod.Spec{ServiceAccountName: "reports"}
+	suite.mockMetadataStore.On("GetJobMetadata", "any-job").Return(&metadata.Metadata{ImageName: "img", Spec: podSpec}, nil).Once()
+
+	_, err := suite.testExecutioner.Execute(&postgres.JobsExecutionAuditLog{}, "any-job", map[string]string{}, Limits{}, Provenance{})
+	assert.EqualError(t, err, "Pod spec of job: any-job is no longer allowed. Error: service account reports is not allowed")
+
+	suite.mockExecutionBackend.AssertNotCalled(t, "ExecuteJob", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ExecutionerTestSuite) TestJobExecutionRequiringApproval() {
+	t := suite.T()
+
+	suite.mockMetadataStore.On("GetJobMetadata", "any-job").Return(&metadata.Metadata{ImageName: "img", RequiresApproval: true}, nil).Once()
+
+	_, err := suite.testExecutioner.Execute(&postgres.JobsExecutionAuditLog{}, "any-job", map[string]string{}, Limits{}, Provenance{})
+	assert.EqualError(t, err, "Job: any-job requires approval to execute")
+
+	suite.mockExecutionBackend.AssertNotCalled(t, "ExecuteJob", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ExecutionerTestSuite) TestApprovedJobExecution() {
+	t := suite.T()
+
+	suite.mockMetadataStore.On("GetJobMetadata", "any-job").Return(&metadata.Metadata{ImageName: "img", RequiresApproval: true}, nil).Once()
+	suite.mockSecretsStore.On("GetJobSecrets", "any-job").Return(map[string]string{}, nil).Once()
+	suite.mockExecutionBackend.On("ExecuteJob", "img", map[string]string{}, backend.JobOptions{Secrets: map[string]string{}}).Return("proctor-ipsum-lorem", nil).Once()
+
+	jobExecutionID, err := suite.testExecutioner.Execute(&postgres.JobsExecutionAuditLog{ApprovedBy: "approver@example.com"}, "any-job", map[string]string{}, Limits{}, Provenance{})
+	assert.NoError(t, err)
+	assert.Equal(t, "proctor-ipsum-lorem", jobExecutionID)
+
+	suite.mockExecutionBackend.AssertExpectations(t)
+}
+
+func (suite *ExecutionerTestSuite) TestJobExecutionWithArgDefaults() {
+	t := suite.T()
+
+	jobMetadata := metadata.Metadata{
+		ImageName: "img",
+		EnvVars:   env.Vars{Args: []env.VarMetadata{{Name: "COUNT", Default: "10"}, {Name: "ENV"}}},
+	}
+	suite.mockMetadataStore.On("GetJobMetadata", "any-job").Return(&jobMetadata, nil).Once()
+	suite.mockSecretsStore.On("GetJobSecrets", "any-job").Return(map[string]string{}, nil).Once()
+
+	envVarsForJob := map[string]string{"COUNT": "10", "ENV": "staging"}
+	suite.mockExecutionBackend.On("ExecuteJob", "img", envVarsForJob, backend.JobOptions{Secrets: map[string]string{}}).Return("proctor-ipsum-lorem", nil).Once()
+
+	_, err := suite.testExecutioner.Execute(&postgres.JobsExecutionAuditLog{}, "any-job", map[string]string{"ENV": "staging"}, Limits{}, Provenance{})
+	assert.NoError(t, err)
+
+	suite.mockExecutionBackend.AssertExpectations(t)
 }
 
 func (suite *ExecutionerTestSuite) TestJobExecutionOnImageLookupFailure() {
@@ -68,7 +189,7 @@ func (suite *ExecutionerTestSuite) TestJobExecutionOnImageLookupFailure() {
 
 	suite.mockMetadataStore.On("GetJobMetadata", mock.Anything).Return(&metadata.Metadata{}, errors.New("image-fetch-error")).Once()
 
-	_, err := suite.testExecutioner.Execute(&postgres.JobsExecutionAuditLog{}, "any-job", map[string]string{})
+	_, err := suite.testExecutioner.Execute(&postgres.JobsExecutionAuditLog{}, "any-job", map[string]string{}, Limits{}, Provenance{})
 	assert.EqualError(t, err, "Error finding image for job: any-job. Error: image-fetch-error")
 }
 
@@ -80,22 +201,42 @@ func (suite *ExecutionerTestSuite) TestJobExecutionOnSecretsFetchFailure() {
 
 	suite.mockSecretsStore.On("GetJobSecrets", mock.Anything).Return(map[string]string{}, errors.New("secret-store-error")).Once()
 
-	_, err := suite.testExecutioner.Execute(&postgres.JobsExecutionAuditLog{}, "any-job", map[string]string{})
+	_, err := suite.testExecutioner.Execute(&postgres.JobsExecutionAuditLog{}, "any-job", map[string]string{}, Limits{}, Provenance{})
 	assert.EqualError(t, err, "Error retrieving secrets for job: any-job. Error: secret-store-error")
 }
 
-func (suite *ExecutionerTestSuite) TestJobExecutionOnKubernetesJobExecutionFailure() {
+func (suite *ExecutionerTestSuite) TestJobExecutionOnBackendFailure() {
 	t := suite.T()
 
 	jobMetadata := metadata.Metadata{ImageName: "img"}
 	suite.mockMetadataStore.On("GetJobMetadata", mock.Anything).Return(&jobMetadata, nil).Once()
 
 	suite.mockSecretsStore.On("GetJobSecrets", mock.Anything).Return(map[string]string{}, nil).Once()
-	suite.mockKubeClient.On("ExecuteJob", mock.Anything, mock.Anything).Return("", errors.New("kube-client-error")).Once()
+	suite.mockExecutionBackend.On("ExecuteJob", mock.Anything, mock.Anything, mock.Anything).Return("", errors.New("backend-error")).Once()
+
+	_, err := suite.testExecutioner.Execute(&postgres.JobsExecutionAuditLog{}, "any-job", map[string]string{}, Limits{}, Provenance{})
+
+	assert.EqualError(t, err, "Error submitting job: any-job. Error: backend-error")
+}
+
+func (suite *ExecutionerTestSuite) TestJobCancellation() {
+	t := suite.T()
+
+	suite.mockExecutionBackend.On("CancelJob", "proctor-ipsum-lorem").Return(nil).Once()
+
+	err := suite.testExecutioner.Cancel("proctor-ipsum-lorem")
+	assert.NoError(t, err)
+
+	suite.mockExecutionBackend.AssertExpectations(t)
+}
+
+func (suite *ExecutionerTestSuite) TestJobCancellationOnBackendFailure() {
+	t := suite.T()
 
-	_, err := suite.testExecutioner.Execute(&postgres.JobsExecutionAuditLog{}, "any-job", map[string]string{})
+	suite.mockExecutionBackend.On("CancelJob", "proctor-ipsum-lorem").Return(errors.New("backend-error")).Once()
 
-	assert.EqualError(t, err, "Error submitting job to kube: any-job. Error: kube-client-error")
+	err := suite.testExecutioner.Cancel("proctor-ipsum-lorem")
+	assert.EqualError(t, err, "Error cancelling job: proctor-ipsum-lorem. Error: backend-error")
 }
 
 func TestExecutionerTestSuite(t *testing.T) {
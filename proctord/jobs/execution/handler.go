@@ -1,37 +1,55 @@
 package execution
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"github.com/getsentry/raven-go"
+	"io"
 	"proctor/proctord/audit"
+	"proctor/proctord/auth"
+	"proctor/proctord/config"
+	"proctor/proctord/jobs/callback"
+	"proctor/proctord/jobs/metadata"
 	"proctor/proctord/logger"
 	"proctor/proctord/storage"
 	"proctor/proctord/storage/postgres"
 	"proctor/proctord/utility"
 	"github.com/gorilla/mux"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type executionHandler struct {
-	auditor     audit.Auditor
-	store       storage.Store
-	executioner Executioner
+	auditor       audit.Auditor
+	store         storage.Store
+	executioner   Executioner
+	metadataStore metadata.Store
+	authorizer    auth.Authorizer
 }
 
 type ExecutionHandler interface {
 	Handle() http.HandlerFunc
 	Status() http.HandlerFunc
-	sendStatusToCaller(remoteCallerURL, jobExecutionID string)
+	Detail() http.HandlerFunc
+	List() http.HandlerFunc
+	Cancel() http.HandlerFunc
+	Rerun() http.HandlerFunc
+	Callbacks() http.HandlerFunc
+	Approvals() http.HandlerFunc
+	Approve() http.HandlerFunc
+	Reject() http.HandlerFunc
 }
 
-func NewExecutionHandler(auditor audit.Auditor, store storage.Store, executioner Executioner) ExecutionHandler {
+func NewExecutionHandler(auditor audit.Auditor, store storage.Store, executioner Executioner, metadataStore metadata.Store, authorizer auth.Authorizer) ExecutionHandler {
 	return &executionHandler{
-		auditor:     auditor,
-		store:       store,
-		executioner: executioner,
+		auditor:       auditor,
+		store:         store,
+		executioner:   executioner,
+		metadataStore: metadataStore,
+		authorizer:    authorizer,
 	}
 }
 
@@ -57,13 +75,210 @@ func (handler *executionHandler) Status() http.HandlerFunc {
 	}
 }
 
+func (handler *executionHandler) Detail() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		jobExecutionID := mux.Vars(req)["name"]
+		user, _ := auth.FromContext(req.Context())
+
+		jobsExecutionAuditLog, _, found := handler.authorizedJobsExecutionAuditLog(w, user, jobExecutionID, "view")
+		if !found {
+			return
+		}
+
+		detailInJSON, err := json.Marshal(NewDetail(jobsExecutionAuditLog))
+		if err != nil {
+			logger.Error(fmt.Sprintf("User %s: Error marshalling job execution: %s", user.Email, jobExecutionID), err.Error())
+			raven.CaptureError(err, map[string]string{"user_email": user.Email, "job_id": jobExecutionID})
+
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(detailInJSON)
+	}
+}
+
+// List leaves out executions of procs the user isn't authorized for, so a page can be shorter than the limit
+func (handler *executionHandler) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		user, _ := auth.FromContext(req.Context())
+
+		filter, err := ParseHistoryFilter(req.URL.Query())
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf("%s: %s", utility.InvalidHistoryFilterClientError, err.Error())))
+			return
+		}
+
+		jobsExecutionAuditLogs, err := handler.store.GetJobsExecutionAuditLogs(filter.storageFilter())
+		if err != nil {
+			logger.Error(fmt.Sprintf("User %s: Error fetching job executions", user.Email), err.Error())
+			raven.CaptureError(err, map[string]string{"user_email": user.Email})
+
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+
+		history := History{Executions: []Detail{}}
+		if len(jobsExecutionAuditLogs) > filter.Limit {
+			jobsExecutionAuditLogs = jobsExecutionAuditLogs[:filter.Limit]
+			history.NextCursor = strconv.FormatInt(jobsExecutionAuditLogs[filter.Limit-1].ID, 10)
+		}
+
+		authorizedJobs := make(map[string]bool)
+		for _, jobsExecutionAuditLog := range jobsExecutionAuditLogs {
+			authorized, found := authorizedJobs[jobsExecutionAuditLog.JobName]
+			if !found {
+				authorized, err = handler.isAuthorizedForJob(user, jobsExecutionAuditLog.JobName)
+				if err != nil {
+					logger.Error(fmt.Sprintf("%s: User %s: Error authorizing user: ", jobsExecutionAuditLog.JobName, user.Email), err.Error())
+					raven.CaptureError(err, map[string]string{"user_email": user.Email, "job_name": jobsExecutionAuditLog.JobName})
+
+					w.WriteHeader(http.StatusInternalServerError)
+					w.Write([]byte(utility.ServerError))
+					return
+				}
+				authorizedJobs[jobsExecutionAuditLog.JobName] = authorized
+			}
+
+			if authorized {
+				history.Executions = append(history.Executions, NewDetail(jobsExecutionAuditLog))
+			}
+		}
+
+		historyInJSON, err := json.Marshal(history)
+		if err != nil {
+			logger.Error(fmt.Sprintf("User %s: Error marshalling job executions", user.Email), err.Error())
+			raven.CaptureError(err, map[string]string{"user_email": user.Email})
+
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(historyInJSON)
+	}
+}
+
+// isAuthorizedForJob treats procs which no longer exist like procs without authorized groups
+func (handler *executionHandler) isAuthorizedForJob(user auth.User, jobName string) (bool, error) {
+	jobMetadata, err := handler.metadataStore.GetJobMetadata(jobName)
+	if err != nil {
+		if err.Error() == "redigo: nil returned" {
+			return handler.authorizer.Authorize(user, nil)
+		}
+		return false, err
+	}
+	return handler.authorizer.Authorize(user, jobMetadata.AuthorizedGroups)
+}
+
+// authorizedJobsExecutionAuditLog responds with an error and returns false unless the user is authorized for the proc of the execution
+func (handler *executionHandler) authorizedJobsExecutionAuditLog(w http.ResponseWriter, user auth.User, jobExecutionID, action string) (postgres.JobsExecutionAuditLog, *metadata.Metadata, bool) {
+	jobsExecutionAuditLog, err := handler.store.GetJobsExecutionAuditLog(jobExecutionID)
+	if err != nil {
+		logger.Error(fmt.Sprintf("User %s: Error fetching job execution: %s", user.Email, jobExecutionID), err.Error())
+		raven.CaptureError(err, map[string]string{"user_email": user.Email, "job_id": jobExecutionID})
+
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(utility.ServerError))
+		return postgres.JobsExecutionAuditLog{}, nil, false
+	}
+	if len(jobsExecutionAuditLog) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(utility.JobNotFoundError))
+		return postgres.JobsExecutionAuditLog{}, nil, false
+	}
+	jobName := jobsExecutionAuditLog[0].JobName
+
+	jobMetadata, err := handler.metadataStore.GetJobMetadata(jobName)
+	if err != nil {
+		if err.Error() == "redigo: nil returned" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(utility.NonExistentProcClientError))
+			return postgres.JobsExecutionAuditLog{}, nil, false
+		}
+		logger.Error(fmt.Sprintf("%s: User %s: Error fetching metadata: ", jobName, user.Email), err.Error())
+		raven.CaptureError(err, map[string]string{"user_email": user.Email, "job_name": jobName})
+
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(utility.ServerError))
+		return postgres.JobsExecutionAuditLog{}, nil, false
+	}
+
+	authorized, err := handler.authorizer.Authorize(user, jobMetadata.AuthorizedGroups)
+	if err != nil {
+		logger.Error(fmt.Sprintf("%s: User %s: Error authorizing user: ", jobName, user.Email), err.Error())
+		raven.CaptureError(err, map[string]string{"user_email": user.Email, "job_name": jobName})
+
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(utility.ServerError))
+		return postgres.JobsExecutionAuditLog{}, nil, false
+	}
+	if !authorized {
+		logger.Info(fmt.Sprintf("%s: User %s: Not authorized to %s job: %s", jobName, user.Email, action, jobExecutionID))
+
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(utility.UnauthorizedProcClientError))
+		return postgres.JobsExecutionAuditLog{}, nil, false
+	}
+
+	return jobsExecutionAuditLog[0], jobMetadata, true
+}
+
+func (handler *executionHandler) Cancel() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		jobExecutionID := mux.Vars(req)["name"]
+		user, _ := auth.FromContext(req.Context())
+
+		jobsExecutionAuditLog, _, found := handler.authorizedJobsExecutionAuditLog(w, user, jobExecutionID, "cancel")
+		if !found {
+			return
+		}
+		jobName := jobsExecutionAuditLog.JobName
+
+		if IsFinished(jobsExecutionAuditLog.JobExecutionStatus) {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(utility.JobAlreadyFinishedClientError))
+			return
+		}
+
+		err := handler.executioner.Cancel(jobExecutionID)
+		if err != nil {
+			logger.Error(fmt.Sprintf("%s: User %s: Error cancelling job: ", jobName, user.Email), err.Error())
+			raven.CaptureError(err, map[string]string{"user_email": user.Email, "job_id": jobExecutionID})
+
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+
+		_, err = handler.store.CancelJobsExecution(jobExecutionID, user.Email)
+		if err != nil {
+			logger.Error(fmt.Sprintf("%s: User %s: Error recording cancellation: ", jobName, user.Email), err.Error())
+			raven.CaptureError(err, map[string]string{"user_email": user.Email, "job_id": jobExecutionID})
+
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fmt.Sprintf("{ \"name\":\"%s\", \"status\":\"%s\" }", jobExecutionID, utility.JobCancelled)))
+	}
+}
+
 func (handler *executionHandler) Handle() http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
 		jobsExecutionAuditLog := &postgres.JobsExecutionAuditLog{
 			JobExecutionStatus: "WAITING",
 		}
 
-		userEmail := req.Header.Get(utility.UserEmailHeaderKey)
+		user, _ := auth.FromContext(req.Context())
+		userEmail := user.Email
 		jobsExecutionAuditLog.UserEmail = userEmail
 
 		var job Job
@@ -82,71 +297,353 @@ func (handler *executionHandler) Handle() http.HandlerFunc {
 
 			return
 		}
-		jobExecutionID, err := handler.executioner.Execute(jobsExecutionAuditLog, job.Name, job.Args)
+		jobsExecutionAuditLog.JobName = job.Name
+
+		jobMetadata, err := handler.metadataStore.GetJobMetadata(job.Name)
 		if err != nil {
-			logger.Error(fmt.Sprintf("%s: User %s: Error executing job: ", job.Name, userEmail), err.Error())
+			if err.Error() == "redigo: nil returned" {
+				logger.Error(fmt.Sprintf("User: %s: Client provided non existent proc name: ", userEmail), job.Name)
+
+				jobsExecutionAuditLog.Errors = fmt.Sprintf("Non existent proc: %s", job.Name)
+				jobsExecutionAuditLog.JobSubmissionStatus = utility.JobSubmissionClientError
+				go handler.auditor.JobsExecutionAndStatus(jobsExecutionAuditLog)
+
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(utility.NonExistentProcClientError))
+				return
+			}
+			logger.Error(fmt.Sprintf("%s: User %s: Error fetching metadata: ", job.Name, userEmail), err.Error())
 			raven.CaptureError(err, map[string]string{"user_email": userEmail, "job_name": job.Name})
 
-			jobsExecutionAuditLog.Errors = fmt.Sprintf("Error executing job: %s", err.Error())
+			jobsExecutionAuditLog.Errors = fmt.Sprintf("Error fetching metadata: %s", err.Error())
 			jobsExecutionAuditLog.JobSubmissionStatus = utility.JobSubmissionServerError
 			go handler.auditor.JobsExecutionAndStatus(jobsExecutionAuditLog)
 
 			w.WriteHeader(http.StatusInternalServerError)
 			w.Write([]byte(utility.ServerError))
+			return
+		}
+
+		authorized, err := handler.authorizer.Authorize(user, jobMetadata.AuthorizedGroups)
+		if err != nil {
+			logger.Error(fmt.Sprintf("%s: User %s: Error authorizing user: ", job.Name, userEmail), err.Error())
+			raven.CaptureError(err, map[string]string{"user_email": userEmail, "job_name": job.Name})
+
+			jobsExecutionAuditLog.Errors = fmt.Sprintf("Error authorizing user: %s", err.Error())
+			jobsExecutionAuditLog.JobSubmissionStatus = utility.JobSubmissionServerError
+			go handler.auditor.JobsExecutionAndStatus(jobsExecutionAuditLog)
 
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
 			return
 		}
+		if !authorized {
+			logger.Info(fmt.Sprintf("%s: User %s: Not authorized to execute job", job.Name, userEmail))
 
-		w.WriteHeader(http.StatusCreated)
-		w.Write([]byte(fmt.Sprintf("{ \"name\":\"%s\" }", jobExecutionID)))
+			jobsExecutionAuditLog.Errors = fmt.Sprintf("User not in authorized groups: %v", jobMetadata.AuthorizedGroups)
+			jobsExecutionAuditLog.JobSubmissionStatus = utility.JobSubmissionForbidden
+			go handler.auditor.JobsExecutionAndStatus(jobsExecutionAuditLog)
+
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(utility.UnauthorizedProcClientError))
+			return
+		}
 
-		remoteCallerURL := job.CallbackURL
-		go handler.postJobExecute(jobsExecutionAuditLog, remoteCallerURL, jobExecutionID)
-		return
+		idempotencyKey := req.Header.Get(utility.IdempotencyKeyHeaderKey)
+		if idempotencyKey == "" {
+			handler.submit(w, user, jobMetadata, job, req.Header.Get(utility.CallbackSecretHeaderKey), jobsExecutionAuditLog)
+			return
+		}
+
+		if !handler.claimIdempotencyKey(w, userEmail, idempotencyKey, job.Name) {
+			return
+		}
+
+		jobExecutionID, approvalID := handler.submit(w, user, jobMetadata, job, req.Header.Get(utility.CallbackSecretHeaderKey), jobsExecutionAuditLog)
+		switch {
+		case jobExecutionID != "":
+			err = handler.store.UpdateIdempotencyKeyExecutionID(userEmail, idempotencyKey, jobExecutionID)
+		case approvalID != "":
+			err = handler.store.UpdateIdempotencyKeyApprovalID(userEmail, idempotencyKey, approvalID)
+		default:
+			// nothing was submitted, a retry with the key gets to submit
+			err = handler.store.RemoveIdempotencyKey(userEmail, idempotencyKey)
+		}
+		if err != nil {
+			logger.Error(fmt.Sprintf("%s: User %s: Error saving idempotency key: %s", job.Name, userEmail, idempotencyKey), err.Error())
+			raven.CaptureError(err, map[string]string{"user_email": userEmail, "job_name": job.Name})
+		}
 	}
 }
 
-func (handler *executionHandler) postJobExecute(jobsExecutionAuditLog *postgres.JobsExecutionAuditLog, remoteCallerURL, jobExecutionID string) {
-	handler.auditor.JobsExecutionAndStatus(jobsExecutionAuditLog)
-	if remoteCallerURL != "" {
-		handler.sendStatusToCaller(remoteCallerURL, jobExecutionID)
+// claimIdempotencyKey returns true when the request is the first to use the key within the window,
+// otherwise it responds with the execution or the approval request of the earlier request
+func (handler *executionHandler) claimIdempotencyKey(w http.ResponseWriter, userEmail, idempotencyKey, jobName string) bool {
+	claimed, err := handler.store.ClaimIdempotencyKey(&postgres.IdempotencyKey{
+		UserEmail: userEmail,
+		Key:       idempotencyKey,
+		JobName:   jobName,
+		ExpiresAt: time.Now().Add(time.Duration(config.IdempotencyKeyTTLInMins()) * time.Minute),
+	})
+	if err == nil && claimed {
+		return true
+	}
+
+	var idempotencyKeys []postgres.IdempotencyKey
+	if err == nil {
+		idempotencyKeys, err = handler.store.GetIdempotencyKey(userEmail, idempotencyKey)
+	}
+	if err != nil {
+		logger.Error(fmt.Sprintf("%s: User %s: Error claiming idempotency key: %s", jobName, userEmail, idempotencyKey), err.Error())
+		raven.CaptureError(err, map[string]string{"user_email": userEmail, "job_name": jobName})
+
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(utility.ServerError))
+		return false
+	}
+
+	if len(idempotencyKeys) > 0 && idempotencyKeys[0].JobName != jobName {
+		logger.Info(fmt.Sprintf("%s: User %s: Idempotency key %s was used for proc: %s", jobName, userEmail, idempotencyKey, idempotencyKeys[0].JobName))
+
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(utility.IdempotencyKeyReusedClientError))
+		return false
+	}
+
+	// the earlier request is yet to submit its execution, or failed to and released the key meanwhile
+	if len(idempotencyKeys) == 0 || (!idempotencyKeys[0].ExecutionID.Valid && !idempotencyKeys[0].ApprovalID.Valid) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(utility.IdempotencyKeyInProgressClientError))
+		return false
+	}
+
+	if !idempotencyKeys[0].ExecutionID.Valid {
+		approvalID := idempotencyKeys[0].ApprovalID.String
+		logger.Info(fmt.Sprintf("%s: User %s: Replaying approval request %s for idempotency key: %s", jobName, userEmail, approvalID, idempotencyKey))
+
+		w.Header().Set(utility.IdempotentReplayedHeaderKey, "true")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(fmt.Sprintf("{ \"id\":\"%s\", \"status\":\"%s\" }", approvalID, utility.ApprovalPending)))
+		return false
 	}
+
+	jobExecutionID := idempotencyKeys[0].ExecutionID.String
+	logger.Info(fmt.Sprintf("%s: User %s: Replaying execution %s for idempotency key: %s", jobName, userEmail, jobExecutionID, idempotencyKey))
+
+	w.Header().Set(utility.IdempotentReplayedHeaderKey, "true")
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(fmt.Sprintf("{ \"name\":\"%s\" }", jobExecutionID)))
+	return false
 }
 
-func (handler *executionHandler) sendStatusToCaller(remoteCallerURL, jobExecutionID string) {
-	status := utility.JobWaiting
+// Rerun submits a new execution of the proc of a previous one, with the args, labels and reason of the previous
+// execution overridden by those in the request body. Secrets are fetched afresh like for any other execution
+func (handler *executionHandler) Rerun() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		parentExecutionID := mux.Vars(req)["name"]
+		user, _ := auth.FromContext(req.Context())
 
-	for {
-		jobExecutionStatus, _ := handler.store.GetJobExecutionStatus(jobExecutionID)
-		if jobExecutionStatus == "" {
-			status = utility.JobNotFound
-			break
+		var job Job
+		err := json.NewDecoder(req.Body).Decode(&job)
+		defer req.Body.Close()
+		if err != nil && err != io.EOF {
+			logger.Error(fmt.Sprintf("User: %s: Error parsing request body", user.Email), err.Error())
+
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(utility.ClientError))
+			return
+		}
+
+		parentAuditLog, jobMetadata, found := handler.authorizedJobsExecutionAuditLog(w, user, parentExecutionID, "rerun")
+		if !found {
+			return
+		}
+
+		parentArgs, err := utility.DeserializeMap(parentAuditLog.JobArgs)
+		if err != nil {
+			logger.Error(fmt.Sprintf("User %s: Error decoding job args of execution: %s", user.Email, parentExecutionID), err.Error())
+			raven.CaptureError(err, map[string]string{"user_email": user.Email, "job_id": parentExecutionID})
+
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+
+		job.Name = parentAuditLog.JobName
+		job.Args = utility.MergeMaps(rerunArgs(jobMetadata, parentArgs), job.Args)
+		if parentLabels := decodeLabels(parentAuditLog); len(parentLabels) > 0 {
+			job.Labels = utility.MergeMaps(parentLabels, job.Labels)
+		}
+		if job.Reason == "" {
+			job.Reason = parentAuditLog.Reason
+		}
+
+		jobsExecutionAuditLog := &postgres.JobsExecutionAuditLog{
+			JobName:            job.Name,
+			UserEmail:          user.Email,
+			JobExecutionStatus: "WAITING",
+			ParentExecutionID:  postgres.StringToSQLString(parentExecutionID),
 		}
+		handler.submit(w, user, jobMetadata, job, req.Header.Get(utility.CallbackSecretHeaderKey), jobsExecutionAuditLog)
+	}
+}
 
-		if jobExecutionStatus == utility.JobSucceeded || jobExecutionStatus == utility.JobFailed {
-			status = jobExecutionStatus
-			break
+// rerunArgs keeps only the args the proc declares, audited args also carry redacted secrets
+func rerunArgs(jobMetadata *metadata.Metadata, auditedArgs map[string]string) map[string]string {
+	args := make(map[string]string)
+	for _, arg := range jobMetadata.EnvVars.Args {
+		if value, ok := auditedArgs[arg.Name]; ok {
+			args[arg.Name] = value
 		}
+	}
+	return args
+}
+
+// submit responds to the request and returns the ID of the execution, or of the approval request holding it
+// for procs requiring approval until an approver submits it. Both are empty when nothing was submitted
+func (handler *executionHandler) submit(w http.ResponseWriter, user auth.User, jobMetadata *metadata.Metadata, job Job, callbackSecret string, jobsExecutionAuditLog *postgres.JobsExecutionAuditLog) (string, string) {
+	userEmail := user.Email
 
-		time.Sleep(1 * time.Second)
+	if job.CallbackURL != "" && !isValidCallbackURL(job.CallbackURL) {
+		logger.Info(fmt.Sprintf("%s: User %s: Invalid callback url: %s", job.Name, userEmail, job.CallbackURL))
+
+		jobsExecutionAuditLog.Errors = fmt.Sprintf("Invalid callback url: %s", job.CallbackURL)
+		jobsExecutionAuditLog.JobSubmissionStatus = utility.JobSubmissionClientError
+		go handler.auditor.JobsExecutionAndStatus(jobsExecutionAuditLog)
+
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(utility.InvalidCallbackURLClientError))
+		return "", ""
+	}
+
+	argProblems := jobMetadata.EnvVars.ValidateArgs(job.Args)
+	if len(argProblems) > 0 {
+		logger.Info(fmt.Sprintf("%s: User %s: Invalid args: %v", job.Name, userEmail, argProblems))
+
+		jobsExecutionAuditLog.Errors = fmt.Sprintf("Invalid args: %s", strings.Join(argProblems, ", "))
+		jobsExecutionAuditLog.JobSubmissionStatus = utility.JobSubmissionClientError
+		go handler.auditor.JobsExecutionAndStatus(jobsExecutionAuditLog)
+
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf("%s:\n%s", utility.InvalidArgsClientError, strings.Join(argProblems, "\n"))))
+		return "", ""
+	}
+
+	err := job.Limits.Validate(jobMetadata)
+	if err != nil {
+		logger.Info(fmt.Sprintf("%s: User %s: Invalid execution limits: %s", job.Name, userEmail, err.Error()))
+
+		jobsExecutionAuditLog.Errors = fmt.Sprintf("Invalid execution limits: %s", err.Error())
+		jobsExecutionAuditLog.JobSubmissionStatus = utility.JobSubmissionClientError
+		go handler.auditor.JobsExecutionAndStatus(jobsExecutionAuditLog)
+
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf("%s: %s", utility.InvalidExecutionLimitsClientError, err.Error())))
+		return "", ""
 	}
 
-	value := map[string]string{"name": jobExecutionID, "status": status}
-	jsonValue, err := json.Marshal(value)
+	err = job.Provenance.Validate(jobMetadata)
 	if err != nil {
-		logger.Error(fmt.Sprintf("StatusCallback: Error parsing %#v", value), err.Error())
-		raven.CaptureError(err, nil)
+		logger.Info(fmt.Sprintf("%s: User %s: Invalid execution labels or reason: %s", job.Name, userEmail, err.Error()))
+
+		jobsExecutionAuditLog.Errors = fmt.Sprintf("Invalid execution labels or reason: %s", err.Error())
+		jobsExecutionAuditLog.JobSubmissionStatus = utility.JobSubmissionClientError
+		go handler.auditor.JobsExecutionAndStatus(jobsExecutionAuditLog)
+
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf("%s: %s", utility.InvalidProvenanceClientError, err.Error())))
+		return "", ""
+	}
 
-		return
+	if jobMetadata.RequiresApproval && jobsExecutionAuditLog.ApprovedBy == "" {
+		return "", handler.requestApproval(w, user, job, callbackSecret, jobsExecutionAuditLog)
 	}
 
-	_, err = http.Post(remoteCallerURL, "application/json", bytes.NewBuffer(jsonValue))
+	jobExecutionID, err := handler.executioner.Execute(jobsExecutionAuditLog, job.Name, job.Args, job.Limits, job.Provenance)
 	if err != nil {
-		logger.Error("StatusCallback: Error sending request to callback url", err.Error())
-		raven.CaptureError(err, nil)
+		logger.Error(fmt.Sprintf("%s: User %s: Error executing job: ", job.Name, userEmail), err.Error())
+		raven.CaptureError(err, map[string]string{"user_email": userEmail, "job_name": job.Name})
 
-		return
+		jobsExecutionAuditLog.Errors = fmt.Sprintf("Error executing job: %s", err.Error())
+		jobsExecutionAuditLog.JobSubmissionStatus = utility.JobSubmissionServerError
+		go handler.auditor.JobsExecutionAndStatus(jobsExecutionAuditLog)
+
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(utility.ServerError))
+
+		return "", ""
+	}
+
+	// audited before responding so that the execution is known to logs and status lookups right away
+	handler.auditor.JobsExecution(jobsExecutionAuditLog)
+
+	if job.CallbackURL != "" {
+		statusCallback := &postgres.StatusCallback{
+			ExecutionID: jobExecutionID,
+			URL:         job.CallbackURL,
+			Secret:      callbackSecret,
+		}
+		err = handler.store.InsertStatusCallback(statusCallback)
+		if err != nil {
+			logger.Error(fmt.Sprintf("%s: User %s: Error saving status callback of execution: %s", job.Name, userEmail, jobExecutionID), err.Error())
+			raven.CaptureError(err, map[string]string{"user_email": userEmail, "job_name": job.Name, "job_id": jobExecutionID})
+		}
 	}
 
-	return
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(fmt.Sprintf("{ \"name\":\"%s\" }", jobExecutionID)))
+	return jobExecutionID, ""
+}
+
+func isValidCallbackURL(callbackURL string) bool {
+	parsedURL, err := url.Parse(callbackURL)
+	if err != nil {
+		return false
+	}
+	return (parsedURL.Scheme == "http" || parsedURL.Scheme == "https") && parsedURL.Host != ""
+}
+
+func (handler *executionHandler) Callbacks() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		jobExecutionID := mux.Vars(req)["name"]
+		user, _ := auth.FromContext(req.Context())
+
+		_, _, found := handler.authorizedJobsExecutionAuditLog(w, user, jobExecutionID, "view")
+		if !found {
+			return
+		}
+
+		statusCallbacks, err := handler.store.GetStatusCallbacks(jobExecutionID)
+		if err != nil {
+			logger.Error(fmt.Sprintf("User %s: Error fetching status callbacks of execution: %s", user.Email, jobExecutionID), err.Error())
+			raven.CaptureError(err, map[string]string{"user_email": user.Email, "job_id": jobExecutionID})
+
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+
+		statusCallbackAttempts, err := handler.store.GetStatusCallbackAttempts(jobExecutionID)
+		if err != nil {
+			logger.Error(fmt.Sprintf("User %s: Error fetching status callback attempts of execution: %s", user.Email, jobExecutionID), err.Error())
+			raven.CaptureError(err, map[string]string{"user_email": user.Email, "job_id": jobExecutionID})
+
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+
+		deliveriesInJSON, err := json.Marshal(callback.NewDeliveries(statusCallbacks, statusCallbackAttempts))
+		if err != nil {
+			logger.Error(fmt.Sprintf("User %s: Error marshalling status callbacks of execution: %s", user.Email, jobExecutionID), err.Error())
+			raven.CaptureError(err, map[string]string{"user_email": user.Email, "job_id": jobExecutionID})
+
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(deliveriesInJSON)
+	}
 }
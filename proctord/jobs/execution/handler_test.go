@@ -2,11 +2,17 @@ package execution
 
 import (
 	"bytes"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"proctor/proctord/audit"
+	"proctor/proctord/auth"
+	"proctor/proctord/jobs/callback"
+	"proctor/proctord/jobs/metadata"
+	"proctor/proctord/jobs/metadata/env"
 	"proctor/proctord/storage"
+	"proctor/proctord/storage/postgres"
 	"proctor/proctord/utility"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
@@ -15,7 +21,9 @@ import (
 	"github.com/urfave/negroni"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
+	"time"
 )
 
 type ExecutionHandlerTestSuite struct {
@@ -23,6 +31,8 @@ type ExecutionHandlerTestSuite struct {
 	mockAuditor          *audit.MockAuditor
 	mockStore            *storage.MockStore
 	mockExecutioner      *MockExecutioner
+	mockMetadataStore    *metadata.MockStore
+	mockAuthorizer       *auth.MockAuthorizer
 	testExecutionHandler ExecutionHandler
 
 	Client     *http.Client
@@ -33,7 +43,9 @@ func (suite *ExecutionHandlerTestSuite) SetupTest() {
 	suite.mockAuditor = &audit.MockAuditor{}
 	suite.mockStore = &storage.MockStore{}
 	suite.mockExecutioner = &MockExecutioner{}
-	suite.testExecutionHandler = NewExecutionHandler(suite.mockAuditor, suite.mockStore, suite.mockExecutioner)
+	suite.mockMetadataStore = &metadata.MockStore{}
+	suite.mockAuthorizer = &auth.MockAuthorizer{}
+	suite.testExecutionHandler = NewExecutionHandler(suite.mockAuditor, suite.mockStore, suite.mockExecutioner, suite.mockMetadataStore, suite.mockAuthorizer)
 
 	suite.Client = &http.Client{}
 	router := mux.NewRouter()
@@ -47,69 +59,280 @@ func (suite *ExecutionHandlerTestSuite) TestSuccessfulJobExecutionHandler() {
 	t := suite.T()
 
 	jobExecutionID := "proctor-ipsum-lorem"
-	statusChan := make(chan bool)
+	remoteCallerURL := "http://example.com/status"
 
-	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		defer req.Body.Close()
+	userEmail := "mrproctor@example.com"
+	job := Job{
+		Name:        "sample-job-name",
+		Args:        map[string]string{"argOne": "sample-arg"},
+		CallbackURL: remoteCallerURL,
+	}
+
+	requestBody, err := json.Marshal(job)
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/execute", bytes.NewReader(requestBody))
+	req.Header.Set(utility.CallbackSecretHeaderKey, "any-secret")
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
+	responseRecorder := httptest.NewRecorder()
+
+	jobMetadata := &metadata.Metadata{Name: job.Name, AuthorizedGroups: []string{"group_one"}, EnvVars: env.Vars{Args: []env.VarMetadata{{Name: "argOne"}}}}
+	suite.mockMetadataStore.On("GetJobMetadata", job.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(true, nil).Once()
+	suite.mockExecutioner.On("Execute", mock.Anything, job.Name, job.Args, job.Limits, job.Provenance).Return(jobExecutionID, nil).Once()
+	suite.mockStore.On("InsertStatusCallback", &postgres.StatusCallback{
+		ExecutionID: jobExecutionID,
+		URL:         remoteCallerURL,
+		Secret:      "any-secret",
+	}).Return(nil).Once()
+
+	suite.mockAuditor.On("JobsExecution", mock.Anything).Return().Once()
+
+	suite.testExecutionHandler.Handle()(responseRecorder, req)
+
+	suite.mockAuditor.AssertExpectations(t)
+	suite.mockExecutioner.AssertExpectations(t)
+	suite.mockStore.AssertExpectations(t)
+
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	assert.Equal(t, fmt.Sprintf("{ \"name\":\"%s\" }", jobExecutionID), responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) idempotentExecuteRequest(userEmail, idempotencyKey string, job Job) *http.Request {
+	requestBody, err := json.Marshal(job)
+	assert.NoError(suite.T(), err)
+
+	req := httptest.NewRequest("POST", "/execute", bytes.NewReader(requestBody))
+	req.Header.Set(utility.IdempotencyKeyHeaderKey, idempotencyKey)
+	return req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
+}
+
+func (suite *ExecutionHandlerTestSuite) expectAuthorizedJob(userEmail string, job Job) {
+	jobMetadata := &metadata.Metadata{Name: job.Name}
+	suite.mockMetadataStore.On("GetJobMetadata", job.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(true, nil).Once()
+}
+
+func idempotencyKeyClaim(userEmail, idempotencyKey, jobName string) interface{} {
+	return mock.MatchedBy(func(claim *postgres.IdempotencyKey) bool {
+		return claim.UserEmail == userEmail && claim.Key == idempotencyKey && claim.JobName == jobName && claim.ExpiresAt.After(time.Now())
+	})
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionHandlerWithIdempotencyKey() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	jobExecutionID := "proctor-ipsum-lorem"
+	job := Job{Name: "sample-job-name", Args: map[string]string{}}
+	responseRecorder := httptest.NewRecorder()
+
+	suite.expectAuthorizedJob(userEmail, job)
+	suite.mockStore.On("ClaimIdempotencyKey", idempotencyKeyClaim(userEmail, "any-key", job.Name)).Return(true, nil).Once()
+	suite.mockExecutioner.On("Execute", mock.Anything, job.Name, job.Args, job.Limits, job.Provenance).Return(jobExecutionID, nil).Once()
+	suite.mockAuditor.On("JobsExecution", mock.Anything).Return().Once()
+	suite.mockStore.On("UpdateIdempotencyKeyExecutionID", userEmail, "any-key", jobExecutionID).Return(nil).Once()
+
+	suite.testExecutionHandler.Handle()(responseRecorder, suite.idempotentExecuteRequest(userEmail, "any-key", job))
+
+	suite.mockStore.AssertExpectations(t)
+	suite.mockExecutioner.AssertExpectations(t)
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	assert.Equal(t, fmt.Sprintf("{ \"name\":\"%s\" }", jobExecutionID), responseRecorder.Body.String())
+	assert.Empty(t, responseRecorder.Header().Get(utility.IdempotentReplayedHeaderKey))
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionHandlerRemembersIdempotencyKeyWithoutConfiguredTTL() {
+	t := suite.T()
+
+	configuredTTL, configured := os.LookupEnv("PROCTOR_IDEMPOTENCY_KEY_TTL_IN_MINS")
+	os.Unsetenv("PROCTOR_IDEMPOTENCY_KEY_TTL_IN_MINS")
+	if configured {
+		defer os.Setenv("PROCTOR_IDEMPOTENCY_KEY_TTL_IN_MINS", configuredTTL)
+	}
+
+	userEmail := "mrproctor@example.com"
+	jobExecutionID := "proctor-ipsum-lorem"
+	job := Job{Name: "sample-job-name", Args: map[string]string{}}
+	responseRecorder := httptest.NewRecorder()
+
+	suite.expectAuthorizedJob(userEmail, job)
+	suite.mockStore.On("ClaimIdempotencyKey", mock.MatchedBy(func(claim *postgres.IdempotencyKey) bool {
+		return claim.ExpiresAt.After(time.Now().Add(time.Hour))
+	})).Return(true, nil).Once()
+	suite.mockExecutioner.On("Execute", mock.Anything, job.Name, job.Args, job.Limits, job.Provenance).Return(jobExecutionID, nil).Once()
+	suite.mockAuditor.On("JobsExecution", mock.Anything).Return().Once()
+	suite.mockStore.On("UpdateIdempotencyKeyExecutionID", userEmail, "any-key", jobExecutionID).Return(nil).Once()
+
+	suite.testExecutionHandler.Handle()(responseRecorder, suite.idempotentExecuteRequest(userEmail, "any-key", job))
+
+	suite.mockStore.AssertExpectations(t)
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionHandlerReplaysRepeatedIdempotencyKey() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	jobExecutionID := "proctor-ipsum-lorem"
+	job := Job{Name: "sample-job-name", Args: map[string]string{}}
+	responseRecorder := httptest.NewRecorder()
+
+	suite.expectAuthorizedJob(userEmail, job)
+	suite.mockStore.On("ClaimIdempotencyKey", idempotencyKeyClaim(userEmail, "any-key", job.Name)).Return(false, nil).Once()
+	suite.mockStore.On("GetIdempotencyKey", userEmail, "any-key").Return([]postgres.IdempotencyKey{
+		{UserEmail: userEmail, Key: "any-key", JobName: job.Name, ExecutionID: postgres.StringToSQLString(jobExecutionID)},
+	}, nil).Once()
+
+	suite.testExecutionHandler.Handle()(responseRecorder, suite.idempotentExecuteRequest(userEmail, "any-key", job))
+
+	suite.mockStore.AssertExpectations(t)
+	suite.mockExecutioner.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	assert.Equal(t, fmt.Sprintf("{ \"name\":\"%s\" }", jobExecutionID), responseRecorder.Body.String())
+	assert.Equal(t, "true", responseRecorder.Header().Get(utility.IdempotentReplayedHeaderKey))
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionHandlerForIdempotencyKeyInProgress() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	job := Job{Name: "sample-job-name", Args: map[string]string{}}
+	responseRecorder := httptest.NewRecorder()
+
+	suite.expectAuthorizedJob(userEmail, job)
+	suite.mockStore.On("ClaimIdempotencyKey", idempotencyKeyClaim(userEmail, "any-key", job.Name)).Return(false, nil).Once()
+	suite.mockStore.On("GetIdempotencyKey", userEmail, "any-key").Return([]postgres.IdempotencyKey{
+		{UserEmail: userEmail, Key: "any-key", JobName: job.Name},
+	}, nil).Once()
 
-		var value map[string]string
-		err := json.NewDecoder(req.Body).Decode(&value)
-		assert.NoError(t, err)
+	suite.testExecutionHandler.Handle()(responseRecorder, suite.idempotentExecuteRequest(userEmail, "any-key", job))
+
+	suite.mockExecutioner.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+	assert.Equal(t, utility.IdempotencyKeyInProgressClientError, responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionHandlerForIdempotencyKeyOfAnotherProc() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	job := Job{Name: "sample-job-name", Args: map[string]string{}}
+	responseRecorder := httptest.NewRecorder()
+
+	suite.expectAuthorizedJob(userEmail, job)
+	suite.mockStore.On("ClaimIdempotencyKey", idempotencyKeyClaim(userEmail, "any-key", job.Name)).Return(false, nil).Once()
+	suite.mockStore.On("GetIdempotencyKey", userEmail, "any-key").Return([]postgres.IdempotencyKey{
+		{UserEmail: userEmail, Key: "any-key", JobName: "other-job-name", ExecutionID: postgres.StringToSQLString("proctor-ipsum-lorem")},
+	}, nil).Once()
+
+	suite.testExecutionHandler.Handle()(responseRecorder, suite.idempotentExecuteRequest(userEmail, "any-key", job))
+
+	suite.mockExecutioner.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusUnprocessableEntity, responseRecorder.Code)
+	assert.Equal(t, utility.IdempotencyKeyReusedClientError, responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionHandlerReleasesIdempotencyKeyOnFailure() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	job := Job{Name: "sample-job-name", Args: map[string]string{}}
+	responseRecorder := httptest.NewRecorder()
 
-		w.WriteHeader(http.StatusOK)
+	suite.expectAuthorizedJob(userEmail, job)
+	suite.mockStore.On("ClaimIdempotencyKey", idempotencyKeyClaim(userEmail, "any-key", job.Name)).Return(true, nil).Once()
+	suite.mockExecutioner.On("Execute", mock.Anything, job.Name, job.Args, job.Limits, job.Provenance).Return("", errors.New("error")).Once()
+	auditingChan := make(chan bool)
+	suite.mockAuditor.On("JobsExecutionAndStatus", mock.Anything).Return().Run(
+		func(args mock.Arguments) { auditingChan <- true },
+	).Once()
+	suite.mockStore.On("RemoveIdempotencyKey", userEmail, "any-key").Return(nil).Once()
 
-		assert.Equal(t, jobExecutionID, value["name"])
-		assert.Equal(t, utility.JobSucceeded, value["status"])
+	suite.testExecutionHandler.Handle()(responseRecorder, suite.idempotentExecuteRequest(userEmail, "any-key", job))
 
-		statusChan <- true
-	}))
-	defer ts.Close()
+	<-auditingChan
+	suite.mockStore.AssertExpectations(t)
+	assert.Equal(t, http.StatusInternalServerError, responseRecorder.Code)
+}
 
-	remoteCallerURL := fmt.Sprintf("%s/status", ts.URL)
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionHandlerForInvalidCallbackURL() {
+	t := suite.T()
 
 	userEmail := "mrproctor@example.com"
 	job := Job{
 		Name:        "sample-job-name",
-		Args:        map[string]string{"argOne": "sample-arg"},
-		CallbackURL: remoteCallerURL,
+		CallbackURL: "example.com/status",
 	}
 
 	requestBody, err := json.Marshal(job)
 	assert.NoError(t, err)
 
 	req := httptest.NewRequest("POST", "/execute", bytes.NewReader(requestBody))
-	req.Header.Set(utility.UserEmailHeaderKey, userEmail)
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
 	responseRecorder := httptest.NewRecorder()
 
-	suite.mockExecutioner.On("Execute", mock.Anything, job.Name, job.Args).Return(jobExecutionID, nil).Once()
+	jobMetadata := &metadata.Metadata{Name: job.Name}
+	suite.mockMetadataStore.On("GetJobMetadata", job.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(true, nil).Once()
 
 	auditingChan := make(chan bool)
-
-	suite.mockAuditor.On("JobsExecutionAndStatus", mock.Anything).Return("", nil).Run(
+	suite.mockAuditor.On("JobsExecutionAndStatus", mock.Anything).Return().Run(
 		func(args mock.Arguments) { auditingChan <- true },
-	)
-	suite.mockStore.On("GetJobExecutionStatus", jobExecutionID).Return(utility.JobSucceeded, nil).Once()
+	).Once()
 
 	suite.testExecutionHandler.Handle()(responseRecorder, req)
 
 	<-auditingChan
-	<-statusChan
-	suite.mockAuditor.AssertExpectations(t)
-	suite.mockExecutioner.AssertExpectations(t)
+	suite.mockExecutioner.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 
-	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
-	assert.Equal(t, fmt.Sprintf("{ \"name\":\"%s\" }", jobExecutionID), responseRecorder.Body.String())
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	assert.Equal(t, utility.InvalidCallbackURLClientError, responseRecorder.Body.String())
 }
 
-func (suite *ExecutionHandlerTestSuite) TestSuccessfulJobExecutionHandlerWithoutCallbackURL() {
+func (suite *ExecutionHandlerTestSuite) TestCallbacks() {
 	t := suite.T()
 
 	jobExecutionID := "proctor-ipsum-lorem"
+	userEmail := "mrproctor@example.com"
+
+	req := httptest.NewRequest("GET", fmt.Sprintf("/jobs/execute/%s/callbacks", jobExecutionID), nil)
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
+	req = mux.SetURLVars(req, map[string]string{"name": jobExecutionID})
+	responseRecorder := httptest.NewRecorder()
+
+	jobMetadata := &metadata.Metadata{Name: "sample-job-name"}
+	suite.mockStore.On("GetJobsExecutionAuditLog", jobExecutionID).Return([]postgres.JobsExecutionAuditLog{{JobName: "sample-job-name"}}, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", "sample-job-name").Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(true, nil).Once()
+	suite.mockStore.On("GetStatusCallbacks", jobExecutionID).Return([]postgres.StatusCallback{
+		{ID: 3, URL: "http://example.com/status", Secret: "any-secret", Status: utility.StatusCallbackDelivered},
+	}, nil).Once()
+	suite.mockStore.On("GetStatusCallbackAttempts", jobExecutionID).Return([]postgres.StatusCallbackAttempt{
+		{CallbackID: 3, Error: "connection refused"},
+		{CallbackID: 3, ResponseCode: sql.NullInt64{Int64: 200, Valid: true}},
+	}, nil).Once()
+
+	suite.testExecutionHandler.Callbacks()(responseRecorder, req)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.NotContains(t, responseRecorder.Body.String(), "any-secret")
+
+	var deliveries []callback.Delivery
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &deliveries)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(deliveries))
+	assert.Equal(t, utility.StatusCallbackDelivered, deliveries[0].Status)
+	assert.Equal(t, 2, len(deliveries[0].Attempts))
+	assert.Equal(t, "connection refused", deliveries[0].Attempts[0].Error)
+	assert.Equal(t, int64(200), deliveries[0].Attempts[1].ResponseCode)
+	suite.mockStore.AssertExpectations(t)
+}
+
+func (suite *ExecutionHandlerTestSuite) TestSuccessfulJobExecutionHandlerWithoutCallbackURL() {
+	t := suite.T()
 
-	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		assert.Equal(t, "should not call status callback", "called status callback")
-	}))
-	defer ts.Close()
+	jobExecutionID := "proctor-ipsum-lorem"
 
 	userEmail := "mrproctor@example.com"
 	job := Job{
@@ -121,23 +344,21 @@ func (suite *ExecutionHandlerTestSuite) TestSuccessfulJobExecutionHandlerWithout
 	assert.NoError(t, err)
 
 	req := httptest.NewRequest("POST", "/execute", bytes.NewReader(requestBody))
-	req.Header.Set(utility.UserEmailHeaderKey, userEmail)
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
 	responseRecorder := httptest.NewRecorder()
 
-	suite.mockExecutioner.On("Execute", mock.Anything, job.Name, job.Args).Return(jobExecutionID, nil).Once()
-
-	auditingChan := make(chan bool)
+	jobMetadata := &metadata.Metadata{Name: job.Name, AuthorizedGroups: []string{"group_one"}, EnvVars: env.Vars{Args: []env.VarMetadata{{Name: "argOne"}}}}
+	suite.mockMetadataStore.On("GetJobMetadata", job.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(true, nil).Once()
+	suite.mockExecutioner.On("Execute", mock.Anything, job.Name, job.Args, job.Limits, job.Provenance).Return(jobExecutionID, nil).Once()
 
-	suite.mockAuditor.On("JobsExecutionAndStatus", mock.Anything).Return("", nil).Run(
-		func(args mock.Arguments) { auditingChan <- true },
-	)
-	suite.mockStore.On("GetJobExecutionStatus", jobExecutionID).Return(utility.JobSucceeded, nil).Once()
+	suite.mockAuditor.On("JobsExecution", mock.Anything).Return().Once()
 
 	suite.testExecutionHandler.Handle()(responseRecorder, req)
 
-	<-auditingChan
 	suite.mockAuditor.AssertExpectations(t)
 	suite.mockExecutioner.AssertExpectations(t)
+	suite.mockStore.AssertNotCalled(t, "InsertStatusCallback", mock.Anything)
 
 	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
 	assert.Equal(t, fmt.Sprintf("{ \"name\":\"%s\" }", jobExecutionID), responseRecorder.Body.String())
@@ -178,7 +399,9 @@ func (suite *ExecutionHandlerTestSuite) TestJobExecutionServerFailure() {
 	req := httptest.NewRequest("POST", "/execute", bytes.NewReader(requestBody))
 	responseRecorder := httptest.NewRecorder()
 
-	suite.mockExecutioner.On("Execute", mock.Anything, job.Name, job.Args).Return("", errors.New("error executing job")).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", job.Name).Return(&metadata.Metadata{Name: job.Name, EnvVars: env.Vars{Args: []env.VarMetadata{{Name: "argOne"}}}}, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{}, []string(nil)).Return(true, nil).Once()
+	suite.mockExecutioner.On("Execute", mock.Anything, job.Name, job.Args, job.Limits, job.Provenance).Return("", errors.New("error executing job")).Once()
 
 	auditingChan := make(chan bool)
 	suite.mockAuditor.On("JobsExecutionAndStatus", mock.Anything).Return("", nil).Run(
@@ -198,6 +421,186 @@ func (suite *ExecutionHandlerTestSuite) TestJobExecutionServerFailure() {
 	assert.Equal(t, utility.ServerError, responseRecorder.Body.String())
 }
 
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionForUnauthorizedUser() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	job := Job{
+		Name: "sample-job-name",
+		Args: map[string]string{"argOne": "sample-arg"},
+	}
+
+	requestBody, err := json.Marshal(job)
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/execute", bytes.NewReader(requestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
+	responseRecorder := httptest.NewRecorder()
+
+	jobMetadata := &metadata.Metadata{Name: job.Name, AuthorizedGroups: []string{"group_one"}}
+	suite.mockMetadataStore.On("GetJobMetadata", job.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(false, nil).Once()
+
+	auditingChan := make(chan *postgres.JobsExecutionAuditLog)
+	suite.mockAuditor.On("JobsExecutionAndStatus", mock.Anything).Return().Run(
+		func(args mock.Arguments) { auditingChan <- args.Get(0).(*postgres.JobsExecutionAuditLog) },
+	)
+
+	suite.testExecutionHandler.Handle()(responseRecorder, req)
+
+	auditedJobsExecution := <-auditingChan
+	assert.Equal(t, utility.JobSubmissionForbidden, auditedJobsExecution.JobSubmissionStatus)
+	assert.Equal(t, job.Name, auditedJobsExecution.JobName)
+	assert.Equal(t, userEmail, auditedJobsExecution.UserEmail)
+
+	suite.mockExecutioner.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	assert.Equal(t, utility.UnauthorizedProcClientError, responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionWithInvalidArgs() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	job := Job{
+		Name: "sample-job-name",
+		Args: map[string]string{"ENV": "prod", "ORDERID": "42"},
+	}
+
+	requestBody, err := json.Marshal(job)
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/execute", bytes.NewReader(requestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
+	responseRecorder := httptest.NewRecorder()
+
+	jobMetadata := &metadata.Metadata{
+		Name: job.Name,
+		EnvVars: env.Vars{Args: []env.VarMetadata{
+			{Name: "ENV", Type: env.EnumType, AllowedValues: []string{"staging", "production"}},
+			{Name: "ORDER_ID", Required: true},
+		}},
+	}
+	suite.mockMetadataStore.On("GetJobMetadata", job.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(true, nil).Once()
+
+	auditingChan := make(chan *postgres.JobsExecutionAuditLog)
+	suite.mockAuditor.On("JobsExecutionAndStatus", mock.Anything).Return().Run(
+		func(args mock.Arguments) { auditingChan <- args.Get(0).(*postgres.JobsExecutionAuditLog) },
+	)
+
+	suite.testExecutionHandler.Handle()(responseRecorder, req)
+
+	auditedJobsExecution := <-auditingChan
+	assert.Equal(t, utility.JobSubmissionClientError, auditedJobsExecution.JobSubmissionStatus)
+
+	suite.mockExecutioner.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	assert.Equal(t, "invalid proc args:\nENV must be one of [staging, production]\nORDER_ID is required\nORDERID is not an arg of this proc", responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionExceedingProcLimits() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	procRetries := int32(1)
+	retries := int32(3)
+	job := Job{
+		Name:   "sample-job-name",
+		Args:   map[string]string{"argOne": "sample-arg"},
+		Limits: Limits{Retries: &retries},
+	}
+
+	requestBody, err := json.Marshal(job)
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/execute", bytes.NewReader(requestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
+	responseRecorder := httptest.NewRecorder()
+
+	jobMetadata := &metadata.Metadata{Name: job.Name, Retries: &procRetries, EnvVars: env.Vars{Args: []env.VarMetadata{{Name: "argOne"}}}}
+	suite.mockMetadataStore.On("GetJobMetadata", job.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(true, nil).Once()
+
+	auditingChan := make(chan *postgres.JobsExecutionAuditLog)
+	suite.mockAuditor.On("JobsExecutionAndStatus", mock.Anything).Return().Run(
+		func(args mock.Arguments) { auditingChan <- args.Get(0).(*postgres.JobsExecutionAuditLog) },
+	)
+
+	suite.testExecutionHandler.Handle()(responseRecorder, req)
+
+	auditedJobsExecution := <-auditingChan
+	assert.Equal(t, utility.JobSubmissionClientError, auditedJobsExecution.JobSubmissionStatus)
+
+	suite.mockExecutioner.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	assert.Equal(t, "invalid execution limits: retries must be between 0 and 1", responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionWithoutRequiredReason() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	job := Job{
+		Name:       "sample-job-name",
+		Args:       map[string]string{},
+		Provenance: Provenance{Labels: map[string]string{"ticket": "OPS-123"}},
+	}
+
+	requestBody, err := json.Marshal(job)
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/execute", bytes.NewReader(requestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
+	responseRecorder := httptest.NewRecorder()
+
+	jobMetadata := &metadata.Metadata{Name: job.Name, ReasonRequired: true}
+	suite.mockMetadataStore.On("GetJobMetadata", job.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(true, nil).Once()
+
+	auditingChan := make(chan *postgres.JobsExecutionAuditLog)
+	suite.mockAuditor.On("JobsExecutionAndStatus", mock.Anything).Return().Run(
+		func(args mock.Arguments) { auditingChan <- args.Get(0).(*postgres.JobsExecutionAuditLog) },
+	)
+
+	suite.testExecutionHandler.Handle()(responseRecorder, req)
+
+	auditedJobsExecution := <-auditingChan
+	assert.Equal(t, utility.JobSubmissionClientError, auditedJobsExecution.JobSubmissionStatus)
+
+	suite.mockExecutioner.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	assert.Equal(t, "invalid execution labels or reason: a reason is required to execute sample-job-name", responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionForNonExistentProc() {
+	t := suite.T()
+
+	job := Job{
+		Name: "non-existent-job",
+	}
+
+	requestBody, err := json.Marshal(job)
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/execute", bytes.NewReader(requestBody))
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockMetadataStore.On("GetJobMetadata", job.Name).Return(&metadata.Metadata{}, errors.New("redigo: nil returned")).Once()
+
+	auditingChan := make(chan bool)
+	suite.mockAuditor.On("JobsExecutionAndStatus", mock.Anything).Return().Run(
+		func(args mock.Arguments) { auditingChan <- true },
+	)
+
+	suite.testExecutionHandler.Handle()(responseRecorder, req)
+
+	<-auditingChan
+	suite.mockAuthorizer.AssertNotCalled(t, "Authorize", mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	assert.Equal(t, utility.NonExistentProcClientError, responseRecorder.Body.String())
+}
+
 func (suite *ExecutionHandlerTestSuite) TestJobStatusShouldReturn200OnSuccess() {
 	t := suite.T()
 
@@ -272,91 +675,755 @@ func (suite *ExecutionHandlerTestSuite) TestJobStatusShouldReturn500OnError() {
 	assert.Equal(suite.T(), http.StatusInternalServerError, response.StatusCode)
 }
 
-func (suite *ExecutionHandlerTestSuite) TestSendStatusToCallerOnSuccess() {
-	t := suite.T()
-
-	jobName := "sample-job-name"
-
-	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		defer req.Body.Close()
-
-		var value map[string]string
-		err := json.NewDecoder(req.Body).Decode(&value)
-		assert.NoError(t, err)
+func (suite *ExecutionHandlerTestSuite) cancelRequest(jobExecutionID, userEmail string) *http.Request {
+	req := httptest.NewRequest("DELETE", fmt.Sprintf("/jobs/execute/%s", jobExecutionID), nil)
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
+	return mux.SetURLVars(req, map[string]string{"name": jobExecutionID})
+}
 
-		w.WriteHeader(http.StatusOK)
+func (suite *ExecutionHandlerTestSuite) TestJobCancellation() {
+	t := suite.T()
 
-		assert.Equal(t, jobName, value["name"])
-		assert.Equal(t, utility.JobSucceeded, value["status"])
-	}))
-	defer ts.Close()
+	userEmail := "mrproctor@example.com"
+	jobExecutionID := "proctor-ipsum-lorem"
+	jobMetadata := &metadata.Metadata{Name: "sample-job-name", AuthorizedGroups: []string{"group_one"}}
+	responseRecorder := httptest.NewRecorder()
 
-	suite.mockStore.On("GetJobExecutionStatus", jobName).Return(utility.JobWaiting, nil).Once()
-	suite.mockStore.On("GetJobExecutionStatus", jobName).Return(utility.JobWaiting, nil).Once()
-	suite.mockStore.On("GetJobExecutionStatus", jobName).Return(utility.JobWaiting, nil).Once()
-	suite.mockStore.On("GetJobExecutionStatus", jobName).Return(utility.JobSucceeded, nil).Once()
+	suite.mockStore.On("GetJobsExecutionAuditLog", jobExecutionID).Return([]postgres.JobsExecutionAuditLog{{JobName: jobMetadata.Name, JobExecutionStatus: utility.JobWaiting}}, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", jobMetadata.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(true, nil).Once()
+	suite.mockExecutioner.On("Cancel", jobExecutionID).Return(nil).Once()
+	suite.mockStore.On("CancelJobsExecution", jobExecutionID, userEmail).Return(int64(1), nil).Once()
 
-	remoteCallerURL := fmt.Sprintf("%s/status", ts.URL)
+	suite.testExecutionHandler.Cancel()(responseRecorder, suite.cancelRequest(jobExecutionID, userEmail))
 
-	suite.testExecutionHandler.sendStatusToCaller(remoteCallerURL, jobName)
 	suite.mockStore.AssertExpectations(t)
+	suite.mockExecutioner.AssertExpectations(t)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, fmt.Sprintf("{ \"name\":\"%s\", \"status\":\"%s\" }", jobExecutionID, utility.JobCancelled), responseRecorder.Body.String())
 }
 
-func (suite *ExecutionHandlerTestSuite) TestSendStatusToCallerOnFailure() {
+func (suite *ExecutionHandlerTestSuite) TestJobCancellationForUnknownExecution() {
 	t := suite.T()
 
-	jobName := "sample-job-name"
+	jobExecutionID := "proctor-ipsum-lorem"
+	responseRecorder := httptest.NewRecorder()
 
-	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		defer req.Body.Close()
+	suite.mockStore.On("GetJobsExecutionAuditLog", jobExecutionID).Return([]postgres.JobsExecutionAuditLog{}, nil).Once()
 
-		var value map[string]string
-		err := json.NewDecoder(req.Body).Decode(&value)
-		assert.NoError(t, err)
+	suite.testExecutionHandler.Cancel()(responseRecorder, suite.cancelRequest(jobExecutionID, "mrproctor@example.com"))
 
-		w.WriteHeader(http.StatusOK)
+	suite.mockExecutioner.AssertNotCalled(t, "Cancel", mock.Anything)
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	assert.Equal(t, utility.JobNotFoundError, responseRecorder.Body.String())
+}
 
-		assert.Equal(t, jobName, value["name"])
-		assert.Equal(t, utility.JobFailed, value["status"])
-	}))
-	defer ts.Close()
+func (suite *ExecutionHandlerTestSuite) TestJobCancellationForUnauthorizedUser() {
+	t := suite.T()
 
-	suite.mockStore.On("GetJobExecutionStatus", jobName).Return(utility.JobWaiting, nil).Once()
-	suite.mockStore.On("GetJobExecutionStatus", jobName).Return(utility.JobWaiting, nil).Once()
-	suite.mockStore.On("GetJobExecutionStatus", jobName).Return(utility.JobWaiting, nil).Once()
-	suite.mockStore.On("GetJobExecutionStatus", jobName).Return(utility.JobFailed, nil).Once()
+	userEmail := "mrproctor@example.com"
+	jobExecutionID := "proctor-ipsum-lorem"
+	jobMetadata := &metadata.Metadata{Name: "sample-job-name", AuthorizedGroups: []string{"group_one"}}
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("GetJobsExecutionAuditLog", jobExecutionID).Return([]postgres.JobsExecutionAuditLog{{JobName: jobMetadata.Name, JobExecutionStatus: utility.JobWaiting}}, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", jobMetadata.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(false, nil).Once()
+
+	suite.testExecutionHandler.Cancel()(responseRecorder, suite.cancelRequest(jobExecutionID, userEmail))
+
+	suite.mockExecutioner.AssertNotCalled(t, "Cancel", mock.Anything)
+	assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	assert.Equal(t, utility.UnauthorizedProcClientError, responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobCancellationForFinishedExecution() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	jobExecutionID := "proctor-ipsum-lorem"
+	jobMetadata := &metadata.Metadata{Name: "sample-job-name", AuthorizedGroups: []string{"group_one"}}
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("GetJobsExecutionAuditLog", jobExecutionID).Return([]postgres.JobsExecutionAuditLog{{JobName: jobMetadata.Name, JobExecutionStatus: utility.JobSucceeded}}, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", jobMetadata.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(true, nil).Once()
+
+	suite.testExecutionHandler.Cancel()(responseRecorder, suite.cancelRequest(jobExecutionID, userEmail))
+
+	suite.mockExecutioner.AssertNotCalled(t, "Cancel", mock.Anything)
+	suite.mockStore.AssertNotCalled(t, "CancelJobsExecution", mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+	assert.Equal(t, utility.JobAlreadyFinishedClientError, responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobCancellationOnKubernetesFailure() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	jobExecutionID := "proctor-ipsum-lorem"
+	jobMetadata := &metadata.Metadata{Name: "sample-job-name", AuthorizedGroups: []string{"group_one"}}
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("GetJobsExecutionAuditLog", jobExecutionID).Return([]postgres.JobsExecutionAuditLog{{JobName: jobMetadata.Name, JobExecutionStatus: utility.JobWaiting}}, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", jobMetadata.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(true, nil).Once()
+	suite.mockExecutioner.On("Cancel", jobExecutionID).Return(errors.New("kube-client-error")).Once()
+
+	suite.testExecutionHandler.Cancel()(responseRecorder, suite.cancelRequest(jobExecutionID, userEmail))
+
+	suite.mockStore.AssertNotCalled(t, "CancelJobsExecution", mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusInternalServerError, responseRecorder.Code)
+	assert.Equal(t, utility.ServerError, responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) detailRequest(jobExecutionID, userEmail string) *http.Request {
+	req := httptest.NewRequest("GET", fmt.Sprintf("/jobs/execute/%s", jobExecutionID), nil)
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
+	return mux.SetURLVars(req, map[string]string{"name": jobExecutionID})
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionDetail() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	jobExecutionID := "proctor-ipsum-lorem"
+	jobMetadata := &metadata.Metadata{Name: "sample-job-name", AuthorizedGroups: []string{"group_one"}}
+	responseRecorder := httptest.NewRecorder()
+
+	createdAt := time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+	jobsExecutionAuditLog := postgres.JobsExecutionAuditLog{
+		JobName:             jobMetadata.Name,
+		UserEmail:           "runner@example.com",
+		ImageName:           "img",
+		ExecutionID:         postgres.StringToSQLString(jobExecutionID),
+		JobSubmissionStatus: utility.JobSubmissionSuccess,
+		JobExecutionStatus:  utility.JobSucceeded,
+		Reason:              "Clearing the stuck payments queue",
+		CreatedAt:           createdAt,
+		UpdatedAt:           createdAt.Add(90 * time.Second),
+	}
+	jobsExecutionAuditLog.AddJobArgs(map[string]string{"argOne": "sample-arg", "secretOne": utility.RedactedSecretValue})
+	jobsExecutionAuditLog.AddLabels(map[string]string{"ticket": "OPS-123"})
+
+	suite.mockStore.On("GetJobsExecutionAuditLog", jobExecutionID).Return([]postgres.JobsExecutionAuditLog{jobsExecutionAuditLog}, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", jobMetadata.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(true, nil).Once()
+
+	suite.testExecutionHandler.Detail()(responseRecorder, suite.detailRequest(jobExecutionID, userEmail))
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var detail Detail
+	err := json.NewDecoder(responseRecorder.Body).Decode(&detail)
+	assert.NoError(t, err)
+
+	expectedDetail := Detail{
+		Name:             jobExecutionID,
+		JobName:          jobMetadata.Name,
+		ImageName:        "img",
+		UserEmail:        "runner@example.com",
+		Args:             map[string]string{"argOne": "sample-arg", "secretOne": utility.RedactedSecretValue},
+		SubmissionStatus: utility.JobSubmissionSuccess,
+		ExecutionStatus:  utility.JobSucceeded,
+		Labels:           map[string]string{"ticket": "OPS-123"},
+		Reason:           "Clearing the stuck payments queue",
+		CreatedAt:        createdAt,
+		UpdatedAt:        createdAt.Add(90 * time.Second),
+		DurationSeconds:  90,
+	}
+	assert.Equal(t, expectedDetail, detail)
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionDetailForUnknownExecution() {
+	t := suite.T()
+
+	jobExecutionID := "proctor-ipsum-lorem"
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("GetJobsExecutionAuditLog", jobExecutionID).Return([]postgres.JobsExecutionAuditLog{}, nil).Once()
+
+	suite.testExecutionHandler.Detail()(responseRecorder, suite.detailRequest(jobExecutionID, "mrproctor@example.com"))
+
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	assert.Equal(t, utility.JobNotFoundError, responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionDetailForUnauthorizedUser() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	jobExecutionID := "proctor-ipsum-lorem"
+	jobMetadata := &metadata.Metadata{Name: "sample-job-name", AuthorizedGroups: []string{"group_one"}}
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("GetJobsExecutionAuditLog", jobExecutionID).Return([]postgres.JobsExecutionAuditLog{{JobName: jobMetadata.Name}}, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", jobMetadata.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(false, nil).Once()
+
+	suite.testExecutionHandler.Detail()(responseRecorder, suite.detailRequest(jobExecutionID, userEmail))
+
+	assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	assert.Equal(t, utility.UnauthorizedProcClientError, responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) rerunRequest(jobExecutionID, userEmail string, body []byte) *http.Request {
+	req := httptest.NewRequest("POST", fmt.Sprintf("/jobs/execute/%s/rerun", jobExecutionID), bytes.NewReader(body))
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
+	return mux.SetURLVars(req, map[string]string{"name": jobExecutionID})
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobRerun() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	parentExecutionID := "proctor-ipsum-lorem"
+	jobExecutionID := "proctor-dolor-sit"
+	jobMetadata := &metadata.Metadata{
+		Name:             "sample-job-name",
+		AuthorizedGroups: []string{"group_one"},
+		EnvVars: env.Vars{
+			Args:    []env.VarMetadata{{Name: "argOne"}, {Name: "argTwo"}},
+			Secrets: []env.VarMetadata{{Name: "secretOne"}},
+		},
+	}
+	responseRecorder := httptest.NewRecorder()
+
+	parentAuditLog := postgres.JobsExecutionAuditLog{JobName: jobMetadata.Name, JobExecutionStatus: utility.JobFailed}
+	parentAuditLog.AddJobArgs(map[string]string{"argOne": "sample-arg", "argTwo": "other-arg", "secretOne": utility.RedactedSecretValue})
+
+	requestBody, err := json.Marshal(Job{Args: map[string]string{"argTwo": "override"}})
+	assert.NoError(t, err)
+
+	suite.mockStore.On("GetJobsExecutionAuditLog", parentExecutionID).Return([]postgres.JobsExecutionAuditLog{parentAuditLog}, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", jobMetadata.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(true, nil).Once()
+
+	expectedArgs := map[string]string{"argOne": "sample-arg", "argTwo": "override"}
+	auditLogMatcher := mock.MatchedBy(func(jobsExecutionAuditLog *postgres.JobsExecutionAuditLog) bool {
+		return jobsExecutionAuditLog.ParentExecutionID.String == parentExecutionID && jobsExecutionAuditLog.UserEmail == userEmail
+	})
+	suite.mockExecutioner.On("Execute", auditLogMatcher, jobMetadata.Name, expectedArgs, Limits{}, Provenance{}).Return(jobExecutionID, nil).Once()
+
+	suite.mockAuditor.On("JobsExecution", auditLogMatcher).Return().Once()
+
+	suite.testExecutionHandler.Rerun()(responseRecorder, suite.rerunRequest(parentExecutionID, userEmail, requestBody))
+
+	suite.mockExecutioner.AssertExpectations(t)
+	suite.mockAuditor.AssertExpectations(t)
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	assert.Equal(t, fmt.Sprintf("{ \"name\":\"%s\" }", jobExecutionID), responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobRerunKeepsLabelsAndReason() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	parentExecutionID := "proctor-ipsum-lorem"
+	jobMetadata := &metadata.Metadata{Name: "sample-job-name", ReasonRequired: true}
+	responseRecorder := httptest.NewRecorder()
+
+	parentAuditLog := postgres.JobsExecutionAuditLog{JobName: jobMetadata.Name, JobExecutionStatus: utility.JobFailed, Reason: "Clearing the stuck payments queue"}
+	parentAuditLog.AddJobArgs(map[string]string{})
+	parentAuditLog.AddLabels(map[string]string{"ticket": "OPS-123", "team": "payments"})
+
+	requestBody, err := json.Marshal(Job{Provenance: Provenance{Labels: map[string]string{"ticket": "OPS-124"}}})
+	assert.NoError(t, err)
+
+	suite.mockStore.On("GetJobsExecutionAuditLog", parentExecutionID).Return([]postgres.JobsExecutionAuditLog{parentAuditLog}, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", jobMetadata.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(true, nil).Once()
+
+	expectedProvenance := Provenance{
+		Labels: map[string]string{"ticket": "OPS-124", "team": "payments"},
+		Reason: "Clearing the stuck payments queue",
+	}
+	suite.mockExecutioner.On("Execute", mock.Anything, jobMetadata.Name, map[string]string{}, Limits{}, expectedProvenance).Return("proctor-dolor-sit", nil).Once()
+	suite.mockAuditor.On("JobsExecution", mock.Anything).Return().Once()
+
+	suite.testExecutionHandler.Rerun()(responseRecorder, suite.rerunRequest(parentExecutionID, userEmail, requestBody))
+
+	suite.mockExecutioner.AssertExpectations(t)
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobRerunWithoutRequestBody() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	parentExecutionID := "proctor-ipsum-lorem"
+	jobExecutionID := "proctor-dolor-sit"
+	jobMetadata := &metadata.Metadata{Name: "sample-job-name", EnvVars: env.Vars{Args: []env.VarMetadata{{Name: "argOne"}}}}
+	responseRecorder := httptest.NewRecorder()
+
+	parentAuditLog := postgres.JobsExecutionAuditLog{JobName: jobMetadata.Name, JobExecutionStatus: utility.JobFailed}
+	parentAuditLog.AddJobArgs(map[string]string{"argOne": "sample-arg"})
+
+	suite.mockStore.On("GetJobsExecutionAuditLog", parentExecutionID).Return([]postgres.JobsExecutionAuditLog{parentAuditLog}, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", jobMetadata.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(true, nil).Once()
+	suite.mockExecutioner.On("Execute", mock.Anything, jobMetadata.Name, map[string]string{"argOne": "sample-arg"}, Limits{}, Provenance{}).Return(jobExecutionID, nil).Once()
+
+	suite.mockAuditor.On("JobsExecution", mock.Anything).Return().Once()
+
+	suite.testExecutionHandler.Rerun()(responseRecorder, suite.rerunRequest(parentExecutionID, userEmail, nil))
+
+	suite.mockExecutioner.AssertExpectations(t)
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobRerunWithInvalidOverrides() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	parentExecutionID := "proctor-ipsum-lorem"
+	jobMetadata := &metadata.Metadata{Name: "sample-job-name", EnvVars: env.Vars{Args: []env.VarMetadata{{Name: "argOne", Type: env.IntType}}}}
+	responseRecorder := httptest.NewRecorder()
+
+	parentAuditLog := postgres.JobsExecutionAuditLog{JobName: jobMetadata.Name, JobExecutionStatus: utility.JobFailed}
+	parentAuditLog.AddJobArgs(map[string]string{"argOne": "1"})
+
+	requestBody, err := json.Marshal(Job{Args: map[string]string{"argOne": "one"}})
+	assert.NoError(t, err)
+
+	suite.mockStore.On("GetJobsExecutionAuditLog", parentExecutionID).Return([]postgres.JobsExecutionAuditLog{parentAuditLog}, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", jobMetadata.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(true, nil).Once()
+	suite.mockAuditor.On("JobsExecutionAndStatus", mock.Anything).Return().Maybe()
+
+	suite.testExecutionHandler.Rerun()(responseRecorder, suite.rerunRequest(parentExecutionID, userEmail, requestBody))
+
+	suite.mockExecutioner.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobRerunForUnknownExecution() {
+	t := suite.T()
+
+	parentExecutionID := "proctor-ipsum-lorem"
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("GetJobsExecutionAuditLog", parentExecutionID).Return([]postgres.JobsExecutionAuditLog{}, nil).Once()
+
+	suite.testExecutionHandler.Rerun()(responseRecorder, suite.rerunRequest(parentExecutionID, "mrproctor@example.com", nil))
+
+	suite.mockExecutioner.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	assert.Equal(t, utility.JobNotFoundError, responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobRerunForUnauthorizedUser() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	parentExecutionID := "proctor-ipsum-lorem"
+	jobMetadata := &metadata.Metadata{Name: "sample-job-name", AuthorizedGroups: []string{"group_one"}}
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("GetJobsExecutionAuditLog", parentExecutionID).Return([]postgres.JobsExecutionAuditLog{{JobName: jobMetadata.Name}}, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", jobMetadata.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(false, nil).Once()
+
+	suite.testExecutionHandler.Rerun()(responseRecorder, suite.rerunRequest(parentExecutionID, userEmail, nil))
+
+	suite.mockExecutioner.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	assert.Equal(t, utility.UnauthorizedProcClientError, responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionsList() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	openJob := &metadata.Metadata{Name: "open-job"}
+	restrictedJob := &metadata.Metadata{Name: "restricted-job", AuthorizedGroups: []string{"group_one"}}
+	responseRecorder := httptest.NewRecorder()
+
+	expectedFilter := storage.JobsExecutionAuditLogFilter{UserEmail: "runner@example.com", BeforeID: 10, Limit: 3}
+	suite.mockStore.On("GetJobsExecutionAuditLogs", expectedFilter).Return([]postgres.JobsExecutionAuditLog{
+		{ID: 9, JobName: openJob.Name, ExecutionID: postgres.StringToSQLString("proctor-nine")},
+		{ID: 8, JobName: restrictedJob.Name, ExecutionID: postgres.StringToSQLString("proctor-eight")},
+		{ID: 7, JobName: "removed-job", ExecutionID: postgres.StringToSQLString("proctor-seven")},
+	}, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", openJob.Name).Return(openJob, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", restrictedJob.Name).Return(restrictedJob, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, []string(nil)).Return(true, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, restrictedJob.AuthorizedGroups).Return(false, nil).Once()
+
+	req := httptest.NewRequest("GET", "/jobs/executions?user=runner@example.com&cursor=10&limit=2", nil)
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
+	suite.testExecutionHandler.List()(responseRecorder, req)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var history History
+	err := json.NewDecoder(responseRecorder.Body).Decode(&history)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "8", history.NextCursor)
+	assert.Equal(t, 1, len(history.Executions))
+	assert.Equal(t, "proctor-nine", history.Executions[0].Name)
+	suite.mockMetadataStore.AssertNotCalled(t, "GetJobMetadata", "removed-job")
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionsListForInvalidFilter() {
+	t := suite.T()
+
+	responseRecorder := httptest.NewRecorder()
+
+	req := httptest.NewRequest("GET", "/jobs/executions?from=yesterday", nil)
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: "mrproctor@example.com"}))
+	suite.testExecutionHandler.List()(responseRecorder, req)
+
+	suite.mockStore.AssertNotCalled(t, "GetJobsExecutionAuditLogs", mock.Anything)
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	assert.Equal(t, fmt.Sprintf("%s: from must be an RFC3339 time", utility.InvalidHistoryFilterClientError), responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionsListOnStoreFailure() {
+	t := suite.T()
+
+	responseRecorder := httptest.NewRecorder()
+	suite.mockStore.On("GetJobsExecutionAuditLogs", mock.Anything).Return([]postgres.JobsExecutionAuditLog{}, errors.New("db error")).Once()
+
+	req := httptest.NewRequest("GET", "/jobs/executions", nil)
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: "mrproctor@example.com"}))
+	suite.testExecutionHandler.List()(responseRecorder, req)
 
-	remoteCallerURL := fmt.Sprintf("%s/status", ts.URL)
+	assert.Equal(t, http.StatusInternalServerError, responseRecorder.Code)
+	assert.Equal(t, utility.ServerError, responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionRequiringApproval() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	job := Job{Name: "sample-job-name", Args: map[string]string{"argOne": "sample-arg"}, Provenance: Provenance{Reason: "refund"}}
+	requestBody, err := json.Marshal(job)
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/execute", bytes.NewReader(requestBody))
+	req.Header.Set(utility.CallbackSecretHeaderKey, "any-secret")
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
+	responseRecorder := httptest.NewRecorder()
+
+	jobMetadata := &metadata.Metadata{Name: job.Name, RequiresApproval: true, ApproverGroups: []string{"approvers"}, EnvVars: env.Vars{Args: []env.VarMetadata{{Name: "argOne"}}}}
+	suite.mockMetadataStore.On("GetJobMetadata", job.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(true, nil).Once()
+	suite.mockStore.On("InsertApprovalRequest", mock.MatchedBy(func(approvalRequest *postgres.ApprovalRequest) bool {
+		var heldJob Job
+		err := json.Unmarshal([]byte(approvalRequest.Job), &heldJob)
+		return err == nil && assert.ObjectsAreEqual(job, heldJob) && approvalRequest.JobName == job.Name && approvalRequest.UserEmail == userEmail &&
+			approvalRequest.CallbackSecret == "any-secret" && approvalRequest.ExpiresAt.After(time.Now())
+	})).Return("approval-id", nil).Once()
+
+	suite.testExecutionHandler.Handle()(responseRecorder, req)
 
-	suite.testExecutionHandler.sendStatusToCaller(remoteCallerURL, jobName)
 	suite.mockStore.AssertExpectations(t)
+	suite.mockExecutioner.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusAccepted, responseRecorder.Code)
+	assert.Equal(t, "{ \"id\":\"approval-id\", \"status\":\"PENDING\" }", responseRecorder.Body.String())
 }
 
-func (suite *ExecutionHandlerTestSuite) TestSendStatusToCallerOnJobNotFound() {
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionHandlerForProcRequiringApprovalWithoutConfiguredTTL() {
 	t := suite.T()
 
-	jobName := "sample-job-name"
+	configuredTTL, configured := os.LookupEnv("PROCTOR_APPROVAL_REQUEST_TTL_IN_MINS")
+	os.Unsetenv("PROCTOR_APPROVAL_REQUEST_TTL_IN_MINS")
+	if configured {
+		defer os.Setenv("PROCTOR_APPROVAL_REQUEST_TTL_IN_MINS", configuredTTL)
+	}
 
-	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		defer req.Body.Close()
+	userEmail := "mrproctor@example.com"
+	job := Job{Name: "sample-job-name", Args: map[string]string{}}
+	requestBody, err := json.Marshal(job)
+	assert.NoError(t, err)
 
-		var value map[string]string
-		err := json.NewDecoder(req.Body).Decode(&value)
-		assert.NoError(t, err)
+	req := httptest.NewRequest("POST", "/execute", bytes.NewReader(requestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
+	responseRecorder := httptest.NewRecorder()
 
-		w.WriteHeader(http.StatusOK)
+	jobMetadata := &metadata.Metadata{Name: job.Name, RequiresApproval: true, ApproverGroups: []string{"approvers"}}
+	suite.mockMetadataStore.On("GetJobMetadata", job.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(true, nil).Once()
+	suite.mockStore.On("InsertApprovalRequest", mock.MatchedBy(func(approvalRequest *postgres.ApprovalRequest) bool {
+		return approvalRequest.ExpiresAt.After(time.Now().Add(time.Hour))
+	})).Return("approval-id", nil).Once()
 
-		assert.Equal(t, jobName, value["name"])
-		assert.Equal(t, utility.JobNotFound, value["status"])
-	}))
-	defer ts.Close()
+	suite.testExecutionHandler.Handle()(responseRecorder, req)
 
-	suite.mockStore.On("GetJobExecutionStatus", jobName).Return("", nil).Once()
+	suite.mockStore.AssertExpectations(t)
+	assert.Equal(t, http.StatusAccepted, responseRecorder.Code)
+}
 
-	remoteCallerURL := fmt.Sprintf("%s/status", ts.URL)
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionHandlerWithIdempotencyKeyForProcRequiringApproval() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	job := Job{Name: "sample-job-name", Args: map[string]string{}}
+	responseRecorder := httptest.NewRecorder()
+
+	jobMetadata := &metadata.Metadata{Name: job.Name, RequiresApproval: true, ApproverGroups: []string{"approvers"}}
+	suite.mockMetadataStore.On("GetJobMetadata", job.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(true, nil).Once()
+	suite.mockStore.On("ClaimIdempotencyKey", idempotencyKeyClaim(userEmail, "any-key", job.Name)).Return(true, nil).Once()
+	suite.mockStore.On("InsertApprovalRequest", mock.Anything).Return("approval-id", nil).Once()
+	suite.mockStore.On("UpdateIdempotencyKeyApprovalID", userEmail, "any-key", "approval-id").Return(nil).Once()
+
+	suite.testExecutionHandler.Handle()(responseRecorder, suite.idempotentExecuteRequest(userEmail, "any-key", job))
 
-	suite.testExecutionHandler.sendStatusToCaller(remoteCallerURL, jobName)
 	suite.mockStore.AssertExpectations(t)
+	suite.mockStore.AssertNotCalled(t, "RemoveIdempotencyKey", mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusAccepted, responseRecorder.Code)
+	assert.Equal(t, "{ \"id\":\"approval-id\", \"status\":\"PENDING\" }", responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobExecutionHandlerReplaysRepeatedIdempotencyKeyOfApprovalRequest() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	job := Job{Name: "sample-job-name", Args: map[string]string{}}
+	responseRecorder := httptest.NewRecorder()
+
+	jobMetadata := &metadata.Metadata{Name: job.Name, RequiresApproval: true, ApproverGroups: []string{"approvers"}}
+	suite.mockMetadataStore.On("GetJobMetadata", job.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(true, nil).Once()
+	suite.mockStore.On("ClaimIdempotencyKey", idempotencyKeyClaim(userEmail, "any-key", job.Name)).Return(false, nil).Once()
+	suite.mockStore.On("GetIdempotencyKey", userEmail, "any-key").Return([]postgres.IdempotencyKey{
+		{UserEmail: userEmail, Key: "any-key", JobName: job.Name, ApprovalID: postgres.StringToSQLString("approval-id")},
+	}, nil).Once()
+
+	suite.testExecutionHandler.Handle()(responseRecorder, suite.idempotentExecuteRequest(userEmail, "any-key", job))
+
+	suite.mockStore.AssertExpectations(t)
+	suite.mockStore.AssertNotCalled(t, "InsertApprovalRequest", mock.Anything)
+	assert.Equal(t, http.StatusAccepted, responseRecorder.Code)
+	assert.Equal(t, "{ \"id\":\"approval-id\", \"status\":\"PENDING\" }", responseRecorder.Body.String())
+	assert.Equal(t, "true", responseRecorder.Header().Get(utility.IdempotentReplayedHeaderKey))
+}
+
+func (suite *ExecutionHandlerTestSuite) approvalRequest(approvalID, action, userEmail string) *http.Request {
+	req := httptest.NewRequest("POST", fmt.Sprintf("/jobs/approvals/%s/%s", approvalID, action), nil)
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
+	return mux.SetURLVars(req, map[string]string{"id": approvalID})
+}
+
+func pendingApprovalRequest(approvalID, requester string, job Job) postgres.ApprovalRequest {
+	jobInJSON, _ := json.Marshal(job)
+	return postgres.ApprovalRequest{
+		ID:             approvalID,
+		JobName:        job.Name,
+		UserEmail:      requester,
+		Job:            string(jobInJSON),
+		CallbackSecret: "any-secret",
+		Status:         utility.ApprovalPending,
+		ExpiresAt:      time.Now().Add(time.Hour),
+	}
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobApproval() {
+	t := suite.T()
+
+	approver := "approver@example.com"
+	requester := "mrproctor@example.com"
+	jobExecutionID := "proctor-ipsum-lorem"
+	job := Job{Name: "sample-job-name", Args: map[string]string{"argOne": "sample-arg"}, CallbackURL: "http://example.com/status"}
+	jobMetadata := &metadata.Metadata{Name: job.Name, RequiresApproval: true, ApproverGroups: []string{"approvers"}, EnvVars: env.Vars{Args: []env.VarMetadata{{Name: "argOne"}}}}
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("GetApprovalRequest", "approval-id").Return([]postgres.ApprovalRequest{pendingApprovalRequest("approval-id", requester, job)}, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", job.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: approver}, jobMetadata.ApproverGroups).Return(true, nil).Once()
+	suite.mockStore.On("DecideApprovalRequest", "approval-id", utility.ApprovalApproved, approver).Return(int64(1), nil).Once()
+
+	auditLogMatcher := mock.MatchedBy(func(jobsExecutionAuditLog *postgres.JobsExecutionAuditLog) bool {
+		return jobsExecutionAuditLog.UserEmail == requester && jobsExecutionAuditLog.ApprovedBy == approver
+	})
+	suite.mockExecutioner.On("Execute", auditLogMatcher, job.Name, job.Args, job.Limits, job.Provenance).Return(jobExecutionID, nil).Once()
+	suite.mockAuditor.On("JobsExecution", auditLogMatcher).Return().Once()
+	suite.mockStore.On("InsertStatusCallback", &postgres.StatusCallback{ExecutionID: jobExecutionID, URL: job.CallbackURL, Secret: "any-secret"}).Return(nil).Once()
+	suite.mockStore.On("UpdateApprovalRequestExecutionID", "approval-id", jobExecutionID).Return(nil).Once()
+
+	suite.testExecutionHandler.Approve()(responseRecorder, suite.approvalRequest("approval-id", "approve", approver))
+
+	suite.mockStore.AssertExpectations(t)
+	suite.mockExecutioner.AssertExpectations(t)
+	suite.mockAuditor.AssertExpectations(t)
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+	assert.Equal(t, fmt.Sprintf("{ \"name\":\"%s\" }", jobExecutionID), responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobApprovalReopensRequestWhenSubmissionFails() {
+	t := suite.T()
+
+	approver := "approver@example.com"
+	requester := "mrproctor@example.com"
+	job := Job{Name: "sample-job-name", Args: map[string]string{"argOne": "sample-arg"}}
+	jobMetadata := &metadata.Metadata{Name: job.Name, RequiresApproval: true, ApproverGroups: []string{"approvers"}, EnvVars: env.Vars{Args: []env.VarMetadata{{Name: "argOne"}}}}
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("GetApprovalRequest", "approval-id").Return([]postgres.ApprovalRequest{pendingApprovalRequest("approval-id", requester, job)}, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", job.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: approver}, jobMetadata.ApproverGroups).Return(true, nil).Once()
+	suite.mockStore.On("DecideApprovalRequest", "approval-id", utility.ApprovalApproved, approver).Return(int64(1), nil).Once()
+	suite.mockExecutioner.On("Execute", mock.Anything, job.Name, job.Args, job.Limits, job.Provenance).Return("", errors.New("error")).Once()
+	auditingChan := make(chan bool)
+	suite.mockAuditor.On("JobsExecutionAndStatus", mock.Anything).Return().Run(
+		func(args mock.Arguments) { auditingChan <- true },
+	).Once()
+	suite.mockStore.On("ReopenApprovalRequest", "approval-id").Return(nil).Once()
+
+	suite.testExecutionHandler.Approve()(responseRecorder, suite.approvalRequest("approval-id", "approve", approver))
+
+	<-auditingChan
+	suite.mockStore.AssertExpectations(t)
+	suite.mockStore.AssertNotCalled(t, "UpdateApprovalRequestExecutionID", mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusInternalServerError, responseRecorder.Code)
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobApprovalByRequester() {
+	t := suite.T()
+
+	requester := "mrproctor@example.com"
+	job := Job{Name: "sample-job-name"}
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("GetApprovalRequest", "approval-id").Return([]postgres.ApprovalRequest{pendingApprovalRequest("approval-id", requester, job)}, nil).Once()
+
+	suite.testExecutionHandler.Approve()(responseRecorder, suite.approvalRequest("approval-id", "approve", requester))
+
+	suite.mockStore.AssertNotCalled(t, "DecideApprovalRequest", mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	assert.Equal(t, utility.SelfApprovalClientError, responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobApprovalByNonApprover() {
+	t := suite.T()
+
+	userEmail := "someone@example.com"
+	job := Job{Name: "sample-job-name"}
+	jobMetadata := &metadata.Metadata{Name: job.Name, RequiresApproval: true, ApproverGroups: []string{"approvers"}}
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("GetApprovalRequest", "approval-id").Return([]postgres.ApprovalRequest{pendingApprovalRequest("approval-id", "mrproctor@example.com", job)}, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", job.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.ApproverGroups).Return(false, nil).Once()
+
+	suite.testExecutionHandler.Approve()(responseRecorder, suite.approvalRequest("approval-id", "approve", userEmail))
+
+	suite.mockStore.AssertNotCalled(t, "DecideApprovalRequest", mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	assert.Equal(t, utility.UnauthorizedApproverClientError, responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobApprovalOfExpiredRequest() {
+	t := suite.T()
+
+	approvalRequest := pendingApprovalRequest("approval-id", "mrproctor@example.com", Job{Name: "sample-job-name"})
+	approvalRequest.Status = utility.ApprovalExpired
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("GetApprovalRequest", "approval-id").Return([]postgres.ApprovalRequest{approvalRequest}, nil).Once()
+
+	suite.testExecutionHandler.Approve()(responseRecorder, suite.approvalRequest("approval-id", "approve", "approver@example.com"))
+
+	suite.mockStore.AssertNotCalled(t, "DecideApprovalRequest", mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+	assert.Equal(t, utility.ApprovalRequestDecidedClientError, responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobApprovalDecidedMeanwhile() {
+	t := suite.T()
+
+	approver := "approver@example.com"
+	job := Job{Name: "sample-job-name"}
+	jobMetadata := &metadata.Metadata{Name: job.Name, RequiresApproval: true, ApproverGroups: []string{"approvers"}}
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("GetApprovalRequest", "approval-id").Return([]postgres.ApprovalRequest{pendingApprovalRequest("approval-id", "mrproctor@example.com", job)}, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", job.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: approver}, jobMetadata.ApproverGroups).Return(true, nil).Once()
+	suite.mockStore.On("DecideApprovalRequest", "approval-id", utility.ApprovalApproved, approver).Return(int64(0), nil).Once()
+
+	suite.testExecutionHandler.Approve()(responseRecorder, suite.approvalRequest("approval-id", "approve", approver))
+
+	suite.mockExecutioner.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusConflict, responseRecorder.Code)
+	assert.Equal(t, utility.ApprovalRequestDecidedClientError, responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobApprovalForUnknownRequest() {
+	t := suite.T()
+
+	responseRecorder := httptest.NewRecorder()
+	suite.mockStore.On("GetApprovalRequest", "approval-id").Return([]postgres.ApprovalRequest{}, nil).Once()
+
+	suite.testExecutionHandler.Approve()(responseRecorder, suite.approvalRequest("approval-id", "approve", "approver@example.com"))
+
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	assert.Equal(t, utility.ApprovalRequestNotFoundError, responseRecorder.Body.String())
+}
+
+func (suite *ExecutionHandlerTestSuite) TestJobRejection() {
+	t := suite.T()
+
+	approver := "approver@example.com"
+	job := Job{Name: "sample-job-name", Args: map[string]string{"argOne": "sample-arg"}}
+	jobMetadata := &metadata.Metadata{Name: job.Name, RequiresApproval: true, ApproverGroups: []string{"approvers"}}
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("GetApprovalRequest", "approval-id").Return([]postgres.ApprovalRequest{pendingApprovalRequest("approval-id", "mrproctor@example.com", job)}, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", job.Name).Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: approver}, jobMetadata.ApproverGroups).Return(true, nil).Once()
+	suite.mockStore.On("DecideApprovalRequest", "approval-id", utility.ApprovalRejected, approver).Return(int64(1), nil).Once()
+
+	suite.testExecutionHandler.Reject()(responseRecorder, suite.approvalRequest("approval-id", "reject", approver))
+
+	suite.mockStore.AssertExpectations(t)
+	suite.mockExecutioner.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var approval Approval
+	assert.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &approval))
+	assert.Equal(t, "approval-id", approval.ID)
+	assert.Equal(t, utility.ApprovalRejected, approval.Status)
+	assert.Equal(t, approver, approval.DecidedBy)
+	assert.Equal(t, job.Args, approval.Args)
+}
+
+func (suite *ExecutionHandlerTestSuite) TestApprovalsList() {
+	t := suite.T()
+
+	userEmail := "approver@example.com"
+	refund := &metadata.Metadata{Name: "refund", RequiresApproval: true, ApproverGroups: []string{"finance"}}
+	wipe := &metadata.Metadata{Name: "wipe", RequiresApproval: true, ApproverGroups: []string{"dba"}}
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("GetPendingApprovalRequests").Return([]postgres.ApprovalRequest{
+		pendingApprovalRequest("approval-one", "mrproctor@example.com", Job{Name: "refund"}),
+		pendingApprovalRequest("approval-two", "mrproctor@example.com", Job{Name: "wipe"}),
+		pendingApprovalRequest("approval-three", userEmail, Job{Name: "wipe"}),
+		pendingApprovalRequest("approval-four", "someone@example.com", Job{Name: "refund"}),
+	}, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", "refund").Return(refund, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", "wipe").Return(wipe, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, refund.ApproverGroups).Return(true, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, wipe.ApproverGroups).Return(false, nil).Once()
+
+	req := httptest.NewRequest("GET", "/jobs/approvals", nil)
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
+	suite.testExecutionHandler.Approvals()(responseRecorder, req)
+
+	suite.mockMetadataStore.AssertExpectations(t)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var approvals []Approval
+	assert.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &approvals))
+	approvalIDs := []string{}
+	for _, approval := range approvals {
+		approvalIDs = append(approvalIDs, approval.ID)
+	}
+	assert.Equal(t, []string{"approval-one", "approval-three", "approval-four"}, approvalIDs)
 }
 
 func TestExecutionHandlerTestSuite(t *testing.T) {
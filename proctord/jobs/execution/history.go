@@ -0,0 +1,134 @@
+package execution
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"proctor/proctord/storage"
+)
+
+const (
+	DefaultHistoryLimit = 50
+	MaxHistoryLimit     = 200
+)
+
+// HistoryFilter is the query of GET /jobs/executions, shared by proctord and the cli
+type HistoryFilter struct {
+	JobName          string
+	UserEmail        string
+	ExecutionStatus  string
+	SubmissionStatus string
+	Labels           map[string]string
+	Reason           string
+	From             *time.Time
+	To               *time.Time
+	Cursor           string
+	Limit            int
+}
+
+type History struct {
+	Executions []Detail `json:"executions"`
+	NextCursor string   `json:"next_cursor,omitempty"`
+}
+
+func ParseHistoryFilter(query url.Values) (HistoryFilter, error) {
+	filter := HistoryFilter{
+		JobName:          query.Get("proc"),
+		UserEmail:        query.Get("user"),
+		ExecutionStatus:  query.Get("status"),
+		SubmissionStatus: query.Get("submission_status"),
+		Reason:           query.Get("reason"),
+		Cursor:           query.Get("cursor"),
+		Limit:            DefaultHistoryLimit,
+	}
+
+	for param, value := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if query.Get(param) == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, query.Get(param))
+		if err != nil {
+			return HistoryFilter{}, fmt.Errorf("%s must be an RFC3339 time", param)
+		}
+		*value = &parsed
+	}
+
+	labels, err := ParseLabels(query["label"])
+	if err != nil {
+		return HistoryFilter{}, err
+	}
+	filter.Labels = labels
+
+	if query.Get("limit") != "" {
+		limit, err := strconv.Atoi(query.Get("limit"))
+		if err != nil || limit < 1 || limit > MaxHistoryLimit {
+			return HistoryFilter{}, fmt.Errorf("limit must be between 1 and %d", MaxHistoryLimit)
+		}
+		filter.Limit = limit
+	}
+
+	if filter.Cursor != "" {
+		_, err := filter.beforeID()
+		if err != nil {
+			return HistoryFilter{}, fmt.Errorf("cursor is invalid")
+		}
+	}
+
+	return filter, nil
+}
+
+func (filter HistoryFilter) QueryParams() url.Values {
+	query := url.Values{}
+	params := map[string]string{
+		"proc":              filter.JobName,
+		"user":              filter.UserEmail,
+		"status":            filter.ExecutionStatus,
+		"submission_status": filter.SubmissionStatus,
+		"reason":            filter.Reason,
+		"cursor":            filter.Cursor,
+	}
+	for param, value := range params {
+		if value != "" {
+			query.Set(param, value)
+		}
+	}
+	for key, value := range filter.Labels {
+		query.Add("label", key+"="+value)
+	}
+	if filter.From != nil {
+		query.Set("from", filter.From.Format(time.RFC3339))
+	}
+	if filter.To != nil {
+		query.Set("to", filter.To.Format(time.RFC3339))
+	}
+	if filter.Limit > 0 {
+		query.Set("limit", strconv.Itoa(filter.Limit))
+	}
+	return query
+}
+
+func (filter HistoryFilter) beforeID() (int64, error) {
+	if filter.Cursor == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(filter.Cursor, 10, 64)
+}
+
+// storageFilter fetches one execution more than the limit to find out if there is a next page
+func (filter HistoryFilter) storageFilter() storage.JobsExecutionAuditLogFilter {
+	beforeID, _ := filter.beforeID()
+	return storage.JobsExecutionAuditLogFilter{
+		JobName:          filter.JobName,
+		UserEmail:        filter.UserEmail,
+		ExecutionStatus:  filter.ExecutionStatus,
+		SubmissionStatus: filter.SubmissionStatus,
+		Labels:           filter.Labels,
+		Reason:           filter.Reason,
+		CreatedAfter:     filter.From,
+		CreatedBefore:    filter.To,
+		BeforeID:         beforeID,
+		Limit:            filter.Limit + 1,
+	}
+}
@@ -0,0 +1,72 @@
+package execution
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"proctor/proctord/storage"
+	"proctor/proctord/utility"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHistoryFilter(t *testing.T) {
+	query := url.Values{}
+	query.Set("proc", "any-job")
+	query.Set("user", "mrproctor@example.com")
+	query.Set("status", utility.JobFailed)
+	query.Set("label", "ticket=OPS-123")
+	query.Set("reason", "payments")
+	query.Set("from", "2019-03-01T00:00:00Z")
+	query.Set("cursor", "42")
+	query.Set("limit", "10")
+
+	filter, err := ParseHistoryFilter(query)
+	assert.NoError(t, err)
+
+	from := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)
+	expectedFilter := HistoryFilter{
+		JobName:         "any-job",
+		UserEmail:       "mrproctor@example.com",
+		ExecutionStatus: utility.JobFailed,
+		Labels:          map[string]string{"ticket": "OPS-123"},
+		Reason:          "payments",
+		From:            &from,
+		Cursor:          "42",
+		Limit:           10,
+	}
+	assert.Equal(t, expectedFilter, filter)
+	assert.Equal(t, query, filter.QueryParams())
+
+	assert.Equal(t, storage.JobsExecutionAuditLogFilter{
+		JobName:         "any-job",
+		UserEmail:       "mrproctor@example.com",
+		ExecutionStatus: utility.JobFailed,
+		Labels:          map[string]string{"ticket": "OPS-123"},
+		Reason:          "payments",
+		CreatedAfter:    &from,
+		BeforeID:        42,
+		Limit:           11,
+	}, filter.storageFilter())
+}
+
+func TestParseHistoryFilterDefaults(t *testing.T) {
+	filter, err := ParseHistoryFilter(url.Values{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, HistoryFilter{Limit: DefaultHistoryLimit}, filter)
+}
+
+func TestParseHistoryFilterForInvalidQuery(t *testing.T) {
+	for query, expectedError := range map[string]string{
+		"to=yesterday": "to must be an RFC3339 time",
+		"limit=0":      "limit must be between 1 and 200",
+		"limit=500":    "limit must be between 1 and 200",
+		"cursor=abc":   "cursor is invalid",
+		"label=OPS":    "label OPS must be KEY=VALUE",
+	} {
+		values, _ := url.ParseQuery(query)
+		_, err := ParseHistoryFilter(values)
+		assert.EqualError(t, err, expectedError, query)
+	}
+}
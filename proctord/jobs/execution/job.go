@@ -1,8 +1,74 @@
 package execution
 
+import (
+	"fmt"
+	"strings"
+
+	"proctor/proctord/jobs/metadata"
+	"proctor/proctord/kubernetes"
+)
+
 type Job struct {
 	Name        string            `json:"name"`
 	Args        map[string]string `json:"args"`
 	CallbackURL string            `json:"callback_url"`
+	Limits
+	Provenance
+}
+
+// Limits override the timeout and retries of a single execution, up to those of the proc
+type Limits struct {
+	TimeoutSeconds *int64 `json:"timeout_seconds,omitempty"`
+	Retries        *int32 `json:"retries,omitempty"`
+}
+
+func (limits Limits) Validate(jobMetadata *metadata.Metadata) error {
+	if limits.TimeoutSeconds != nil {
+		maxTimeoutSeconds := jobMetadata.MaxTimeoutSeconds()
+		if *limits.TimeoutSeconds <= 0 || *limits.TimeoutSeconds > maxTimeoutSeconds {
+			return fmt.Errorf("timeout_seconds must be between 1 and %d", maxTimeoutSeconds)
+		}
+	}
+	if limits.Retries != nil {
+		maxRetries := jobMetadata.MaxRetries()
+		if *limits.Retries < 0 || *limits.Retries > maxRetries {
+			return fmt.Errorf("retries must be between 0 and %d", maxRetries)
+		}
+	}
+	return nil
 }
 
+// Provenance ties an execution to what prompted it, e.g. labels like ticket=OPS-123 and a reason
+type Provenance struct {
+	Labels map[string]string `json:"labels,omitempty"`
+	Reason string            `json:"reason,omitempty"`
+}
+
+func (provenance Provenance) Validate(jobMetadata *metadata.Metadata) error {
+	if jobMetadata.ReasonRequired && strings.TrimSpace(provenance.Reason) == "" {
+		return fmt.Errorf("a reason is required to execute %s", jobMetadata.Name)
+	}
+	for key := range provenance.Labels {
+		if kubernetes.SanitizeLabel(key) == "" {
+			return fmt.Errorf("label key %q must contain a letter or digit", key)
+		}
+	}
+	return nil
+}
+
+// ParseLabels reads labels given as KEY=VALUE, none give a nil map
+func ParseLabels(keyValues []string) (map[string]string, error) {
+	if len(keyValues) == 0 {
+		return nil, nil
+	}
+
+	labels := make(map[string]string)
+	for _, keyValue := range keyValues {
+		parts := strings.SplitN(keyValue, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("label %s must be KEY=VALUE", keyValue)
+		}
+		labels[parts[0]] = parts[1]
+	}
+	return labels, nil
+}
@@ -0,0 +1,83 @@
+package execution
+
+import (
+	"fmt"
+	"github.com/getsentry/raven-go"
+	"os"
+	"time"
+
+	"proctor/proctord/backend"
+	"proctor/proctord/logger"
+	"proctor/proctord/storage"
+)
+
+type statusController struct {
+	store            storage.Store
+	executionBackend backend.ExecutionBackend
+}
+
+type StatusController interface {
+	Run(<-chan time.Time, <-chan os.Signal)
+}
+
+// NewStatusController keeps the execution status of jobs_execution_audit_log in step with the jobs created by proctor
+func NewStatusController(store storage.Store, executionBackend backend.ExecutionBackend) StatusController {
+	return &statusController{
+		store:            store,
+		executionBackend: executionBackend,
+	}
+}
+
+// Run reconciles on start and on every tick, which also picks up changes seen before their executions were audited
+func (controller *statusController) Run(tickerChan <-chan time.Time, signalsChan <-chan os.Signal) {
+	controller.reconcile()
+
+	statusChan := make(chan backend.JobStatus)
+	stopChan := make(chan struct{})
+	go controller.executionBackend.WatchJobStatuses(statusChan, stopChan)
+
+	for {
+		select {
+		case jobStatus := <-statusChan:
+			controller.update(jobStatus.JobExecutionID, jobStatus.Status)
+		case <-tickerChan:
+			controller.reconcile()
+		case <-signalsChan:
+			close(stopChan)
+			return
+		}
+	}
+}
+
+// reconcile catches up on executions whose jobs changed while nothing was watching them,
+// including jobs created before they were labelled for the watch and jobs which are gone
+func (controller *statusController) reconcile() {
+	jobsExecutionAuditLogs, err := controller.store.GetUnfinishedJobsExecutionAuditLogs()
+	if err != nil {
+		logger.Error("Error getting unfinished executions from store: ", err.Error())
+		raven.CaptureError(err, nil)
+		return
+	}
+
+	for _, jobsExecutionAuditLog := range jobsExecutionAuditLogs {
+		jobExecutionID := jobsExecutionAuditLog.ExecutionID.String
+		status, err := controller.executionBackend.GetJobStatus(jobExecutionID)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error getting status of job: %s", jobExecutionID), err.Error())
+			raven.CaptureError(err, map[string]string{"job_id": jobExecutionID})
+			continue
+		}
+
+		if status != jobsExecutionAuditLog.JobExecutionStatus {
+			controller.update(jobExecutionID, status)
+		}
+	}
+}
+
+func (controller *statusController) update(jobExecutionID, status string) {
+	err := controller.store.UpdateJobsExecutionAuditLog(jobExecutionID, status)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Error updating status of execution: %s to %s", jobExecutionID, status), err.Error())
+		raven.CaptureError(err, map[string]string{"job_id": jobExecutionID})
+	}
+}
@@ -0,0 +1,91 @@
+package execution
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"proctor/proctord/backend"
+	"proctor/proctord/storage"
+	"proctor/proctord/storage/postgres"
+	"proctor/proctord/utility"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusControllerTestSuite struct {
+	suite.Suite
+	mockStore            *storage.MockStore
+	mockExecutionBackend *backend.MockExecutionBackend
+	testStatusController StatusController
+}
+
+func (suite *StatusControllerTestSuite) SetupTest() {
+	suite.mockStore = &storage.MockStore{}
+	suite.mockExecutionBackend = &backend.MockExecutionBackend{}
+	suite.testStatusController = NewStatusController(suite.mockStore, suite.mockExecutionBackend)
+}
+
+func (suite *StatusControllerTestSuite) TestRunReconcilesAndUpdatesStatusOnEveryChange() {
+	t := suite.T()
+
+	suite.mockStore.On("GetUnfinishedJobsExecutionAuditLogs").Return([]postgres.JobsExecutionAuditLog{
+		{ExecutionID: postgres.StringToSQLString("proctor-job-1"), JobExecutionStatus: utility.JobWaiting},
+		{ExecutionID: postgres.StringToSQLString("proctor-job-2"), JobExecutionStatus: utility.JobRunning},
+		{ExecutionID: postgres.StringToSQLString("proctor-job-3"), JobExecutionStatus: utility.JobRunning},
+	}, nil).Once()
+	suite.mockExecutionBackend.On("GetJobStatus", "proctor-job-1").Return(utility.JobSucceeded, nil).Once()
+	suite.mockExecutionBackend.On("GetJobStatus", "proctor-job-2").Return(utility.JobRunning, nil).Once()
+	suite.mockExecutionBackend.On("GetJobStatus", "proctor-job-3").Return("", errors.New("error")).Once()
+	suite.mockStore.On("UpdateJobsExecutionAuditLog", "proctor-job-1", utility.JobSucceeded).Return(nil).Once()
+
+	suite.mockExecutionBackend.On("WatchJobStatuses", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		statusChan := args.Get(0).(chan<- backend.JobStatus)
+		statusChan <- backend.JobStatus{JobExecutionID: "proctor-job-2", Status: utility.JobFailed}
+	}).Once()
+	updatedChan := make(chan bool)
+	suite.mockStore.On("UpdateJobsExecutionAuditLog", "proctor-job-2", utility.JobFailed).Return(nil).Run(func(args mock.Arguments) {
+		updatedChan <- true
+	}).Once()
+
+	tickerChan := make(chan time.Time)
+	signalsChan := make(chan os.Signal, 1)
+	go func() {
+		<-updatedChan
+		signalsChan <- syscall.SIGTERM
+	}()
+
+	suite.testStatusController.Run(tickerChan, signalsChan)
+
+	suite.mockStore.AssertExpectations(t)
+	suite.mockExecutionBackend.AssertExpectations(t)
+}
+
+func (suite *StatusControllerTestSuite) TestRunReconcilesOnTick() {
+	t := suite.T()
+
+	suite.mockStore.On("GetUnfinishedJobsExecutionAuditLogs").Return([]postgres.JobsExecutionAuditLog{}, nil).Once()
+	suite.mockStore.On("GetUnfinishedJobsExecutionAuditLogs").Return([]postgres.JobsExecutionAuditLog{
+		{ExecutionID: postgres.StringToSQLString("proctor-job-1"), JobExecutionStatus: utility.JobWaiting},
+	}, nil).Once()
+	suite.mockExecutionBackend.On("GetJobStatus", "proctor-job-1").Return(utility.JobNotFound, nil).Once()
+	suite.mockStore.On("UpdateJobsExecutionAuditLog", "proctor-job-1", utility.JobNotFound).Return(nil).Once()
+	suite.mockExecutionBackend.On("WatchJobStatuses", mock.Anything, mock.Anything).Return().Once()
+
+	tickerChan := make(chan time.Time)
+	signalsChan := make(chan os.Signal, 1)
+	go func() {
+		tickerChan <- time.Now()
+		signalsChan <- syscall.SIGTERM
+	}()
+
+	suite.testStatusController.Run(tickerChan, signalsChan)
+
+	suite.mockStore.AssertExpectations(t)
+}
+
+func TestStatusControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusControllerTestSuite))
+}
@@ -0,0 +1,133 @@
+package gc
+
+import (
+	"fmt"
+	"github.com/getsentry/raven-go"
+	"io"
+	"os"
+	"time"
+
+	"proctor/proctord/backend"
+	"proctor/proctord/jobs/logs"
+	"proctor/proctord/logger"
+	"proctor/proctord/storage"
+	"proctor/proctord/utility"
+)
+
+type collector struct {
+	executionBackend backend.ExecutionBackend
+	store            storage.Store
+	succeededTTL     time.Duration
+	failedTTL        time.Duration
+	logsArchiveDir   string
+}
+
+type Collector interface {
+	Collect(bool) ([]backend.FinishedJob, error)
+	Run(<-chan time.Time, <-chan os.Signal)
+}
+
+// NewCollector removes finished jobs and their pods once they outlive the ttl of their status,
+// a zero ttl keeps jobs of that status. Logs are archived to logsArchiveDir first when it is set
+func NewCollector(executionBackend backend.ExecutionBackend, store storage.Store, succeededTTL, failedTTL time.Duration, logsArchiveDir string) Collector {
+	return &collector{
+		executionBackend: executionBackend,
+		store:            store,
+		succeededTTL:     succeededTTL,
+		failedTTL:        failedTTL,
+		logsArchiveDir:   logsArchiveDir,
+	}
+}
+
+func (collector *collector) Run(tickerChan <-chan time.Time, signalsChan <-chan os.Signal) {
+	for {
+		select {
+		case <-tickerChan:
+			_, err := collector.Collect(false)
+			if err != nil {
+				logger.Error("Error collecting finished jobs: ", err.Error())
+				raven.CaptureError(err, nil)
+			}
+		case <-signalsChan:
+			return
+		}
+	}
+}
+
+// Collect returns the expired jobs, which are removed unless dryRun is set.
+// A job whose logs couldn't be archived is kept and left out, one without logs left to archive is removed
+func (collector *collector) Collect(dryRun bool) ([]backend.FinishedJob, error) {
+	finishedJobs, err := collector.executionBackend.ListFinishedJobs()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiredJobs := []backend.FinishedJob{}
+	for _, finishedJob := range finishedJobs {
+		ttl := collector.ttl(finishedJob.Status)
+		if ttl == 0 || now.Sub(finishedJob.FinishedAt) < ttl {
+			continue
+		}
+
+		if dryRun {
+			expiredJobs = append(expiredJobs, finishedJob)
+			continue
+		}
+
+		err := collector.remove(finishedJob)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error removing finished job: %s", finishedJob.Name), err.Error())
+			raven.CaptureError(err, map[string]string{"job_id": finishedJob.Name})
+			continue
+		}
+		expiredJobs = append(expiredJobs, finishedJob)
+	}
+	return expiredJobs, nil
+}
+
+func (collector *collector) ttl(status string) time.Duration {
+	if status == utility.JobSucceeded {
+		return collector.succeededTTL
+	}
+	return collector.failedTTL
+}
+
+func (collector *collector) remove(finishedJob backend.FinishedJob) error {
+	if collector.logsArchiveDir != "" {
+		err := collector.archiveLogs(finishedJob.Name)
+		if err == backend.ErrNoLogs {
+			logger.Info("No logs left to archive for finished job: ", finishedJob.Name)
+		} else if err != nil {
+			return fmt.Errorf("Error archiving logs: %v", err)
+		}
+	}
+
+	// recorded before deleting, the deletion of a job which isn't known to have finished reads as a cancellation
+	err := collector.store.UpdateJobsExecutionAuditLog(finishedJob.Name, finishedJob.Status)
+	if err != nil {
+		return err
+	}
+
+	return collector.executionBackend.DeleteJob(finishedJob.Name)
+}
+
+func (collector *collector) archiveLogs(jobName string) error {
+	logStream, err := collector.executionBackend.StreamJobLogs(jobName, false)
+	if err != nil {
+		return err
+	}
+	defer logStream.Close()
+
+	archive, err := os.Create(logs.ArchivePath(collector.logsArchiveDir, jobName))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(archive, logStream)
+	closeErr := archive.Close()
+	if err != nil {
+		return err
+	}
+	return closeErr
+}
@@ -0,0 +1,173 @@
+package gc
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"proctor/proctord/backend"
+	"proctor/proctord/storage"
+	"proctor/proctord/utility"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CollectorTestSuite struct {
+	suite.Suite
+	mockExecutionBackend *backend.MockExecutionBackend
+	mockStore            *storage.MockStore
+	finishedJobs         []backend.FinishedJob
+}
+
+func (suite *CollectorTestSuite) SetupTest() {
+	suite.mockExecutionBackend = &backend.MockExecutionBackend{}
+	suite.mockStore = &storage.MockStore{}
+
+	now := time.Now()
+	suite.finishedJobs = []backend.FinishedJob{
+		{Name: "proctor-job-1", Status: utility.JobSucceeded, FinishedAt: now.Add(-2 * time.Hour)},
+		{Name: "proctor-job-2", Status: utility.JobSucceeded, FinishedAt: now.Add(-10 * time.Minute)},
+		{Name: "proctor-job-3", Status: utility.JobFailed, FinishedAt: now.Add(-2 * time.Hour)},
+		{Name: "proctor-job-4", Status: utility.JobFailed, FinishedAt: now.Add(-48 * time.Hour)},
+	}
+}
+
+func (suite *CollectorTestSuite) TestCollectRemovesJobsPastTheirTTL() {
+	t := suite.T()
+
+	testCollector := NewCollector(suite.mockExecutionBackend, suite.mockStore, time.Hour, 24*time.Hour, "")
+
+	suite.mockExecutionBackend.On("ListFinishedJobs").Return(suite.finishedJobs, nil).Once()
+	suite.mockStore.On("UpdateJobsExecutionAuditLog", "proctor-job-1", utility.JobSucceeded).Return(nil).Once()
+	suite.mockExecutionBackend.On("DeleteJob", "proctor-job-1").Return(nil).Once()
+	suite.mockStore.On("UpdateJobsExecutionAuditLog", "proctor-job-4", utility.JobFailed).Return(nil).Once()
+	suite.mockExecutionBackend.On("DeleteJob", "proctor-job-4").Return(nil).Once()
+
+	removedJobs, err := testCollector.Collect(false)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []backend.FinishedJob{suite.finishedJobs[0], suite.finishedJobs[3]}, removedJobs)
+	suite.mockExecutionBackend.AssertExpectations(t)
+	suite.mockStore.AssertExpectations(t)
+}
+
+func (suite *CollectorTestSuite) TestCollectKeepsJobsOfStatusWithoutTTL() {
+	t := suite.T()
+
+	testCollector := NewCollector(suite.mockExecutionBackend, suite.mockStore, 0, 24*time.Hour, "")
+
+	suite.mockExecutionBackend.On("ListFinishedJobs").Return(suite.finishedJobs, nil).Once()
+
+	removedJobs, err := testCollector.Collect(true)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []backend.FinishedJob{suite.finishedJobs[3]}, removedJobs)
+}
+
+func (suite *CollectorTestSuite) TestCollectInDryRunRemovesNothing() {
+	t := suite.T()
+
+	testCollector := NewCollector(suite.mockExecutionBackend, suite.mockStore, time.Hour, 24*time.Hour, "")
+
+	suite.mockExecutionBackend.On("ListFinishedJobs").Return(suite.finishedJobs, nil).Once()
+
+	expiredJobs, err := testCollector.Collect(true)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []backend.FinishedJob{suite.finishedJobs[0], suite.finishedJobs[3]}, expiredJobs)
+	suite.mockExecutionBackend.AssertNotCalled(t, "DeleteJob", mock.Anything)
+	suite.mockStore.AssertNotCalled(t, "UpdateJobsExecutionAuditLog", mock.Anything, mock.Anything)
+}
+
+func (suite *CollectorTestSuite) TestCollectArchivesLogsBeforeRemovingJobs() {
+	t := suite.T()
+
+	archiveDir, err := ioutil.TempDir("", "proctor-logs")
+	assert.NoError(t, err)
+	defer os.RemoveAll(archiveDir)
+
+	testCollector := NewCollector(suite.mockExecutionBackend, suite.mockStore, time.Hour, 0, archiveDir)
+
+	logs := utility.NewBuffer()
+	logs.Write([]byte("first line\nsecond line\n"))
+
+	suite.mockExecutionBackend.On("ListFinishedJobs").Return(suite.finishedJobs, nil).Once()
+	suite.mockExecutionBackend.On("StreamJobLogs", "proctor-job-1", false).Return(logs, nil).Once()
+	suite.mockStore.On("UpdateJobsExecutionAuditLog", "proctor-job-1", utility.JobSucceeded).Return(nil).Once()
+	suite.mockExecutionBackend.On("DeleteJob", "proctor-job-1").Return(nil).Once()
+
+	removedJobs, err := testCollector.Collect(false)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []backend.FinishedJob{suite.finishedJobs[0]}, removedJobs)
+	archivedLogs, err := ioutil.ReadFile(filepath.Join(archiveDir, "proctor-job-1.log"))
+	assert.NoError(t, err)
+	assert.Equal(t, "first line\nsecond line\n", string(archivedLogs))
+	assert.True(t, logs.WasClosed())
+	suite.mockExecutionBackend.AssertExpectations(t)
+}
+
+func (suite *CollectorTestSuite) TestCollectKeepsJobWhenLogsCannotBeArchived() {
+	t := suite.T()
+
+	testCollector := NewCollector(suite.mockExecutionBackend, suite.mockStore, time.Hour, 0, "/non/existent/dir")
+
+	suite.mockExecutionBackend.On("ListFinishedJobs").Return(suite.finishedJobs, nil).Once()
+	suite.mockExecutionBackend.On("StreamJobLogs", "proctor-job-1", false).Return(utility.NewBuffer(), nil).Once()
+
+	removedJobs, err := testCollector.Collect(false)
+	assert.NoError(t, err)
+
+	assert.Empty(t, removedJobs)
+	suite.mockExecutionBackend.AssertNotCalled(t, "DeleteJob", mock.Anything)
+}
+
+func (suite *CollectorTestSuite) TestCollectRemovesJobWithoutLogsLeft() {
+	t := suite.T()
+
+	archiveDir, err := ioutil.TempDir("", "proctor-logs")
+	assert.NoError(t, err)
+	defer os.RemoveAll(archiveDir)
+
+	testCollector := NewCollector(suite.mockExecutionBackend, suite.mockStore, time.Hour, 0, archiveDir)
+
+	suite.mockExecutionBackend.On("ListFinishedJobs").Return(suite.finishedJobs, nil).Once()
+	suite.mockExecutionBackend.On("StreamJobLogs", "proctor-job-1", false).Return(utility.NewBuffer(), backend.ErrNoLogs).Once()
+	suite.mockStore.On("UpdateJobsExecutionAuditLog", "proctor-job-1", utility.JobSucceeded).Return(nil).Once()
+	suite.mockExecutionBackend.On("DeleteJob", "proctor-job-1").Return(nil).Once()
+
+	removedJobs, err := testCollector.Collect(false)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []backend.FinishedJob{suite.finishedJobs[0]}, removedJobs)
+	_, err = os.Stat(filepath.Join(archiveDir, "proctor-job-1.log"))
+	assert.True(t, os.IsNotExist(err))
+	suite.mockExecutionBackend.AssertExpectations(t)
+}
+
+func (suite *CollectorTestSuite) TestRunCollectsOnTick() {
+	t := suite.T()
+
+	testCollector := NewCollector(suite.mockExecutionBackend, suite.mockStore, time.Hour, 24*time.Hour, "")
+	suite.mockExecutionBackend.On("ListFinishedJobs").Return([]backend.FinishedJob{}, errors.New("error")).Once()
+
+	tickerChan := make(chan time.Time)
+	signalsChan := make(chan os.Signal, 1)
+	go func() {
+		tickerChan <- time.Now()
+		signalsChan <- syscall.SIGTERM
+	}()
+
+	testCollector.Run(tickerChan, signalsChan)
+
+	suite.mockExecutionBackend.AssertExpectations(t)
+}
+
+func TestCollectorTestSuite(t *testing.T) {
+	suite.Run(t, new(CollectorTestSuite))
+}
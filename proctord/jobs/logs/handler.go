@@ -2,14 +2,21 @@ package logs
 
 import (
 	"bufio"
+	"fmt"
 	"github.com/getsentry/raven-go"
 	"io"
 	"net/http"
-	"strings"
+	"os"
+	"path/filepath"
 
+	"proctor/proctord/audit"
+	"proctor/proctord/auth"
+	"proctor/proctord/backend"
 	"proctor/proctord/config"
-	"proctor/proctord/kubernetes"
+	"proctor/proctord/jobs/metadata"
 	_logger "proctor/proctord/logger"
+	"proctor/proctord/storage"
+	"proctor/proctord/storage/postgres"
 	"proctor/proctord/utility"
 
 	"github.com/gorilla/websocket"
@@ -21,19 +28,48 @@ var upgrader = websocket.Upgrader{
 }
 
 type logger struct {
-	kubeClient kubernetes.Client
+	executionBackend backend.ExecutionBackend
+	store            storage.Store
+	metadataStore    metadata.Store
+	auditor          audit.Auditor
+	authorizer       auth.Authorizer
+	logsArchiveDir   string
 }
 
 type Logger interface {
 	Stream() http.HandlerFunc
 }
 
-func NewLogger(kubeClient kubernetes.Client) Logger {
+// NewLogger streams logs from the execution backend, or from logsArchiveDir for jobs removed after their logs were archived
+func NewLogger(executionBackend backend.ExecutionBackend, store storage.Store, metadataStore metadata.Store, auditor audit.Auditor, authorizer auth.Authorizer, logsArchiveDir string) Logger {
 	return &logger{
-		kubeClient: kubeClient,
+		executionBackend: executionBackend,
+		store:            store,
+		metadataStore:    metadataStore,
+		auditor:          auditor,
+		authorizer:       authorizer,
+		logsArchiveDir:   logsArchiveDir,
 	}
 }
 
+// ArchivePath is where the logs of a job are archived before the job is removed
+func ArchivePath(logsArchiveDir, jobExecutionID string) string {
+	return filepath.Join(logsArchiveDir, filepath.Base(jobExecutionID)+".log")
+}
+
+func (l *logger) streamJobLogs(jobExecutionID string, follow bool) (io.ReadCloser, error) {
+	if l.logsArchiveDir != "" {
+		archive, err := os.Open(ArchivePath(l.logsArchiveDir, jobExecutionID))
+		if err == nil {
+			return archive, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	return l.executionBackend.StreamJobLogs(jobExecutionID, follow)
+}
+
 func CloseWebSocket(message string, conn *websocket.Conn) {
 	err := conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, message))
 	if err != nil {
@@ -43,8 +79,70 @@ func CloseWebSocket(message string, conn *websocket.Conn) {
 	return
 }
 
+func (l *logger) authorize(w http.ResponseWriter, req *http.Request, jobExecutionID string) bool {
+	user, _ := auth.FromContext(req.Context())
+
+	jobsExecutionAuditLog, err := l.store.GetJobsExecutionAuditLog(jobExecutionID)
+	if err != nil {
+		_logger.Error("Error fetching job execution: ", jobExecutionID, err.Error())
+		raven.CaptureError(err, map[string]string{"job_id": jobExecutionID})
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(utility.ServerError))
+		return false
+	}
+	if len(jobsExecutionAuditLog) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(utility.JobNotFoundError))
+		return false
+	}
+	jobName := jobsExecutionAuditLog[0].JobName
+
+	jobMetadata, err := l.metadataStore.GetJobMetadata(jobName)
+	if err != nil {
+		if err.Error() == "redigo: nil returned" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(utility.NonExistentProcClientError))
+			return false
+		}
+		_logger.Error("Error fetching metadata for proc: ", jobName, err.Error())
+		raven.CaptureError(err, map[string]string{"job_name": jobName})
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(utility.ServerError))
+		return false
+	}
+
+	authorized, err := l.authorizer.Authorize(user, jobMetadata.AuthorizedGroups)
+	if err != nil {
+		_logger.Error("Error authorizing user: ", user.Email, err.Error())
+		raven.CaptureError(err, map[string]string{"user_email": user.Email, "job_name": jobName})
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(utility.ServerError))
+		return false
+	}
+	if !authorized {
+		_logger.Info("User ", user.Email, " not authorized to read logs of job: ", jobExecutionID)
+		go l.auditor.JobsExecution(&postgres.JobsExecutionAuditLog{
+			JobName:             jobName,
+			UserEmail:           user.Email,
+			JobSubmissionStatus: utility.JobSubmissionForbidden,
+			Errors:              fmt.Sprintf("User not in authorized groups to read logs of %s: %v", jobExecutionID, jobMetadata.AuthorizedGroups),
+		})
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(utility.UnauthorizedProcClientError))
+		return false
+	}
+
+	return true
+}
+
 func (l *logger) Stream() http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
+		jobName := req.URL.Query().Get("job_name")
+		follow := req.URL.Query().Get("follow") != "false"
+		if jobName != "" && !l.authorize(w, req, jobName) {
+			return
+		}
+
 		conn, err := upgrader.Upgrade(w, req, nil)
 		if err != nil {
 			_logger.Error("Error upgrading connection to websocket protocol: ", err)
@@ -56,16 +154,19 @@ func (l *logger) Stream() http.HandlerFunc {
 		}
 		defer conn.Close()
 
-		jobName := strings.TrimLeft(req.URL.RawQuery, "job_name=")
 		if jobName == "" {
 			_logger.Error("No job name provided as part of URL: ", req.URL.RawQuery)
 			CloseWebSocket("No job name provided while requesting for logs", conn)
 			return
 		}
 
-		logStream, err := l.kubeClient.StreamJobLogs(jobName)
+		logStream, err := l.streamJobLogs(jobName, follow)
+		if err == backend.ErrNoLogs {
+			CloseWebSocket("No logs left for the execution", conn)
+			return
+		}
 		if err != nil {
-			_logger.Error("Error streaming logs from kube client: ", err)
+			_logger.Error("Error streaming logs from execution backend: ", err)
 			raven.CaptureError(err, map[string]string{"job_name": jobName})
 
 			CloseWebSocket("Something went wrong", conn)
@@ -2,12 +2,19 @@ package logs
 
 import (
 	"errors"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
 
-	"proctor/proctord/kubernetes"
+	"proctor/proctord/audit"
+	"proctor/proctord/auth"
+	"proctor/proctord/backend"
+	"proctor/proctord/jobs/metadata"
+	"proctor/proctord/storage"
+	"proctor/proctord/storage/postgres"
 	"proctor/proctord/utility"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
@@ -17,13 +24,30 @@ import (
 
 type LoggerTestSuite struct {
 	suite.Suite
-	testLogger     Logger
-	mockKubeClient *kubernetes.MockClient
+	testLogger           Logger
+	mockExecutionBackend *backend.MockExecutionBackend
+	mockStore            *storage.MockStore
+	mockMetadataStore    *metadata.MockStore
+	mockAuditor          *audit.MockAuditor
+	mockAuthorizer       *auth.MockAuthorizer
 }
 
 func (suite *LoggerTestSuite) SetupTest() {
-	suite.mockKubeClient = &kubernetes.MockClient{}
-	suite.testLogger = NewLogger(suite.mockKubeClient)
+	suite.mockExecutionBackend = &backend.MockExecutionBackend{}
+	suite.mockStore = &storage.MockStore{}
+	suite.mockMetadataStore = &metadata.MockStore{}
+	suite.mockAuditor = &audit.MockAuditor{}
+	suite.mockAuthorizer = &auth.MockAuthorizer{}
+	suite.testLogger = NewLogger(suite.mockExecutionBackend, suite.mockStore, suite.mockMetadataStore, suite.mockAuditor, suite.mockAuthorizer, "")
+}
+
+func (suite *LoggerTestSuite) expectAuthorization(jobExecutionID string, authorized bool) {
+	jobsExecutionAuditLog := []postgres.JobsExecutionAuditLog{{JobName: "sample-job", ExecutionID: postgres.StringToSQLString(jobExecutionID)}}
+	jobMetadata := &metadata.Metadata{Name: "sample-job", AuthorizedGroups: []string{"group_one"}}
+
+	suite.mockStore.On("GetJobsExecutionAuditLog", jobExecutionID).Return(jobsExecutionAuditLog, nil).Once()
+	suite.mockMetadataStore.On("GetJobMetadata", "sample-job").Return(jobMetadata, nil).Once()
+	suite.mockAuthorizer.On("Authorize", auth.User{}, jobMetadata.AuthorizedGroups).Return(authorized, nil).Once()
 }
 
 type logsHandlerServer struct {
@@ -61,7 +85,8 @@ func (suite *LoggerTestSuite) TestLoggerStream() {
 
 	buffer := utility.NewBuffer()
 	buffer.Write([]byte("first line\nsecond line\n"))
-	suite.mockKubeClient.On("StreamJobLogs", "sample").Return(buffer, nil).Once()
+	suite.expectAuthorization("sample", true)
+	suite.mockExecutionBackend.On("StreamJobLogs", "sample", true).Return(buffer, nil).Once()
 
 	c, _, err := websocket.DefaultDialer.Dial(s.URL+"?"+logsHandlerRawQuery, nil)
 	assert.NoError(t, err)
@@ -80,10 +105,83 @@ func (suite *LoggerTestSuite) TestLoggerStream() {
 	assert.Equal(t, "", string(finalMessage))
 	assert.Equal(t, "websocket: close 1000 (normal): All logs are read", err.Error())
 
-	suite.mockKubeClient.AssertExpectations(t)
+	suite.mockExecutionBackend.AssertExpectations(t)
 	assert.True(t, buffer.WasClosed())
 }
 
+func (suite *LoggerTestSuite) TestLoggerStreamWithoutFollow() {
+	t := suite.T()
+
+	s := suite.newServer()
+	defer s.Close()
+
+	buffer := utility.NewBuffer()
+	buffer.Write([]byte("first line\n"))
+	suite.expectAuthorization("sample", true)
+	suite.mockExecutionBackend.On("StreamJobLogs", "sample", false).Return(buffer, nil).Once()
+
+	c, _, err := websocket.DefaultDialer.Dial(s.URL+"?"+logsHandlerRawQuery+"&follow=false", nil)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	_, firstMessage, err := c.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, "first line", string(firstMessage))
+
+	_, _, err = c.ReadMessage()
+	assert.Equal(t, "websocket: close 1000 (normal): All logs are read", err.Error())
+
+	suite.mockExecutionBackend.AssertExpectations(t)
+}
+
+func (suite *LoggerTestSuite) TestLoggerStreamFromArchive() {
+	t := suite.T()
+
+	archiveDir, err := ioutil.TempDir("", "proctor-logs")
+	assert.NoError(t, err)
+	defer os.RemoveAll(archiveDir)
+	err = ioutil.WriteFile(ArchivePath(archiveDir, "sample"), []byte("archived line\n"), 0644)
+	assert.NoError(t, err)
+
+	suite.testLogger = NewLogger(suite.mockExecutionBackend, suite.mockStore, suite.mockMetadataStore, suite.mockAuditor, suite.mockAuthorizer, archiveDir)
+	s := suite.newServer()
+	defer s.Close()
+
+	suite.expectAuthorization("sample", true)
+
+	c, _, err := websocket.DefaultDialer.Dial(s.URL+"?"+logsHandlerRawQuery, nil)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	_, firstMessage, err := c.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, "archived line", string(firstMessage))
+
+	_, _, err = c.ReadMessage()
+	assert.Equal(t, "websocket: close 1000 (normal): All logs are read", err.Error())
+
+	suite.mockExecutionBackend.AssertNotCalled(t, "StreamJobLogs", mock.Anything, mock.Anything)
+}
+
+func (suite *LoggerTestSuite) TestLoggerStreamWithoutLogsLeft() {
+	t := suite.T()
+
+	s := suite.newServer()
+	defer s.Close()
+
+	suite.expectAuthorization("sample", true)
+	suite.mockExecutionBackend.On("StreamJobLogs", "sample", true).Return(&utility.Buffer{}, backend.ErrNoLogs).Once()
+
+	c, _, err := websocket.DefaultDialer.Dial(s.URL+"?"+logsHandlerRawQuery, nil)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	_, _, err = c.ReadMessage()
+	assert.Equal(t, "websocket: close 1000 (normal): No logs left for the execution", err.Error())
+
+	suite.mockExecutionBackend.AssertExpectations(t)
+}
+
 func (suite *LoggerTestSuite) TestLoggerStreamConnectionUpgradeFailure() {
 	t := suite.T()
 
@@ -92,7 +190,7 @@ func (suite *LoggerTestSuite) TestLoggerStreamConnectionUpgradeFailure() {
 
 	suite.testLogger.Stream()(responseRecorder, req)
 
-	suite.mockKubeClient.AssertNotCalled(t, "StreamJobLogs", mock.Anything)
+	suite.mockExecutionBackend.AssertNotCalled(t, "StreamJobLogs", mock.Anything, mock.Anything)
 
 	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
 	assert.Equal(t, "Bad Request\n"+utility.ClientError, responseRecorder.Body.String())
@@ -108,7 +206,7 @@ func (suite *LoggerTestSuite) TestLoggerStreamForNoJobName() {
 	assert.NoError(t, err)
 	defer c.Close()
 
-	suite.mockKubeClient.AssertNotCalled(t, "StreamJobLogs", mock.Anything)
+	suite.mockExecutionBackend.AssertNotCalled(t, "StreamJobLogs", mock.Anything, mock.Anything)
 
 	_, finalMessage, err := c.ReadMessage()
 	assert.Error(t, err)
@@ -116,13 +214,14 @@ func (suite *LoggerTestSuite) TestLoggerStreamForNoJobName() {
 	assert.Equal(t, "websocket: close 1000 (normal): No job name provided while requesting for logs", err.Error())
 }
 
-func (suite *LoggerTestSuite) TestLoggerStreamKubeClientFailure() {
+func (suite *LoggerTestSuite) TestLoggerStreamBackendFailure() {
 	t := suite.T()
 
 	s := suite.newServer()
 	defer s.Close()
 
-	suite.mockKubeClient.On("StreamJobLogs", "sample").Return(&utility.Buffer{}, errors.New("error")).Once()
+	suite.expectAuthorization("sample", true)
+	suite.mockExecutionBackend.On("StreamJobLogs", "sample", true).Return(&utility.Buffer{}, errors.New("error")).Once()
 
 	c, _, err := websocket.DefaultDialer.Dial(s.URL+"?"+logsHandlerRawQuery, nil)
 	assert.NoError(t, err)
@@ -133,7 +232,45 @@ func (suite *LoggerTestSuite) TestLoggerStreamKubeClientFailure() {
 	assert.Equal(t, "", string(finalMessage))
 	assert.Equal(t, "websocket: close 1000 (normal): Something went wrong", err.Error())
 
-	suite.mockKubeClient.AssertExpectations(t)
+	suite.mockExecutionBackend.AssertExpectations(t)
+}
+
+func (suite *LoggerTestSuite) TestLoggerStreamForUnauthorizedUser() {
+	t := suite.T()
+
+	req := httptest.NewRequest("GET", "/jobs/logs?"+logsHandlerRawQuery, nil)
+	responseRecorder := httptest.NewRecorder()
+
+	suite.expectAuthorization("sample", false)
+	auditingChan := make(chan *postgres.JobsExecutionAuditLog)
+	suite.mockAuditor.On("JobsExecution", mock.Anything).Return().Run(
+		func(args mock.Arguments) { auditingChan <- args.Get(0).(*postgres.JobsExecutionAuditLog) },
+	)
+
+	suite.testLogger.Stream()(responseRecorder, req)
+
+	auditedJobsExecution := <-auditingChan
+	assert.Equal(t, utility.JobSubmissionForbidden, auditedJobsExecution.JobSubmissionStatus)
+	assert.Equal(t, "sample-job", auditedJobsExecution.JobName)
+
+	suite.mockExecutionBackend.AssertNotCalled(t, "StreamJobLogs", mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	assert.Equal(t, utility.UnauthorizedProcClientError, responseRecorder.Body.String())
+}
+
+func (suite *LoggerTestSuite) TestLoggerStreamForUnknownExecution() {
+	t := suite.T()
+
+	req := httptest.NewRequest("GET", "/jobs/logs?"+logsHandlerRawQuery, nil)
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("GetJobsExecutionAuditLog", "sample").Return([]postgres.JobsExecutionAuditLog{}, nil).Once()
+
+	suite.testLogger.Stream()(responseRecorder, req)
+
+	suite.mockExecutionBackend.AssertNotCalled(t, "StreamJobLogs", mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	assert.Equal(t, utility.JobNotFoundError, responseRecorder.Body.String())
 }
 
 func TestLoggerTestSuite(t *testing.T) {
@@ -0,0 +1,128 @@
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Validate checks that the constraints of an arg are consistent, including its default value
+func (varMetadata VarMetadata) Validate() error {
+	switch varMetadata.Type {
+	case "", StringType, IntType, BoolType, JSONType:
+	case EnumType:
+		if len(varMetadata.AllowedValues) == 0 {
+			return fmt.Errorf("%s of type enum must have allowed_values", varMetadata.Name)
+		}
+	default:
+		return fmt.Errorf("%s has unknown type %s", varMetadata.Name, varMetadata.Type)
+	}
+
+	if varMetadata.Pattern != "" {
+		if _, err := regexp.Compile(varMetadata.Pattern); err != nil {
+			return fmt.Errorf("%s has invalid pattern %s", varMetadata.Name, varMetadata.Pattern)
+		}
+	}
+
+	if varMetadata.Default != "" {
+		if err := varMetadata.ValidateValue(varMetadata.Default); err != nil {
+			return fmt.Errorf("default of %s", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// ValidateValue checks a value against the type, pattern and allowed values of an arg.
+// A pattern has to match the whole value
+func (varMetadata VarMetadata) ValidateValue(value string) error {
+	switch varMetadata.Type {
+	case IntType:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("%s must be an int", varMetadata.Name)
+		}
+	case BoolType:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%s must be a bool", varMetadata.Name)
+		}
+	case JSONType:
+		if !json.Valid([]byte(value)) {
+			return fmt.Errorf("%s must be valid json", varMetadata.Name)
+		}
+	}
+
+	if len(varMetadata.AllowedValues) > 0 && !contains(varMetadata.AllowedValues, value) {
+		return fmt.Errorf("%s must be one of [%s]", varMetadata.Name, strings.Join(varMetadata.AllowedValues, ", "))
+	}
+
+	if varMetadata.Pattern != "" {
+		matched, err := regexp.MatchString("^(?:"+varMetadata.Pattern+")$", value)
+		if err != nil || !matched {
+			return fmt.Errorf("%s must match pattern %s", varMetadata.Name, varMetadata.Pattern)
+		}
+	}
+
+	return nil
+}
+
+// ValidateArgs lists every problem with args: missing required args,
+// values breaking their constraints and args the proc does not declare
+func (vars Vars) ValidateArgs(args map[string]string) []string {
+	problems := []string{}
+	declared := map[string]bool{}
+
+	for _, arg := range vars.Args {
+		declared[arg.Name] = true
+
+		value, ok := args[arg.Name]
+		if !ok {
+			if arg.Required && arg.Default == "" {
+				problems = append(problems, fmt.Sprintf("%s is required", arg.Name))
+			}
+			continue
+		}
+
+		if err := arg.ValidateValue(value); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	unknownArgs := []string{}
+	for name := range args {
+		if !declared[name] {
+			unknownArgs = append(unknownArgs, name)
+		}
+	}
+	sort.Strings(unknownArgs)
+	for _, name := range unknownArgs {
+		problems = append(problems, fmt.Sprintf("%s is not an arg of this proc", name))
+	}
+
+	return problems
+}
+
+// WithDefaults returns a copy of args with the defaults of missing args filled in
+func (vars Vars) WithDefaults(args map[string]string) map[string]string {
+	argsWithDefaults := map[string]string{}
+	for _, arg := range vars.Args {
+		if arg.Default != "" {
+			argsWithDefaults[arg.Name] = arg.Default
+		}
+	}
+	for name, value := range args {
+		argsWithDefaults[name] = value
+	}
+	return argsWithDefaults
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
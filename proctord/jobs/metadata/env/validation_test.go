@@ -0,0 +1,70 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVarMetadataValidate(t *testing.T) {
+	assert.NoError(t, VarMetadata{Name: "NAME"}.Validate())
+	assert.NoError(t, VarMetadata{Name: "COUNT", Type: IntType, Default: "10"}.Validate())
+	assert.NoError(t, VarMetadata{Name: "ENV", Type: EnumType, AllowedValues: []string{"staging", "production"}}.Validate())
+
+	assert.EqualError(t, VarMetadata{Name: "COUNT", Type: "float"}.Validate(), "COUNT has unknown type float")
+	assert.EqualError(t, VarMetadata{Name: "ENV", Type: EnumType}.Validate(), "ENV of type enum must have allowed_values")
+	assert.EqualError(t, VarMetadata{Name: "ID", Pattern: "[0-9"}.Validate(), "ID has invalid pattern [0-9")
+	assert.EqualError(t, VarMetadata{Name: "COUNT", Type: IntType, Default: "ten"}.Validate(), "default of COUNT must be an int")
+}
+
+func TestVarMetadataValidateValue(t *testing.T) {
+	assert.NoError(t, VarMetadata{Name: "NAME"}.ValidateValue("anything"))
+	assert.NoError(t, VarMetadata{Name: "COUNT", Type: IntType}.ValidateValue("-42"))
+	assert.NoError(t, VarMetadata{Name: "DRY_RUN", Type: BoolType}.ValidateValue("true"))
+	assert.NoError(t, VarMetadata{Name: "PAYLOAD", Type: JSONType}.ValidateValue(`{"a": [1, 2]}`))
+	assert.NoError(t, VarMetadata{Name: "ENV", Type: EnumType, AllowedValues: []string{"staging", "production"}}.ValidateValue("staging"))
+	assert.NoError(t, VarMetadata{Name: "ID", Pattern: "[a-z]+-[0-9]+"}.ValidateValue("order-42"))
+
+	assert.EqualError(t, VarMetadata{Name: "COUNT", Type: IntType}.ValidateValue("4.2"), "COUNT must be an int")
+	assert.EqualError(t, VarMetadata{Name: "DRY_RUN", Type: BoolType}.ValidateValue("maybe"), "DRY_RUN must be a bool")
+	assert.EqualError(t, VarMetadata{Name: "PAYLOAD", Type: JSONType}.ValidateValue("{"), "PAYLOAD must be valid json")
+	assert.EqualError(t, VarMetadata{Name: "ENV", Type: EnumType, AllowedValues: []string{"staging", "production"}}.ValidateValue("prod"), "ENV must be one of [staging, production]")
+	assert.EqualError(t, VarMetadata{Name: "ID", Pattern: "[a-z]+-[0-9]+"}.ValidateValue("order-42-x"), "ID must match pattern [a-z]+-[0-9]+")
+}
+
+func TestValidateArgs(t *testing.T) {
+	vars := Vars{
+		Args: []VarMetadata{
+			{Name: "ENV", Required: true, Type: EnumType, AllowedValues: []string{"staging", "production"}},
+			{Name: "COUNT", Required: true, Type: IntType, Default: "10"},
+			{Name: "ORDER_ID", Required: true},
+			{Name: "DRY_RUN", Type: BoolType},
+		},
+	}
+
+	assert.Empty(t, vars.ValidateArgs(map[string]string{"ENV": "staging", "ORDER_ID": "42"}))
+
+	problems := vars.ValidateArgs(map[string]string{"ENV": "prod", "DRY_RUN": "maybe", "ORDERID": "42", "COUNTT": "1"})
+	assert.Equal(t, []string{
+		"ENV must be one of [staging, production]",
+		"ORDER_ID is required",
+		"DRY_RUN must be a bool",
+		"COUNTT is not an arg of this proc",
+		"ORDERID is not an arg of this proc",
+	}, problems)
+}
+
+func TestWithDefaults(t *testing.T) {
+	vars := Vars{
+		Args: []VarMetadata{
+			{Name: "COUNT", Default: "10"},
+			{Name: "ENV", Default: "staging"},
+			{Name: "ORDER_ID"},
+		},
+	}
+
+	args := map[string]string{"ENV": "production", "ORDER_ID": "42"}
+
+	assert.Equal(t, map[string]string{"COUNT": "10", "ENV": "production", "ORDER_ID": "42"}, vars.WithDefaults(args))
+	assert.Equal(t, map[string]string{"ENV": "production", "ORDER_ID": "42"}, args)
+}
@@ -1,11 +1,24 @@
 package env
 
+const (
+	StringType = "string"
+	IntType    = "int"
+	BoolType   = "bool"
+	EnumType   = "enum"
+	JSONType   = "json"
+)
+
 type Vars struct {
 	Secrets []VarMetadata `json:"secrets"`
 	Args    []VarMetadata `json:"args"`
 }
 
 type VarMetadata struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	Required      bool     `json:"required,omitempty"`
+	Default       string   `json:"default,omitempty"`
+	Type          string   `json:"type,omitempty"`
+	Pattern       string   `json:"pattern,omitempty"`
+	AllowedValues []string `json:"allowed_values,omitempty"`
 }
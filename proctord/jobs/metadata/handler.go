@@ -2,15 +2,22 @@ package metadata
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/getsentry/raven-go"
 	"net/http"
 
+	"proctor/proctord/audit"
+	"proctor/proctord/auth"
+	"proctor/proctord/config"
+	"proctor/proctord/jobs/metadata/resources"
 	"proctor/proctord/logger"
 	"proctor/proctord/utility"
 )
 
 type handler struct {
-	store Store
+	store      Store
+	auditor    audit.Auditor
+	authorizer auth.Authorizer
 }
 
 type Handler interface {
@@ -18,16 +25,36 @@ type Handler interface {
 	HandleBulkDisplay() http.HandlerFunc
 }
 
-func NewHandler(store Store) Handler {
+func NewHandler(store Store, auditor audit.Auditor, authorizer auth.Authorizer) Handler {
 	return &handler{
-		store: store,
+		store:      store,
+		auditor:    auditor,
+		authorizer: authorizer,
 	}
 }
 
 func (handler *handler) HandleSubmission() http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
+		user, _ := auth.FromContext(req.Context())
+		role, err := handler.authorizer.Role(user)
+		if err != nil {
+			logger.Error("Error resolving user role", err.Error())
+			raven.CaptureError(err, nil)
+
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+		if !role.Includes(auth.PublisherRole) {
+			logger.Info("User", user.Email, "with role", role, "is not allowed to submit metadata")
+
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(utility.InsufficientRoleClientError))
+			return
+		}
+
 		var jobMetadata []Metadata
-		err := json.NewDecoder(req.Body).Decode(&jobMetadata)
+		err = json.NewDecoder(req.Body).Decode(&jobMetadata)
 		defer req.Body.Close()
 		if err != nil {
 			logger.Error("Error parsing request body", err.Error())
@@ -38,6 +65,32 @@ func (handler *handler) HandleSubmission() http.HandlerFunc {
 		}
 
 		for _, metadata := range jobMetadata {
+			err = validate(metadata)
+			if err != nil {
+				logger.Info("User", user.Email, "submitted invalid metadata for", metadata.Name, err.Error())
+
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(fmt.Sprintf("%s for %s: %s", utility.InvalidMetadataClientError, metadata.Name, err.Error())))
+				return
+			}
+		}
+
+		for _, metadata := range jobMetadata {
+			action := audit.MetadataUpdated
+			existingMetadata, err := handler.store.GetJobMetadata(metadata.Name)
+			if err != nil {
+				if err.Error() != "redigo: nil returned" {
+					logger.Error("Error fetching metadata", err.Error())
+					raven.CaptureError(err, nil)
+
+					w.WriteHeader(http.StatusInternalServerError)
+					w.Write([]byte(utility.ServerError))
+					return
+				}
+				action = audit.MetadataCreated
+				existingMetadata = nil
+			}
+
 			err = handler.store.CreateOrUpdateJobMetadata(metadata)
 			if err != nil {
 				logger.Error("Error updating metadata", err.Error())
@@ -47,6 +100,8 @@ func (handler *handler) HandleSubmission() http.HandlerFunc {
 				w.Write([]byte(utility.ServerError))
 				return
 			}
+
+			handler.auditor.AdminAction(user.Email, metadata.Name, action, existingMetadata, metadata)
 		}
 
 		w.WriteHeader(http.StatusCreated)
@@ -79,3 +134,44 @@ func (handler *handler) HandleBulkDisplay() http.HandlerFunc {
 		w.Write(jobsMetadataInJSON)
 	}
 }
+
+func validate(metadata Metadata) error {
+	for _, arg := range metadata.EnvVars.Args {
+		err := arg.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	maximumResources := resources.Quantities{CPU: config.KubeJobMaxCPU(), Memory: config.KubeJobMaxMemory()}
+	err := metadata.Resources.Validate(maximumResources)
+	if err != nil {
+		return err
+	}
+
+	maxTimeoutSeconds := config.KubeJobMaxActiveDeadlineSeconds()
+	if metadata.TimeoutSeconds != nil {
+		if *metadata.TimeoutSeconds <= 0 {
+			return fmt.Errorf("timeout_seconds %d must be positive", *metadata.TimeoutSeconds)
+		}
+		if maxTimeoutSeconds > 0 && *metadata.TimeoutSeconds > maxTimeoutSeconds {
+			return fmt.Errorf("timeout_seconds %d exceeds the maximum of %d", *metadata.TimeoutSeconds, maxTimeoutSeconds)
+		}
+	}
+
+	maxRetries := config.KubeJobMaxRetries()
+	if metadata.Retries != nil {
+		if *metadata.Retries < 0 {
+			return fmt.Errorf("retries %d must not be negative", *metadata.Retries)
+		}
+		if maxRetries > 0 && *metadata.Retries > maxRetries {
+			return fmt.Errorf("retries %d exceeds the maximum of %d", *metadata.Retries, maxRetries)
+		}
+	}
+
+	if metadata.RequiresApproval && len(metadata.ApproverGroups) == 0 {
+		return fmt.Errorf("approver_groups are required when requires_approval is set")
+	}
+
+	return metadata.Spec.Validate(PodAllowlist())
+}
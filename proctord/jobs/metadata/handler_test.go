@@ -7,9 +7,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 
+	"proctor/proctord/audit"
+	"proctor/proctord/auth"
 	"proctor/proctord/jobs/metadata/env"
+	"proctor/proctord/jobs/metadata/pod"
+	"proctor/proctord/jobs/metadata/resources"
 
 	"proctor/proctord/utility"
 	"github.com/stretchr/testify/assert"
@@ -20,14 +25,20 @@ import (
 type MetadataHandlerTestSuite struct {
 	suite.Suite
 	mockStore           *MockStore
+	mockAuditor         *audit.MockAuditor
+	mockAuthorizer      *auth.MockAuthorizer
 	testMetadataHandler Handler
 	serverError         string
+	user                auth.User
 }
 
 func (s *MetadataHandlerTestSuite) SetupTest() {
 	s.mockStore = &MockStore{}
+	s.mockAuditor = &audit.MockAuditor{}
+	s.mockAuthorizer = &auth.MockAuthorizer{}
+	s.user = auth.User{Email: "mrproctor@example.com"}
 
-	s.testMetadataHandler = NewHandler(s.mockStore)
+	s.testMetadataHandler = NewHandler(s.mockStore, s.mockAuditor, s.mockAuthorizer)
 
 	s.serverError = "Something went wrong"
 }
@@ -56,6 +67,10 @@ func (s *MetadataHandlerTestSuite) TestSuccessfulMetadataSubmission() {
 		Description:      "This is a hello world script",
 		ImageName:        "proctor-jobs-run-sample",
 		EnvVars:          envVars,
+		Resources: resources.Requirements{
+			Requests: resources.Quantities{CPU: "100m", Memory: "128Mi"},
+			Limits:   resources.Quantities{CPU: "1", Memory: "1Gi"},
+		},
 		AuthorizedGroups: []string{"group_one", "group_two"},
 		Author:           "Test User<testuser@example.com>",
 		Contributors:     "Test User<testuser@example.com>",
@@ -67,24 +82,77 @@ func (s *MetadataHandlerTestSuite) TestSuccessfulMetadataSubmission() {
 	metadataSubmissionRequestBody, err := json.Marshal(jobsMetadata)
 	assert.NoError(t, err)
 	req := httptest.NewRequest("PUT", "/jobs/metadata", bytes.NewReader(metadataSubmissionRequestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), s.user))
+	responseRecorder := httptest.NewRecorder()
+
+	s.mockAuthorizer.On("Role", s.user).Return(auth.PublisherRole, nil).Once()
+	s.mockStore.On("GetJobMetadata", metadata.Name).Return(&Metadata{}, errors.New("redigo: nil returned")).Once()
+	s.mockStore.On("CreateOrUpdateJobMetadata", metadata).Return(nil).Once()
+	s.mockAuditor.On("AdminAction", s.user.Email, metadata.Name, audit.MetadataCreated, (*Metadata)(nil), metadata).Return().Once()
+
+	s.testMetadataHandler.HandleSubmission()(responseRecorder, req)
+
+	s.mockStore.AssertExpectations(t)
+	s.mockAuditor.AssertExpectations(t)
+
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+}
+
+func (s *MetadataHandlerTestSuite) TestSuccessfulMetadataUpdation() {
+	t := s.T()
+
+	existingMetadata := &Metadata{Name: "run-sample", ImageName: "proctor-jobs-run-sample:1"}
+	metadata := Metadata{Name: "run-sample", ImageName: "proctor-jobs-run-sample:2"}
+
+	metadataSubmissionRequestBody, err := json.Marshal([]Metadata{metadata})
+	assert.NoError(t, err)
+	req := httptest.NewRequest("PUT", "/jobs/metadata", bytes.NewReader(metadataSubmissionRequestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), s.user))
 	responseRecorder := httptest.NewRecorder()
 
+	s.mockAuthorizer.On("Role", s.user).Return(auth.AdminRole, nil).Once()
+	s.mockStore.On("GetJobMetadata", metadata.Name).Return(existingMetadata, nil).Once()
 	s.mockStore.On("CreateOrUpdateJobMetadata", metadata).Return(nil).Once()
+	s.mockAuditor.On("AdminAction", s.user.Email, metadata.Name, audit.MetadataUpdated, existingMetadata, metadata).Return().Once()
 
 	s.testMetadataHandler.HandleSubmission()(responseRecorder, req)
 
 	s.mockStore.AssertExpectations(t)
+	s.mockAuditor.AssertExpectations(t)
 
 	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
 }
 
+func (s *MetadataHandlerTestSuite) TestJobMetadataSubmissionForUserRole() {
+	t := s.T()
+
+	metadataSubmissionRequestBody, err := json.Marshal([]Metadata{{Name: "run-sample"}})
+	assert.NoError(t, err)
+	req := httptest.NewRequest("PUT", "/jobs/metadata", bytes.NewReader(metadataSubmissionRequestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), s.user))
+	responseRecorder := httptest.NewRecorder()
+
+	s.mockAuthorizer.On("Role", s.user).Return(auth.UserRole, nil).Once()
+
+	s.testMetadataHandler.HandleSubmission()(responseRecorder, req)
+
+	s.mockStore.AssertNotCalled(t, "CreateOrUpdateJobMetadata", mock.Anything)
+	s.mockAuditor.AssertNotCalled(t, "AdminAction", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	assert.Equal(t, utility.InsufficientRoleClientError, responseRecorder.Body.String())
+}
+
 func (s *MetadataHandlerTestSuite) TestJobMetadataSubmissionMalformedRequest() {
 	t := s.T()
 
 	jobMetadataSubmissionRequest := fmt.Sprintf("{ some-malformed-reque")
 	req := httptest.NewRequest("PUT", "/jobs/metadata", bytes.NewReader([]byte(jobMetadataSubmissionRequest)))
+	req = req.WithContext(auth.NewContext(req.Context(), s.user))
 	responseRecorder := httptest.NewRecorder()
 
+	s.mockAuthorizer.On("Role", s.user).Return(auth.PublisherRole, nil).Once()
+
 	s.testMetadataHandler.HandleSubmission()(responseRecorder, req)
 
 	s.mockStore.AssertNotCalled(t, "CreateOrUpdateJobMetadata", mock.Anything)
@@ -93,6 +161,142 @@ func (s *MetadataHandlerTestSuite) TestJobMetadataSubmissionMalformedRequest() {
 	assert.Equal(t, utility.ClientError, responseRecorder.Body.String())
 }
 
+func (s *MetadataHandlerTestSuite) TestJobMetadataSubmissionExceedingMaximumResources() {
+	t := s.T()
+	os.Setenv("PROCTOR_KUBE_JOB_MAX_MEMORY", "4Gi")
+
+	jobsMetadata := []Metadata{
+		{Name: "run-sample"},
+		{Name: "run-heavy", Resources: resources.Requirements{Limits: resources.Quantities{Memory: "64Gi"}}},
+	}
+
+	metadataSubmissionRequestBody, err := json.Marshal(jobsMetadata)
+	assert.NoError(t, err)
+	req := httptest.NewRequest("PUT", "/jobs/metadata", bytes.NewReader(metadataSubmissionRequestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), s.user))
+	responseRecorder := httptest.NewRecorder()
+
+	s.mockAuthorizer.On("Role", s.user).Return(auth.PublisherRole, nil).Once()
+
+	s.testMetadataHandler.HandleSubmission()(responseRecorder, req)
+
+	s.mockStore.AssertNotCalled(t, "CreateOrUpdateJobMetadata", mock.Anything)
+
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	assert.Equal(t, "invalid proc metadata for run-heavy: memory limit 64Gi exceeds the maximum of 4Gi", responseRecorder.Body.String())
+}
+
+func (s *MetadataHandlerTestSuite) TestJobMetadataSubmissionExceedingMaximumTimeout() {
+	t := s.T()
+	os.Setenv("PROCTOR_KUBE_JOB_MAX_ACTIVE_DEADLINE_SECONDS", "14400")
+
+	timeoutSeconds := int64(86400)
+	jobsMetadata := []Metadata{{Name: "run-backfill", TimeoutSeconds: &timeoutSeconds}}
+
+	metadataSubmissionRequestBody, err := json.Marshal(jobsMetadata)
+	assert.NoError(t, err)
+	req := httptest.NewRequest("PUT", "/jobs/metadata", bytes.NewReader(metadataSubmissionRequestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), s.user))
+	responseRecorder := httptest.NewRecorder()
+
+	s.mockAuthorizer.On("Role", s.user).Return(auth.PublisherRole, nil).Once()
+
+	s.testMetadataHandler.HandleSubmission()(responseRecorder, req)
+
+	s.mockStore.AssertNotCalled(t, "CreateOrUpdateJobMetadata", mock.Anything)
+
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	assert.Equal(t, "invalid proc metadata for run-backfill: timeout_seconds 86400 exceeds the maximum of 14400", responseRecorder.Body.String())
+}
+
+func (s *MetadataHandlerTestSuite) TestJobMetadataSubmissionWithInvalidArgMetadata() {
+	t := s.T()
+
+	envVars := env.Vars{Args: []env.VarMetadata{{Name: "ENV", Type: env.EnumType, AllowedValues: []string{"staging"}, Default: "production"}}}
+	jobsMetadata := []Metadata{{Name: "run-sample", EnvVars: envVars}}
+
+	metadataSubmissionRequestBody, err := json.Marshal(jobsMetadata)
+	assert.NoError(t, err)
+	req := httptest.NewRequest("PUT", "/jobs/metadata", bytes.NewReader(metadataSubmissionRequestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), s.user))
+	responseRecorder := httptest.NewRecorder()
+
+	s.mockAuthorizer.On("Role", s.user).Return(auth.PublisherRole, nil).Once()
+
+	s.testMetadataHandler.HandleSubmission()(responseRecorder, req)
+
+	s.mockStore.AssertNotCalled(t, "CreateOrUpdateJobMetadata", mock.Anything)
+
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	assert.Equal(t, "invalid proc metadata for run-sample: default of ENV must be one of [staging]", responseRecorder.Body.String())
+}
+
+func (s *MetadataHandlerTestSuite) TestJobMetadataSubmissionWithNegativeRetries() {
+	t := s.T()
+
+	retries := int32(-1)
+	jobsMetadata := []Metadata{{Name: "run-sample", Retries: &retries}}
+
+	metadataSubmissionRequestBody, err := json.Marshal(jobsMetadata)
+	assert.NoError(t, err)
+	req := httptest.NewRequest("PUT", "/jobs/metadata", bytes.NewReader(metadataSubmissionRequestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), s.user))
+	responseRecorder := httptest.NewRecorder()
+
+	s.mockAuthorizer.On("Role", s.user).Return(auth.PublisherRole, nil).Once()
+
+	s.testMetadataHandler.HandleSubmission()(responseRecorder, req)
+
+	s.mockStore.AssertNotCalled(t, "CreateOrUpdateJobMetadata", mock.Anything)
+
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	assert.Equal(t, "invalid proc metadata for run-sample: retries -1 must not be negative", responseRecorder.Body.String())
+}
+
+func (s *MetadataHandlerTestSuite) TestJobMetadataSubmissionRequiringApprovalWithoutApproverGroups() {
+	t := s.T()
+
+	jobsMetadata := []Metadata{{Name: "run-sample", RequiresApproval: true}}
+
+	metadataSubmissionRequestBody, err := json.Marshal(jobsMetadata)
+	assert.NoError(t, err)
+	req := httptest.NewRequest("PUT", "/jobs/metadata", bytes.NewReader(metadataSubmissionRequestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), s.user))
+	responseRecorder := httptest.NewRecorder()
+
+	s.mockAuthorizer.On("Role", s.user).Return(auth.PublisherRole, nil).Once()
+
+	s.testMetadataHandler.HandleSubmission()(responseRecorder, req)
+
+	s.mockStore.AssertNotCalled(t, "CreateOrUpdateJobMetadata", mock.Anything)
+
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	assert.Equal(t, "invalid proc metadata for run-sample: approver_groups are required when requires_approval is set", responseRecorder.Body.String())
+}
+
+func (s *MetadataHandlerTestSuite) TestJobMetadataSubmissionWithServiceAccountOutsideAllowlist() {
+	t := s.T()
+	os.Setenv("PROCTOR_KUBE_JOB_ALLOWED_SERVICE_ACCOUNTS", "reports")
+	defer os.Setenv("PROCTOR_KUBE_JOB_ALLOWED_SERVICE_ACCOUNTS", "")
+
+	jobsMetadata := []Metadata{{Name: "run-sample", Spec: pod.Spec{ServiceAccountName: "cluster-admin"}}}
+
+	metadataSubmissionRequestBody, err := json.Marshal(jobsMetadata)
+	assert.NoError(t, err)
+	req := httptest.NewRequest("PUT", "/jobs/metadata", bytes.NewReader(metadataSubmissionRequestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), s.user))
+	responseRecorder := httptest.NewRecorder()
+
+	s.mockAuthorizer.On("Role", s.user).Return(auth.PublisherRole, nil).Once()
+
+	s.testMetadataHandler.HandleSubmission()(responseRecorder, req)
+
+	s.mockStore.AssertNotCalled(t, "CreateOrUpdateJobMetadata", mock.Anything)
+
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	assert.Equal(t, "invalid proc metadata for run-sample: service account cluster-admin is not allowed", responseRecorder.Body.String())
+}
+
 func (s *MetadataHandlerTestSuite) TestJobMetadataSubmissionForStoreFailure() {
 	t := s.T()
 
@@ -103,8 +307,11 @@ func (s *MetadataHandlerTestSuite) TestJobMetadataSubmissionForStoreFailure() {
 	metadataSubmissionRequestBody, err := json.Marshal(jobMetadata)
 	assert.NoError(t, err)
 	req := httptest.NewRequest("PUT", "/jobs/metadata", bytes.NewReader(metadataSubmissionRequestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), s.user))
 	responseRecorder := httptest.NewRecorder()
 
+	s.mockAuthorizer.On("Role", s.user).Return(auth.PublisherRole, nil).Once()
+	s.mockStore.On("GetJobMetadata", metadata.Name).Return(&metadata, nil).Once()
 	s.mockStore.On("CreateOrUpdateJobMetadata", metadata).Return(errors.New("error")).Once()
 
 	s.testMetadataHandler.HandleSubmission()(responseRecorder, req)
@@ -1,14 +1,53 @@
 package metadata
 
-import "proctor/proctord/jobs/metadata/env"
+import (
+	"proctor/proctord/config"
+	"proctor/proctord/jobs/metadata/env"
+	"proctor/proctord/jobs/metadata/pod"
+	"proctor/proctord/jobs/metadata/resources"
+)
 
 type Metadata struct {
-	Name             string   `json:"name"`
-	Description      string   `json:"description"`
-	ImageName        string   `json:"image_name"`
-	EnvVars          env.Vars `json:"env_vars"`
-	AuthorizedGroups []string `json:"authorized_groups"`
-	Author           string   `json:"author"`
-	Contributors     string   `json:"contributors"`
-	Organization     string   `json:"organization"`
+	Name             string                 `json:"name"`
+	Description      string                 `json:"description"`
+	ImageName        string                 `json:"image_name"`
+	EnvVars          env.Vars               `json:"env_vars"`
+	Resources        resources.Requirements `json:"resources"`
+	TimeoutSeconds   *int64                 `json:"timeout_seconds,omitempty"`
+	Retries          *int32                 `json:"retries,omitempty"`
+	ReasonRequired   bool                   `json:"reason_required,omitempty"`
+	AuthorizedGroups []string               `json:"authorized_groups"`
+	RequiresApproval bool                   `json:"requires_approval,omitempty"`
+	ApproverGroups   []string               `json:"approver_groups,omitempty"`
+	Author           string                 `json:"author"`
+	Contributors     string                 `json:"contributors"`
+	Organization     string                 `json:"organization"`
+	pod.Spec
+}
+
+// MaxTimeoutSeconds is the proc's own timeout when set, otherwise the server default
+func (metadata Metadata) MaxTimeoutSeconds() int64 {
+	if metadata.TimeoutSeconds != nil {
+		return *metadata.TimeoutSeconds
+	}
+	return *config.KubeJobActiveDeadlineSeconds()
+}
+
+// MaxRetries is the proc's own retries when set, otherwise the server default
+func (metadata Metadata) MaxRetries() int32 {
+	if metadata.Retries != nil {
+		return *metadata.Retries
+	}
+	return *config.KubeJobRetries()
+}
+
+// PodAllowlist is what admins let procs set on the pods of their jobs
+func PodAllowlist() pod.Allowlist {
+	return pod.Allowlist{
+		ServiceAccounts:  config.KubeJobAllowedServiceAccounts(),
+		NodeSelectorKeys: config.KubeJobAllowedNodeSelectorKeys(),
+		TolerationKeys:   config.KubeJobAllowedTolerationKeys(),
+		ConfigMaps:       config.KubeJobAllowedConfigMaps(),
+		Secrets:          config.KubeJobAllowedSecrets(),
+	}
 }
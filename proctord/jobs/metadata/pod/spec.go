@@ -0,0 +1,155 @@
+package pod
+
+import (
+	"fmt"
+	"path"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const anyAllowed = "*"
+
+// Spec is what a proc can set on the pod of its job besides the container
+type Spec struct {
+	ServiceAccountName string            `json:"service_account_name,omitempty"`
+	NodeSelector       map[string]string `json:"node_selector,omitempty"`
+	Tolerations        []Toleration      `json:"tolerations,omitempty"`
+	Volumes            []Volume          `json:"volumes,omitempty"`
+}
+
+type Toleration struct {
+	Key      string `json:"key"`
+	Operator string `json:"operator,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Effect   string `json:"effect,omitempty"`
+}
+
+// Volume is mounted into the container from exactly one of a config map, a secret or an empty dir
+type Volume struct {
+	Name      string    `json:"name"`
+	MountPath string    `json:"mount_path"`
+	ConfigMap string    `json:"config_map,omitempty"`
+	Secret    string    `json:"secret,omitempty"`
+	EmptyDir  *EmptyDir `json:"empty_dir,omitempty"`
+}
+
+type EmptyDir struct {
+	SizeLimit string `json:"size_limit,omitempty"`
+}
+
+// Allowlist is what admins let procs use, "*" allows anything
+type Allowlist struct {
+	ServiceAccounts  []string
+	NodeSelectorKeys []string
+	TolerationKeys   []string
+	ConfigMaps       []string
+	Secrets          []string
+}
+
+func (spec Spec) Validate(allowlist Allowlist) error {
+	if spec.ServiceAccountName != "" && !allowed(allowlist.ServiceAccounts, spec.ServiceAccountName) {
+		return fmt.Errorf("service account %s is not allowed", spec.ServiceAccountName)
+	}
+
+	for key := range spec.NodeSelector {
+		if !allowed(allowlist.NodeSelectorKeys, key) {
+			return fmt.Errorf("node selector %s is not allowed", key)
+		}
+	}
+
+	for _, toleration := range spec.Tolerations {
+		err := toleration.validate(allowlist)
+		if err != nil {
+			return err
+		}
+	}
+
+	names := make(map[string]bool)
+	mountPaths := make(map[string]bool)
+	for _, volume := range spec.Volumes {
+		err := volume.validate(allowlist)
+		if err != nil {
+			return err
+		}
+		if names[volume.Name] {
+			return fmt.Errorf("volume %s is declared more than once", volume.Name)
+		}
+		if mountPaths[path.Clean(volume.MountPath)] {
+			return fmt.Errorf("mount path %s is used by more than one volume", volume.MountPath)
+		}
+		names[volume.Name] = true
+		mountPaths[path.Clean(volume.MountPath)] = true
+	}
+
+	return nil
+}
+
+func (toleration Toleration) validate(allowlist Allowlist) error {
+	if !allowed(allowlist.TolerationKeys, toleration.Key) {
+		return fmt.Errorf("toleration %s is not allowed", toleration.Key)
+	}
+
+	switch toleration.Operator {
+	case "", "Equal":
+	case "Exists":
+		if toleration.Value != "" {
+			return fmt.Errorf("toleration %s with operator Exists can't have a value", toleration.Key)
+		}
+	default:
+		return fmt.Errorf("toleration %s has invalid operator %s, use Equal or Exists", toleration.Key, toleration.Operator)
+	}
+
+	switch toleration.Effect {
+	case "", "NoSchedule", "PreferNoSchedule", "NoExecute":
+	default:
+		return fmt.Errorf("toleration %s has invalid effect %s, use NoSchedule, PreferNoSchedule or NoExecute", toleration.Key, toleration.Effect)
+	}
+
+	return nil
+}
+
+func (volume Volume) validate(allowlist Allowlist) error {
+	if problems := validation.IsDNS1123Label(volume.Name); len(problems) > 0 {
+		return fmt.Errorf("invalid volume name %s: %s", volume.Name, problems[0])
+	}
+	if !path.IsAbs(volume.MountPath) {
+		return fmt.Errorf("mount path %s of volume %s must be absolute", volume.MountPath, volume.Name)
+	}
+
+	sources := 0
+	if volume.ConfigMap != "" {
+		sources++
+		if !allowed(allowlist.ConfigMaps, volume.ConfigMap) {
+			return fmt.Errorf("config map %s is not allowed", volume.ConfigMap)
+		}
+	}
+	if volume.Secret != "" {
+		sources++
+		if !allowed(allowlist.Secrets, volume.Secret) {
+			return fmt.Errorf("secret %s is not allowed", volume.Secret)
+		}
+	}
+	if volume.EmptyDir != nil {
+		sources++
+		if volume.EmptyDir.SizeLimit != "" {
+			if _, err := resource.ParseQuantity(volume.EmptyDir.SizeLimit); err != nil {
+				return fmt.Errorf("invalid size limit %s of volume %s", volume.EmptyDir.SizeLimit, volume.Name)
+			}
+		}
+	}
+	if sources != 1 {
+		return fmt.Errorf("volume %s must have exactly one of config_map, secret or empty_dir", volume.Name)
+	}
+
+	return nil
+}
+
+func allowed(allowlist []string, value string) bool {
+	for _, allowedValue := range allowlist {
+		if allowedValue == anyAllowed || allowedValue == value {
+			return true
+		}
+	}
+	return false
+}
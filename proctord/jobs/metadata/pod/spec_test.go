@@ -0,0 +1,87 @@
+package pod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var allowlist = Allowlist{
+	ServiceAccounts:  []string{"reports"},
+	NodeSelectorKeys: []string{"cloud.google.com/gke-nodepool"},
+	TolerationKeys:   []string{"dedicated"},
+	ConfigMaps:       []string{"ca-bundle"},
+	Secrets:          []string{"*"},
+}
+
+func TestValidateAllowedSpec(t *testing.T) {
+	spec := Spec{
+		ServiceAccountName: "reports",
+		NodeSelector:       map[string]string{"cloud.google.com/gke-nodepool": "batch"},
+		Tolerations:        []Toleration{{Key: "dedicated", Value: "batch", Effect: "NoSchedule"}},
+		Volumes: []Volume{
+			{Name: "ca-bundle", MountPath: "/etc/ssl/certs", ConfigMap: "ca-bundle"},
+			{Name: "config", MountPath: "/etc/reports", Secret: "reports-config"},
+			{Name: "scratch", MountPath: "/scratch", EmptyDir: &EmptyDir{SizeLimit: "1Gi"}},
+		},
+	}
+
+	assert.NoError(t, spec.Validate(allowlist))
+	assert.NoError(t, Spec{}.Validate(Allowlist{}))
+}
+
+func TestValidateSpecOutsideAllowlist(t *testing.T) {
+	for expectedError, spec := range map[string]Spec{
+		"service account default is not allowed":                   {ServiceAccountName: "default"},
+		"node selector kubernetes.io/hostname is not allowed":      {NodeSelector: map[string]string{"kubernetes.io/hostname": "node-1"}},
+		"toleration node-role.kubernetes.io/master is not allowed": {Tolerations: []Toleration{{Key: "node-role.kubernetes.io/master"}}},
+		"config map kube-proxy is not allowed":                     {Volumes: []Volume{{Name: "proxy", MountPath: "/etc/proxy", ConfigMap: "kube-proxy"}}},
+	} {
+		assert.EqualError(t, spec.Validate(allowlist), expectedError)
+	}
+}
+
+func TestValidateInvalidSpec(t *testing.T) {
+	for _, testCase := range []struct {
+		spec          Spec
+		expectedError string
+	}{
+		{
+			Spec{Tolerations: []Toleration{{Key: "dedicated", Operator: "Exists", Value: "batch"}}},
+			"toleration dedicated with operator Exists can't have a value",
+		},
+		{
+			Spec{Tolerations: []Toleration{{Key: "dedicated", Operator: "In"}}},
+			"toleration dedicated has invalid operator In, use Equal or Exists",
+		},
+		{
+			Spec{Volumes: []Volume{{Name: "scratch", MountPath: "scratch", EmptyDir: &EmptyDir{}}}},
+			"mount path scratch of volume scratch must be absolute",
+		},
+		{
+			Spec{Volumes: []Volume{{Name: "scratch", MountPath: "/scratch"}}},
+			"volume scratch must have exactly one of config_map, secret or empty_dir",
+		},
+		{
+			Spec{Volumes: []Volume{{Name: "scratch", MountPath: "/scratch", Secret: "any", EmptyDir: &EmptyDir{}}}},
+			"volume scratch must have exactly one of config_map, secret or empty_dir",
+		},
+		{
+			Spec{Volumes: []Volume{{Name: "scratch", MountPath: "/scratch", EmptyDir: &EmptyDir{SizeLimit: "lots"}}}},
+			"invalid size limit lots of volume scratch",
+		},
+		{
+			Spec{Volumes: []Volume{{Name: "scratch", MountPath: "/a", EmptyDir: &EmptyDir{}}, {Name: "scratch", MountPath: "/b", EmptyDir: &EmptyDir{}}}},
+			"volume scratch is declared more than once",
+		},
+		{
+			Spec{Volumes: []Volume{{Name: "one", MountPath: "/a", EmptyDir: &EmptyDir{}}, {Name: "two", MountPath: "/a/", EmptyDir: &EmptyDir{}}}},
+			"mount path /a/ is used by more than one volume",
+		},
+	} {
+		assert.EqualError(t, testCase.spec.Validate(allowlist), testCase.expectedError)
+	}
+
+	err := Spec{Volumes: []Volume{{Name: "Scratch_Dir", MountPath: "/scratch", EmptyDir: &EmptyDir{}}}}.Validate(allowlist)
+	assert.Contains(t, err.Error(), "invalid volume name Scratch_Dir")
+}
@@ -0,0 +1,97 @@
+package resources
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+type Requirements struct {
+	Requests Quantities `json:"requests"`
+	Limits   Quantities `json:"limits"`
+}
+
+type Quantities struct {
+	CPU    string `json:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty"`
+}
+
+// WithDefaults fills the quantities missing from requirements with defaults,
+// without letting a defaulted request exceed its limit or a defaulted limit fall below its request
+func (requirements Requirements) WithDefaults(defaults Requirements) Requirements {
+	cpuRequest, cpuLimit := withDefaults(requirements.Requests.CPU, requirements.Limits.CPU, defaults.Requests.CPU, defaults.Limits.CPU)
+	memoryRequest, memoryLimit := withDefaults(requirements.Requests.Memory, requirements.Limits.Memory, defaults.Requests.Memory, defaults.Limits.Memory)
+
+	return Requirements{
+		Requests: Quantities{CPU: cpuRequest, Memory: memoryRequest},
+		Limits:   Quantities{CPU: cpuLimit, Memory: memoryLimit},
+	}
+}
+
+func (requirements Requirements) Validate(maximum Quantities) error {
+	quantities := []struct {
+		name    string
+		value   string
+		maximum string
+	}{
+		{"cpu request", requirements.Requests.CPU, maximum.CPU},
+		{"memory request", requirements.Requests.Memory, maximum.Memory},
+		{"cpu limit", requirements.Limits.CPU, maximum.CPU},
+		{"memory limit", requirements.Limits.Memory, maximum.Memory},
+	}
+
+	for _, quantity := range quantities {
+		if quantity.value == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(quantity.value); err != nil {
+			return fmt.Errorf("invalid %s: %s", quantity.name, quantity.value)
+		}
+		if exceeds(quantity.value, quantity.maximum) {
+			return fmt.Errorf("%s %s exceeds the maximum of %s", quantity.name, quantity.value, quantity.maximum)
+		}
+	}
+
+	if exceeds(requirements.Requests.CPU, requirements.Limits.CPU) {
+		return fmt.Errorf("cpu request %s exceeds the cpu limit %s", requirements.Requests.CPU, requirements.Limits.CPU)
+	}
+	if exceeds(requirements.Requests.Memory, requirements.Limits.Memory) {
+		return fmt.Errorf("memory request %s exceeds the memory limit %s", requirements.Requests.Memory, requirements.Limits.Memory)
+	}
+
+	return nil
+}
+
+func withDefaults(request, limit, defaultRequest, defaultLimit string) (string, string) {
+	if request == "" {
+		request = defaultRequest
+		if exceeds(request, limit) {
+			request = limit
+		}
+	}
+	if limit == "" {
+		limit = defaultLimit
+		if exceeds(request, limit) {
+			limit = request
+		}
+	}
+	return request, limit
+}
+
+// exceeds is false unless both quantities are valid and quantity is greater than bound
+func exceeds(quantity, bound string) bool {
+	if quantity == "" || bound == "" {
+		return false
+	}
+
+	parsedQuantity, err := resource.ParseQuantity(quantity)
+	if err != nil {
+		return false
+	}
+	parsedBound, err := resource.ParseQuantity(bound)
+	if err != nil {
+		return false
+	}
+
+	return parsedQuantity.Cmp(parsedBound) > 0
+}
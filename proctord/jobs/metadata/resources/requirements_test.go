@@ -0,0 +1,72 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithDefaultsKeepsGivenQuantities(t *testing.T) {
+	requirements := Requirements{
+		Requests: Quantities{CPU: "500m", Memory: "256Mi"},
+		Limits:   Quantities{CPU: "1", Memory: "512Mi"},
+	}
+	defaults := Requirements{
+		Requests: Quantities{CPU: "100m", Memory: "128Mi"},
+		Limits:   Quantities{CPU: "200m", Memory: "256Mi"},
+	}
+
+	assert.Equal(t, requirements, requirements.WithDefaults(defaults))
+}
+
+func TestWithDefaultsFillsMissingQuantities(t *testing.T) {
+	requirements := Requirements{
+		Limits: Quantities{CPU: "1"},
+	}
+	defaults := Requirements{
+		Requests: Quantities{CPU: "100m", Memory: "128Mi"},
+		Limits:   Quantities{CPU: "200m", Memory: "256Mi"},
+	}
+
+	expected := Requirements{
+		Requests: Quantities{CPU: "100m", Memory: "128Mi"},
+		Limits:   Quantities{CPU: "1", Memory: "256Mi"},
+	}
+	assert.Equal(t, expected, requirements.WithDefaults(defaults))
+}
+
+func TestWithDefaultsKeepsRequestsWithinLimits(t *testing.T) {
+	requirements := Requirements{
+		Requests: Quantities{Memory: "1Gi"},
+		Limits:   Quantities{CPU: "50m"},
+	}
+	defaults := Requirements{
+		Requests: Quantities{CPU: "100m", Memory: "128Mi"},
+		Limits:   Quantities{CPU: "200m", Memory: "256Mi"},
+	}
+
+	expected := Requirements{
+		Requests: Quantities{CPU: "50m", Memory: "1Gi"},
+		Limits:   Quantities{CPU: "50m", Memory: "1Gi"},
+	}
+	assert.Equal(t, expected, requirements.WithDefaults(defaults))
+}
+
+func TestValidate(t *testing.T) {
+	maximum := Quantities{CPU: "2", Memory: "4Gi"}
+
+	assert.NoError(t, Requirements{}.Validate(maximum))
+	assert.NoError(t, Requirements{
+		Requests: Quantities{CPU: "500m", Memory: "1Gi"},
+		Limits:   Quantities{CPU: "2", Memory: "4Gi"},
+	}.Validate(maximum))
+	assert.NoError(t, Requirements{Limits: Quantities{CPU: "8"}}.Validate(Quantities{}))
+
+	assert.EqualError(t, Requirements{Limits: Quantities{CPU: "lots"}}.Validate(maximum), "invalid cpu limit: lots")
+	assert.EqualError(t, Requirements{Limits: Quantities{CPU: "3"}}.Validate(maximum), "cpu limit 3 exceeds the maximum of 2")
+	assert.EqualError(t, Requirements{Requests: Quantities{Memory: "8Gi"}}.Validate(maximum), "memory request 8Gi exceeds the maximum of 4Gi")
+	assert.EqualError(t, Requirements{
+		Requests: Quantities{Memory: "2Gi"},
+		Limits:   Quantities{Memory: "1Gi"},
+	}.Validate(maximum), "memory request 2Gi exceeds the memory limit 1Gi")
+}
@@ -9,10 +9,13 @@ import (
 	"strings"
 
 	"github.com/badoux/checkmail"
+	"proctor/proctord/audit"
+	"proctor/proctord/auth"
 
 	"proctor/proctord/jobs/metadata"
 	"proctor/proctord/logger"
 	"proctor/proctord/storage"
+	"proctor/proctord/storage/postgres"
 	"proctor/proctord/utility"
 	"github.com/robfig/cron"
 )
@@ -20,6 +23,8 @@ import (
 type scheduler struct {
 	store         storage.Store
 	metadataStore metadata.Store
+	auditor       audit.Auditor
+	authorizer    auth.Authorizer
 }
 
 type Scheduler interface {
@@ -29,10 +34,12 @@ type Scheduler interface {
 	RemoveScheduledJob() http.HandlerFunc
 }
 
-func NewScheduler(store storage.Store, metadataStore metadata.Store) Scheduler {
+func NewScheduler(store storage.Store, metadataStore metadata.Store, auditor audit.Auditor, authorizer auth.Authorizer) Scheduler {
 	return &scheduler{
 		metadataStore: metadataStore,
 		store:         store,
+		auditor:       auditor,
+		authorizer:    authorizer,
 	}
 }
 
@@ -40,7 +47,8 @@ func (scheduler *scheduler) Schedule() http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
 		var scheduledJob ScheduledJob
 		err := json.NewDecoder(req.Body).Decode(&scheduledJob)
-		userEmail := req.Header.Get(utility.UserEmailHeaderKey)
+		user, _ := auth.FromContext(req.Context())
+		userEmail := user.Email
 		defer req.Body.Close()
 		if err != nil {
 			logger.Error("Error parsing request body for scheduling jobs: ", err.Error())
@@ -87,7 +95,7 @@ func (scheduler *scheduler) Schedule() http.HandlerFunc {
 			return
 		}
 
-		_, err = scheduler.metadataStore.GetJobMetadata(scheduledJob.Name)
+		jobMetadata, err := scheduler.metadataStore.GetJobMetadata(scheduledJob.Name)
 		if err != nil {
 			if err.Error() == "redigo: nil returned" {
 				logger.Error(fmt.Sprintf("Client provided non existent proc name: %s ", scheduledJob.Tags), scheduledJob.Name, )
@@ -105,6 +113,44 @@ func (scheduler *scheduler) Schedule() http.HandlerFunc {
 			return
 		}
 
+		authorized, err := scheduler.authorizer.Authorize(user, jobMetadata.AuthorizedGroups)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error authorizing user %s for proc %s ", userEmail, scheduledJob.Tags), scheduledJob.Name, err.Error())
+			raven.CaptureError(err, map[string]string{"job_tags": scheduledJob.Tags, "job_name": scheduledJob.Name})
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+		if !authorized {
+			logger.Info(fmt.Sprintf("User %s not authorized to schedule proc %s ", userEmail, scheduledJob.Tags), scheduledJob.Name)
+			go scheduler.auditor.JobsExecution(&postgres.JobsExecutionAuditLog{
+				JobName:             scheduledJob.Name,
+				UserEmail:           userEmail,
+				JobSubmissionStatus: utility.JobSubmissionForbidden,
+				Errors:              fmt.Sprintf("User not in authorized groups to schedule: %v", jobMetadata.AuthorizedGroups),
+			})
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(utility.UnauthorizedProcClientError))
+			return
+		}
+
+		if jobMetadata.RequiresApproval {
+			logger.Info(fmt.Sprintf("User %s tried scheduling proc requiring approval %s ", userEmail, scheduledJob.Tags), scheduledJob.Name)
+
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(utility.ApprovalRequiredScheduleClientError))
+			return
+		}
+
+		argProblems := jobMetadata.EnvVars.ValidateArgs(scheduledJob.Args)
+		if len(argProblems) > 0 {
+			logger.Info(fmt.Sprintf("User %s provided invalid args to schedule proc %s ", userEmail, scheduledJob.Tags), scheduledJob.Name, argProblems)
+
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf("%s:\n%s", utility.InvalidArgsClientError, strings.Join(argProblems, "\n"))))
+			return
+		}
+
 		scheduledJob.Time = fmt.Sprintf("0 %s", scheduledJob.Time)
 		scheduledJob.ID, err = scheduler.store.InsertScheduledJob(scheduledJob.Name, scheduledJob.Tags, scheduledJob.Time, scheduledJob.NotificationEmails, userEmail, scheduledJob.Group, scheduledJob.Args)
 		if err != nil {
@@ -12,11 +12,15 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"proctor/proctord/audit"
+	"proctor/proctord/auth"
 	"proctor/proctord/jobs/metadata"
+	"proctor/proctord/jobs/metadata/env"
 	"proctor/proctord/storage"
 	"proctor/proctord/storage/postgres"
 	"proctor/proctord/utility"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -24,6 +28,8 @@ type SchedulerTestSuite struct {
 	suite.Suite
 	mockStore         *storage.MockStore
 	mockMetadataStore *metadata.MockStore
+	mockAuditor       *audit.MockAuditor
+	mockAuthorizer    *auth.MockAuthorizer
 
 	testScheduler Scheduler
 
@@ -34,7 +40,9 @@ type SchedulerTestSuite struct {
 func (suite *SchedulerTestSuite) SetupTest() {
 	suite.mockMetadataStore = &metadata.MockStore{}
 	suite.mockStore = &storage.MockStore{}
-	suite.testScheduler = NewScheduler(suite.mockStore, suite.mockMetadataStore)
+	suite.mockAuditor = &audit.MockAuditor{}
+	suite.mockAuthorizer = &auth.MockAuthorizer{}
+	suite.testScheduler = NewScheduler(suite.mockStore, suite.mockMetadataStore, suite.mockAuditor, suite.mockAuthorizer)
 
 	suite.Client = &http.Client{}
 	router := mux.NewRouter()
@@ -62,9 +70,10 @@ func (suite *SchedulerTestSuite) TestSuccessfulJobScheduling() {
 
 	responseRecorder := httptest.NewRecorder()
 	req := httptest.NewRequest("POST", "/schedule", bytes.NewReader(requestBody))
-	req.Header.Set(utility.UserEmailHeaderKey, userEmail)
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
 
 	suite.mockMetadataStore.On("GetJobMetadata", scheduledJob.Name).Return(&metadata.Metadata{}, nil)
+	suite.mockAuthorizer.On("Authorize", mock.Anything, []string(nil)).Return(true, nil)
 	insertedScheduledJobID := "123"
 	suite.mockStore.On("InsertScheduledJob", scheduledJob.Name, scheduledJob.Tags, "0 * 2 * * *", scheduledJob.NotificationEmails, userEmail,scheduledJob.Group, scheduledJob.Args).Return(insertedScheduledJobID, nil)
 
@@ -208,6 +217,112 @@ func (suite *SchedulerTestSuite) TestNonExistentJobScheduling() {
 	assert.Equal(t, utility.NonExistentProcClientError, string(responseBody))
 }
 
+func (suite *SchedulerTestSuite) TestUnauthorizedJobScheduling() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	scheduledJob := ScheduledJob{
+		Name:               "any-job",
+		Time:               "* 2 * * *",
+		NotificationEmails: "foo@bar.com,bar@foo.com",
+		Tags:               "tag-one,tag-two",
+		Group:              "some-group",
+	}
+	requestBody, err := json.Marshal(scheduledJob)
+	assert.NoError(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/schedule", bytes.NewReader(requestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
+
+	jobMetadata := &metadata.Metadata{Name: scheduledJob.Name, AuthorizedGroups: []string{"group_one"}}
+	suite.mockMetadataStore.On("GetJobMetadata", scheduledJob.Name).Return(jobMetadata, nil)
+	suite.mockAuthorizer.On("Authorize", auth.User{Email: userEmail}, jobMetadata.AuthorizedGroups).Return(false, nil)
+
+	auditingChan := make(chan *postgres.JobsExecutionAuditLog)
+	suite.mockAuditor.On("JobsExecution", mock.Anything).Return().Run(
+		func(args mock.Arguments) { auditingChan <- args.Get(0).(*postgres.JobsExecutionAuditLog) },
+	)
+
+	suite.testScheduler.Schedule()(responseRecorder, req)
+
+	auditedJobsExecution := <-auditingChan
+	assert.Equal(t, utility.JobSubmissionForbidden, auditedJobsExecution.JobSubmissionStatus)
+	assert.Equal(t, userEmail, auditedJobsExecution.UserEmail)
+
+	suite.mockStore.AssertNotCalled(t, "InsertScheduledJob", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	responseBody, _ := ioutil.ReadAll(responseRecorder.Body)
+	assert.Equal(t, utility.UnauthorizedProcClientError, string(responseBody))
+}
+
+func (suite *SchedulerTestSuite) TestJobSchedulingWithInvalidArgs() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	scheduledJob := ScheduledJob{
+		Name:               "any-job",
+		Args:               map[string]string{"COUNT": "ten"},
+		Time:               "* 2 * * *",
+		NotificationEmails: "foo@bar.com,bar@foo.com",
+		Tags:               "tag-one,tag-two",
+		Group:              "some-group",
+	}
+	requestBody, err := json.Marshal(scheduledJob)
+	assert.NoError(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/schedule", bytes.NewReader(requestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
+
+	jobMetadata := &metadata.Metadata{
+		Name: scheduledJob.Name,
+		EnvVars: env.Vars{Args: []env.VarMetadata{
+			{Name: "COUNT", Type: env.IntType},
+			{Name: "ENV", Required: true},
+		}},
+	}
+	suite.mockMetadataStore.On("GetJobMetadata", scheduledJob.Name).Return(jobMetadata, nil)
+	suite.mockAuthorizer.On("Authorize", mock.Anything, []string(nil)).Return(true, nil)
+
+	suite.testScheduler.Schedule()(responseRecorder, req)
+
+	suite.mockStore.AssertNotCalled(t, "InsertScheduledJob", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	responseBody, _ := ioutil.ReadAll(responseRecorder.Body)
+	assert.Equal(t, "invalid proc args:\nCOUNT must be an int\nENV is required", string(responseBody))
+}
+
+func (suite *SchedulerTestSuite) TestJobSchedulingOfProcRequiringApproval() {
+	t := suite.T()
+
+	userEmail := "mrproctor@example.com"
+	scheduledJob := ScheduledJob{
+		Name:               "any-job",
+		Args:               map[string]string{},
+		Time:               "* 2 * * *",
+		NotificationEmails: "foo@bar.com,bar@foo.com",
+		Tags:               "tag-one,tag-two",
+		Group:              "some-group",
+	}
+	requestBody, err := json.Marshal(scheduledJob)
+	assert.NoError(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/schedule", bytes.NewReader(requestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: userEmail}))
+
+	jobMetadata := &metadata.Metadata{Name: scheduledJob.Name, RequiresApproval: true, ApproverGroups: []string{"approvers"}}
+	suite.mockMetadataStore.On("GetJobMetadata", scheduledJob.Name).Return(jobMetadata, nil)
+	suite.mockAuthorizer.On("Authorize", mock.Anything, []string(nil)).Return(true, nil)
+
+	suite.testScheduler.Schedule()(responseRecorder, req)
+
+	suite.mockStore.AssertNotCalled(t, "InsertScheduledJob", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	assert.Equal(t, utility.ApprovalRequiredScheduleClientError, responseRecorder.Body.String())
+}
+
 func (suite *SchedulerTestSuite) TestErrorFetchingJobMetadata() {
 	t := suite.T()
 
@@ -250,6 +365,7 @@ func (suite *SchedulerTestSuite) TestUniqnessConstrainOnJobNameAndArg() {
 	req := httptest.NewRequest("POST", "/schedule", bytes.NewReader(requestBody))
 
 	suite.mockMetadataStore.On("GetJobMetadata", scheduledJob.Name).Return(&metadata.Metadata{}, nil)
+	suite.mockAuthorizer.On("Authorize", mock.Anything, []string(nil)).Return(true, nil)
 	suite.mockStore.On("InsertScheduledJob", scheduledJob.Name, scheduledJob.Tags, "0 * 2 * * *", scheduledJob.NotificationEmails, "",scheduledJob.Group, scheduledJob.Args).Return("", errors.New("pq: duplicate key value violates unique constraint \"unique_jobs_schedule_name_args\""))
 
 	suite.testScheduler.Schedule()(responseRecorder, req)
@@ -276,6 +392,7 @@ func (suite *SchedulerTestSuite) TestErrorPersistingScheduledJob() {
 	req := httptest.NewRequest("POST", "/schedule", bytes.NewReader(requestBody))
 
 	suite.mockMetadataStore.On("GetJobMetadata", scheduledJob.Name).Return(&metadata.Metadata{}, nil)
+	suite.mockAuthorizer.On("Authorize", mock.Anything, []string(nil)).Return(true, nil)
 	suite.mockStore.On("InsertScheduledJob", scheduledJob.Name, scheduledJob.Tags, "0 * 2 * * *", scheduledJob.NotificationEmails, "",scheduledJob.Group, scheduledJob.Args).Return("", errors.New("any-error"))
 
 	suite.testScheduler.Schedule()(responseRecorder, req)
@@ -60,7 +60,7 @@ func (worker *worker) enableScheduledJobIfItDoesNotExist(scheduledJob postgres.J
 			jobsExecutionAuditLog := &postgres.JobsExecutionAuditLog{}
 			jobsExecutionAuditLog.UserEmail = utility.WorkerEmail
 
-			jobExecutionID, err := worker.executioner.Execute(jobsExecutionAuditLog, scheduledJob.Name, jobArgs)
+			jobExecutionID, err := worker.executioner.Execute(jobsExecutionAuditLog, scheduledJob.Name, jobArgs, execution.Limits{}, execution.Provenance{})
 			if err != nil {
 				logger.Error(fmt.Sprintf("Error submitting job: %s ", scheduledJob.Tags), scheduledJob.Name, " for execution: ", err.Error())
 				raven.CaptureError(err, map[string]string{"job_tags": scheduledJob.Tags, "job_name": scheduledJob.Name})
@@ -74,7 +74,7 @@ func (suite *WorkerTestSuite) TestCronEnablingForScheduledJobs() {
 	suite.mockStore.On("GetScheduledJobs").Return(scheduledJobs, nil)
 
 	jobExecutionID := "job-execution-id"
-	suite.mockExecutioner.On("Execute", mock.Anything, enabledJob, jobArgs).Return(jobExecutionID, nil)
+	suite.mockExecutioner.On("Execute", mock.Anything, enabledJob, jobArgs, execution.Limits{}, execution.Provenance{}).Return(jobExecutionID, nil)
 
 	jobExecutionStatus := utility.JobSucceeded
 	suite.mockAuditor.On("JobsExecution", mock.Anything).Return()
@@ -96,7 +96,7 @@ func (suite *WorkerTestSuite) TestCronEnablingForScheduledJobs() {
 	suite.mockExecutioner.AssertExpectations(t)
 	suite.mockAuditor.AssertExpectations(t)
 	suite.mockMailer.AssertExpectations(t)
-	suite.mockExecutioner.AssertNotCalled(t, "Execute", disabledJob, jobArgs)
+	suite.mockExecutioner.AssertNotCalled(t, "Execute", mock.Anything, disabledJob, jobArgs, execution.Limits{}, execution.Provenance{})
 }
 
 func (suite *WorkerTestSuite) TestCronForDisablingEnabledScheduledJobs() {
@@ -141,7 +141,7 @@ func (suite *WorkerTestSuite) TestCronForDisablingEnabledScheduledJobs() {
 	)
 
 	jobExecutionID := "job-execution-id"
-	suite.mockExecutioner.On("Execute", mock.Anything, jobName, jobArgs).Return(jobExecutionID, nil)
+	suite.mockExecutioner.On("Execute", mock.Anything, jobName, jobArgs, execution.Limits{}, execution.Provenance{}).Return(jobExecutionID, nil)
 
 	suite.mockAuditor.On("JobsExecution", mock.Anything).Return()
 	jobExecutionStatus := utility.JobSucceeded
@@ -4,29 +4,54 @@ import (
 	"encoding/json"
 	"github.com/getsentry/raven-go"
 	"net/http"
+	"sort"
 
+	"proctor/proctord/audit"
+	"proctor/proctord/auth"
 	"proctor/proctord/logger"
 	"proctor/proctord/utility"
 )
 
 type handler struct {
 	secretsStore Store
+	auditor      audit.Auditor
+	authorizer   auth.Authorizer
 }
 
 type Handler interface {
 	HandleSubmission() http.HandlerFunc
 }
 
-func NewHandler(secretsStore Store) Handler {
+func NewHandler(secretsStore Store, auditor audit.Auditor, authorizer auth.Authorizer) Handler {
 	return &handler{
 		secretsStore: secretsStore,
+		auditor:      auditor,
+		authorizer:   authorizer,
 	}
 }
 
 func (handler *handler) HandleSubmission() http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
+		user, _ := auth.FromContext(req.Context())
+		role, err := handler.authorizer.Role(user)
+		if err != nil {
+			logger.Error("Error resolving user role", err.Error())
+			raven.CaptureError(err, nil)
+
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+		if !role.Includes(auth.AdminRole) {
+			logger.Info("User", user.Email, "with role", role, "is not allowed to submit secrets")
+
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(utility.InsufficientRoleClientError))
+			return
+		}
+
 		var secret Secret
-		err := json.NewDecoder(req.Body).Decode(&secret)
+		err = json.NewDecoder(req.Body).Decode(&secret)
 		defer req.Body.Close()
 		if err != nil {
 			logger.Error("Error parsing request body", err.Error())
@@ -36,6 +61,20 @@ func (handler *handler) HandleSubmission() http.HandlerFunc {
 			return
 		}
 
+		action := audit.SecretsUpdated
+		existingSecrets, err := handler.secretsStore.GetJobSecrets(secret.JobName)
+		if err != nil {
+			if err.Error() != "redigo: nil returned" {
+				logger.Error("Error fetching secrets", err.Error())
+				raven.CaptureError(err, nil)
+
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(utility.ServerError))
+				return
+			}
+			action = audit.SecretsCreated
+		}
+
 		err = handler.secretsStore.CreateOrUpdateJobSecret(secret)
 		if err != nil {
 			logger.Error("Error updating secrets", err.Error())
@@ -46,6 +85,22 @@ func (handler *handler) HandleSubmission() http.HandlerFunc {
 			return
 		}
 
+		var before interface{}
+		if action == audit.SecretsUpdated {
+			before = secretNames(existingSecrets)
+		}
+		handler.auditor.AdminAction(user.Email, secret.JobName, action, before, secretNames(secret.Secrets))
+
 		w.WriteHeader(http.StatusCreated)
 	}
 }
+
+// secretNames keeps secret values out of the admin audit log
+func secretNames(secrets map[string]string) map[string][]string {
+	names := []string{}
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return map[string][]string{"secret_names": names}
+}
@@ -9,6 +9,8 @@ import (
 
 	"errors"
 
+	"proctor/proctord/audit"
+	"proctor/proctord/auth"
 	"proctor/proctord/utility"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -18,13 +20,19 @@ import (
 type SecretsHandlerTestSuite struct {
 	suite.Suite
 	mockSecretsStore   *MockStore
+	mockAuditor        *audit.MockAuditor
+	mockAuthorizer     *auth.MockAuthorizer
 	testSecretsHandler Handler
+	user               auth.User
 }
 
 func (suite *SecretsHandlerTestSuite) SetupTest() {
 	suite.mockSecretsStore = &MockStore{}
+	suite.mockAuditor = &audit.MockAuditor{}
+	suite.mockAuthorizer = &auth.MockAuthorizer{}
+	suite.user = auth.User{Email: "mrproctor@example.com"}
 
-	suite.testSecretsHandler = NewHandler(suite.mockSecretsStore)
+	suite.testSecretsHandler = NewHandler(suite.mockSecretsStore, suite.mockAuditor, suite.mockAuthorizer)
 }
 
 func (suite *SecretsHandlerTestSuite) TestSuccessfulSecretsUpdation() {
@@ -39,17 +47,72 @@ func (suite *SecretsHandlerTestSuite) TestSuccessfulSecretsUpdation() {
 	assert.NoError(t, err)
 
 	req := httptest.NewRequest("POST", "/job-secrets", bytes.NewReader(requestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), suite.user))
 	responseRecorder := httptest.NewRecorder()
 
+	suite.mockAuthorizer.On("Role", suite.user).Return(auth.AdminRole, nil).Once()
+	suite.mockSecretsStore.On("GetJobSecrets", "job1").Return(map[string]string{"k1": "old"}, nil).Once()
 	suite.mockSecretsStore.On("CreateOrUpdateJobSecret", secret).Return(nil).Once()
+	suite.mockAuditor.On("AdminAction", suite.user.Email, "job1", audit.SecretsUpdated,
+		map[string][]string{"secret_names": {"k1"}},
+		map[string][]string{"secret_names": {"k1", "k2"}}).Return().Once()
 
 	suite.testSecretsHandler.HandleSubmission()(responseRecorder, req)
 
 	suite.mockSecretsStore.AssertExpectations(t)
+	suite.mockAuditor.AssertExpectations(t)
 
 	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
 }
 
+func (suite *SecretsHandlerTestSuite) TestSuccessfulSecretsCreation() {
+	t := suite.T()
+
+	secret := Secret{
+		JobName: "job1",
+		Secrets: map[string]string{"k1": "v1"},
+	}
+
+	requestBody, err := json.Marshal(secret)
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/job-secrets", bytes.NewReader(requestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), suite.user))
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockAuthorizer.On("Role", suite.user).Return(auth.AdminRole, nil).Once()
+	suite.mockSecretsStore.On("GetJobSecrets", "job1").Return(map[string]string(nil), errors.New("redigo: nil returned")).Once()
+	suite.mockSecretsStore.On("CreateOrUpdateJobSecret", secret).Return(nil).Once()
+	suite.mockAuditor.On("AdminAction", suite.user.Email, "job1", audit.SecretsCreated,
+		nil, map[string][]string{"secret_names": {"k1"}}).Return().Once()
+
+	suite.testSecretsHandler.HandleSubmission()(responseRecorder, req)
+
+	suite.mockSecretsStore.AssertExpectations(t)
+	suite.mockAuditor.AssertExpectations(t)
+
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+}
+
+func (suite *SecretsHandlerTestSuite) TestSecretsUpdationForPublisherRole() {
+	t := suite.T()
+
+	requestBody, err := json.Marshal(Secret{JobName: "job1", Secrets: map[string]string{"k1": "v1"}})
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/job-secrets", bytes.NewReader(requestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), suite.user))
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockAuthorizer.On("Role", suite.user).Return(auth.PublisherRole, nil).Once()
+
+	suite.testSecretsHandler.HandleSubmission()(responseRecorder, req)
+
+	suite.mockSecretsStore.AssertNotCalled(t, "CreateOrUpdateJobSecret", mock.Anything)
+	assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	assert.Equal(t, utility.InsufficientRoleClientError, responseRecorder.Body.String())
+}
+
 func (suite *SecretsHandlerTestSuite) TestSecretsUpdationSecretsMalformedData() {
 	t := suite.T()
 
@@ -57,8 +120,11 @@ func (suite *SecretsHandlerTestSuite) TestSecretsUpdationSecretsMalformedData()
 	assert.NoError(t, err)
 
 	req := httptest.NewRequest("POST", "/job-secrets", bytes.NewReader(requestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), suite.user))
 	responseRecorder := httptest.NewRecorder()
 
+	suite.mockAuthorizer.On("Role", suite.user).Return(auth.AdminRole, nil).Once()
+
 	suite.testSecretsHandler.HandleSubmission()(responseRecorder, req)
 
 	suite.mockSecretsStore.AssertNotCalled(t, "CreateOrUpdateJobSecret", mock.Anything)
@@ -78,8 +144,11 @@ func (suite *SecretsHandlerTestSuite) TestSecretsUpdationSecretsStoreFailure() {
 	assert.NoError(t, err)
 
 	req := httptest.NewRequest("POST", "/job-secrets", bytes.NewReader(requestBody))
+	req = req.WithContext(auth.NewContext(req.Context(), suite.user))
 	responseRecorder := httptest.NewRecorder()
 
+	suite.mockAuthorizer.On("Role", suite.user).Return(auth.AdminRole, nil).Once()
+	suite.mockSecretsStore.On("GetJobSecrets", "job1").Return(map[string]string{}, nil).Once()
 	suite.mockSecretsStore.On("CreateOrUpdateJobSecret", secret).Return(errors.New("error")).Once()
 
 	suite.testSecretsHandler.HandleSubmission()(responseRecorder, req)
@@ -0,0 +1,93 @@
+package secrets
+
+import (
+	"proctor/proctord/logger"
+	"proctor/proctord/storage"
+	"proctor/proctord/utility"
+)
+
+const scrubBatchSize = 500
+
+// Redact keeps the names of secrets and drops their values
+func Redact(secrets map[string]string) map[string]string {
+	redacted := make(map[string]string)
+	for name := range secrets {
+		redacted[name] = utility.RedactedSecretValue
+	}
+	return redacted
+}
+
+type Scrubber interface {
+	ScrubJobsExecutionAuditLog() (int, error)
+}
+
+type scrubber struct {
+	store        storage.Store
+	secretsStore Store
+}
+
+func NewScrubber(store storage.Store, secretsStore Store) Scrubber {
+	return &scrubber{
+		store:        store,
+		secretsStore: secretsStore,
+	}
+}
+
+// ScrubJobsExecutionAuditLog redacts values of the current secrets of each proc from the args of its past executions
+func (scrubber *scrubber) ScrubJobsExecutionAuditLog() (int, error) {
+	secretsOfJob := make(map[string]map[string]string)
+	scrubbedCount := 0
+
+	var lastID int64
+	for {
+		jobsExecutionAuditLogs, err := scrubber.store.GetJobsExecutionAuditLogArgs(lastID, scrubBatchSize)
+		if err != nil {
+			return scrubbedCount, err
+		}
+		if len(jobsExecutionAuditLogs) == 0 {
+			return scrubbedCount, nil
+		}
+
+		for _, jobsExecutionAuditLog := range jobsExecutionAuditLogs {
+			lastID = jobsExecutionAuditLog.ID
+
+			jobSecrets, ok := secretsOfJob[jobsExecutionAuditLog.JobName]
+			if !ok {
+				jobSecrets, err = scrubber.secretsStore.GetJobSecrets(jobsExecutionAuditLog.JobName)
+				if err != nil && err.Error() != "redigo: nil returned" {
+					return scrubbedCount, err
+				}
+				secretsOfJob[jobsExecutionAuditLog.JobName] = jobSecrets
+			}
+
+			jobArgs, err := utility.DeserializeMap(jobsExecutionAuditLog.JobArgs)
+			if err != nil {
+				logger.Error("Error decoding job args of jobs execution audit log", jobsExecutionAuditLog.ID, err.Error())
+				continue
+			}
+
+			if !redactSecrets(jobArgs, jobSecrets) {
+				continue
+			}
+
+			jobsExecutionAuditLog.AddJobArgs(jobArgs)
+			err = scrubber.store.UpdateJobsExecutionAuditLogArgs(jobsExecutionAuditLog.ID, jobsExecutionAuditLog.JobArgs)
+			if err != nil {
+				return scrubbedCount, err
+			}
+			scrubbedCount++
+		}
+	}
+}
+
+func redactSecrets(jobArgs, jobSecrets map[string]string) bool {
+	redacted := false
+	for name := range jobSecrets {
+		value, ok := jobArgs[name]
+		if ok && value != utility.RedactedSecretValue {
+			jobArgs[name] = utility.RedactedSecretValue
+			redacted = true
+		}
+	}
+	return redacted
+}
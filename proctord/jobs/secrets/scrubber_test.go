@@ -0,0 +1,82 @@
+package secrets
+
+import (
+	"errors"
+	"testing"
+
+	"proctor/proctord/storage"
+	"proctor/proctord/storage/postgres"
+	"proctor/proctord/utility"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ScrubberTestSuite struct {
+	suite.Suite
+	mockStore        *storage.MockStore
+	mockSecretsStore *MockStore
+	testScrubber     Scrubber
+}
+
+func (s *ScrubberTestSuite) SetupTest() {
+	s.mockStore = &storage.MockStore{}
+	s.mockSecretsStore = &MockStore{}
+
+	s.testScrubber = NewScrubber(s.mockStore, s.mockSecretsStore)
+}
+
+func auditLogWithArgs(id int64, jobName string, jobArgs map[string]string) postgres.JobsExecutionAuditLog {
+	jobsExecutionAuditLog := postgres.JobsExecutionAuditLog{ID: id, JobName: jobName}
+	jobsExecutionAuditLog.AddJobArgs(jobArgs)
+	return jobsExecutionAuditLog
+}
+
+func (s *ScrubberTestSuite) TestRedact() {
+	redacted := Redact(map[string]string{"k1": "v1", "k2": "v2"})
+
+	assert.Equal(s.T(), map[string]string{"k1": utility.RedactedSecretValue, "k2": utility.RedactedSecretValue}, redacted)
+}
+
+func (s *ScrubberTestSuite) TestScrubJobsExecutionAuditLog() {
+	t := s.T()
+
+	leakedAuditLog := auditLogWithArgs(1, "job1", map[string]string{"arg": "value", "k1": "v1"})
+	scrubbedAuditLog := auditLogWithArgs(2, "job1", map[string]string{"arg": "value", "k1": utility.RedactedSecretValue})
+	noSecretsAuditLog := auditLogWithArgs(3, "job2", map[string]string{"arg": "value"})
+
+	s.mockStore.On("GetJobsExecutionAuditLogArgs", int64(0), scrubBatchSize).
+		Return([]postgres.JobsExecutionAuditLog{leakedAuditLog, scrubbedAuditLog, noSecretsAuditLog}, nil).Once()
+	s.mockStore.On("GetJobsExecutionAuditLogArgs", int64(3), scrubBatchSize).
+		Return([]postgres.JobsExecutionAuditLog{}, nil).Once()
+
+	s.mockSecretsStore.On("GetJobSecrets", "job1").Return(map[string]string{"k1": "v1-rotated"}, nil).Once()
+	s.mockSecretsStore.On("GetJobSecrets", "job2").Return(map[string]string(nil), errors.New("redigo: nil returned")).Once()
+
+	s.mockStore.On("UpdateJobsExecutionAuditLogArgs", int64(1), scrubbedAuditLog.JobArgs).Return(nil).Once()
+
+	scrubbedCount, err := s.testScrubber.ScrubJobsExecutionAuditLog()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, scrubbedCount)
+	s.mockStore.AssertExpectations(t)
+	s.mockSecretsStore.AssertExpectations(t)
+}
+
+func (s *ScrubberTestSuite) TestScrubJobsExecutionAuditLogSecretsStoreFailure() {
+	t := s.T()
+
+	s.mockStore.On("GetJobsExecutionAuditLogArgs", int64(0), scrubBatchSize).
+		Return([]postgres.JobsExecutionAuditLog{auditLogWithArgs(1, "job1", map[string]string{"k1": "v1"})}, nil).Once()
+	s.mockSecretsStore.On("GetJobSecrets", "job1").Return(map[string]string(nil), errors.New("error")).Once()
+
+	scrubbedCount, err := s.testScrubber.ScrubJobsExecutionAuditLog()
+
+	assert.EqualError(t, err, "error")
+	assert.Equal(t, 0, scrubbedCount)
+	s.mockStore.AssertExpectations(t)
+	s.mockSecretsStore.AssertExpectations(t)
+}
+
+func TestScrubberTestSuite(t *testing.T) {
+	suite.Run(t, new(ScrubberTestSuite))
+}
@@ -5,27 +5,38 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strings"
 	"time"
 
+	"proctor/proctord/backend"
 	"proctor/proctord/config"
+	"proctor/proctord/jobs/metadata/pod"
+	"proctor/proctord/jobs/metadata/resources"
 	"proctor/proctord/logger"
 	"proctor/proctord/utility"
 
 	uuid "github.com/satori/go.uuid"
 	batch_v1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 
 	//Package needed for kubernetes cluster in google cloud
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
 var typeMeta meta_v1.TypeMeta
 var namespace string
 
+const managedByLabelSelector = "app.kubernetes.io/managed-by=proctor"
+
 func init() {
 	typeMeta = meta_v1.TypeMeta{
 		Kind:       "Job",
@@ -39,13 +50,7 @@ type client struct {
 	httpClient *http.Client
 }
 
-type Client interface {
-	ExecuteJob(string, map[string]string) (string, error)
-	StreamJobLogs(string) (io.ReadCloser, error)
-	JobExecutionStatus(string) (string, error)
-}
-
-func NewClient(kubeconfig string, httpClient *http.Client) Client {
+func NewClient(kubeconfig string, httpClient *http.Client) backend.ExecutionBackend {
 	newClient := &client{
 		httpClient: httpClient,
 	}
@@ -63,25 +68,158 @@ func NewClient(kubeconfig string, httpClient *http.Client) Client {
 	return newClient
 }
 
-func getEnvVars(envMap map[string]string) []v1.EnvVar {
+// NewClientForClientSet runs jobs through the given clientset, like the fake one of client-go in tests
+func NewClientForClientSet(clientSet kubernetes.Interface) backend.ExecutionBackend {
+	return &client{
+		clientSet: clientSet,
+	}
+}
+
+// getEnvVars references each secret by key from the job secret, so that secrets take precedence over
+// args of the same name as they did when both were plain env vars, and their values stay out of the job spec
+func getEnvVars(secretName string, envMap, secrets map[string]string) []v1.EnvVar {
 	var envVars []v1.EnvVar
 	for k, v := range envMap {
-		envVar := v1.EnvVar{
-			Name:  k,
-			Value: v,
+		if _, ok := secrets[k]; ok {
+			continue
 		}
-		envVars = append(envVars, envVar)
+		envVars = append(envVars, v1.EnvVar{Name: k, Value: v})
+	}
+
+	secretKeys := make([]string, 0, len(secrets))
+	for k := range secrets {
+		secretKeys = append(secretKeys, k)
+	}
+	sort.Strings(secretKeys)
+	for _, k := range secretKeys {
+		envVars = append(envVars, v1.EnvVar{
+			Name: k,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: secretName},
+					Key:                  k,
+				},
+			},
+		})
 	}
 	return envVars
 }
 
+// jobSecret is owned by the job, so kubernetes removes it along with the job
+func jobSecret(job *batch_v1.Job, secrets map[string]string) *v1.Secret {
+	data := make(map[string][]byte)
+	for name, value := range secrets {
+		data[name] = []byte(value)
+	}
+
+	return &v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:   job.ObjectMeta.Name,
+			Labels: job.ObjectMeta.Labels,
+			OwnerReferences: []meta_v1.OwnerReference{
+				*meta_v1.NewControllerRef(job, batch_v1.SchemeGroupVersion.WithKind("Job")),
+			},
+		},
+		Type: v1.SecretTypeOpaque,
+		Data: data,
+	}
+}
+
+func defaultResources() resources.Requirements {
+	return resources.Requirements{
+		Requests: resources.Quantities{CPU: config.KubeJobDefaultCPURequest(), Memory: config.KubeJobDefaultMemoryRequest()},
+		Limits:   resources.Quantities{CPU: config.KubeJobDefaultCPULimit(), Memory: config.KubeJobDefaultMemoryLimit()},
+	}
+}
+
+func getResourceList(quantities resources.Quantities) (v1.ResourceList, error) {
+	resourceList := v1.ResourceList{}
+	if quantities.CPU != "" {
+		cpu, err := resource.ParseQuantity(quantities.CPU)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing cpu quantity %s: %v", quantities.CPU, err)
+		}
+		resourceList[v1.ResourceCPU] = cpu
+	}
+	if quantities.Memory != "" {
+		memory, err := resource.ParseQuantity(quantities.Memory)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing memory quantity %s: %v", quantities.Memory, err)
+		}
+		resourceList[v1.ResourceMemory] = memory
+	}
+	return resourceList, nil
+}
+
+func getResourceRequirements(jobResources resources.Requirements) (v1.ResourceRequirements, error) {
+	jobResources = jobResources.WithDefaults(defaultResources())
+
+	requests, err := getResourceList(jobResources.Requests)
+	if err != nil {
+		return v1.ResourceRequirements{}, err
+	}
+	limits, err := getResourceList(jobResources.Limits)
+	if err != nil {
+		return v1.ResourceRequirements{}, err
+	}
+
+	return v1.ResourceRequirements{
+		Requests: requests,
+		Limits:   limits,
+	}, nil
+}
+
+func getTolerations(tolerations []pod.Toleration) []v1.Toleration {
+	var kubeTolerations []v1.Toleration
+	for _, toleration := range tolerations {
+		kubeTolerations = append(kubeTolerations, v1.Toleration{
+			Key:      toleration.Key,
+			Operator: v1.TolerationOperator(toleration.Operator),
+			Value:    toleration.Value,
+			Effect:   v1.TaintEffect(toleration.Effect),
+		})
+	}
+	return kubeTolerations
+}
+
+// getVolumes mounts config maps and secrets read only
+func getVolumes(volumes []pod.Volume) ([]v1.Volume, []v1.VolumeMount, error) {
+	var kubeVolumes []v1.Volume
+	var volumeMounts []v1.VolumeMount
+	for _, volume := range volumes {
+		var source v1.VolumeSource
+		readOnly := true
+		switch {
+		case volume.ConfigMap != "":
+			source.ConfigMap = &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: volume.ConfigMap}}
+		case volume.Secret != "":
+			source.Secret = &v1.SecretVolumeSource{SecretName: volume.Secret}
+		case volume.EmptyDir != nil:
+			source.EmptyDir = &v1.EmptyDirVolumeSource{}
+			if volume.EmptyDir.SizeLimit != "" {
+				sizeLimit, err := resource.ParseQuantity(volume.EmptyDir.SizeLimit)
+				if err != nil {
+					return nil, nil, fmt.Errorf("invalid size limit %s of volume %s", volume.EmptyDir.SizeLimit, volume.Name)
+				}
+				source.EmptyDir.SizeLimit = &sizeLimit
+			}
+			readOnly = false
+		}
+
+		kubeVolumes = append(kubeVolumes, v1.Volume{Name: volume.Name, VolumeSource: source})
+		volumeMounts = append(volumeMounts, v1.VolumeMount{Name: volume.Name, MountPath: volume.MountPath, ReadOnly: readOnly})
+	}
+	return kubeVolumes, volumeMounts, nil
+}
+
 func uniqueName() string {
 	return "proctor" + "-" + uuid.NewV4().String()
 }
 
 func jobLabel(jobName string) map[string]string {
 	return map[string]string{
-		"job": jobName,
+		"job":                          jobName,
+		"app.kubernetes.io/managed-by": "proctor",
 	}
 }
 
@@ -89,28 +227,43 @@ func jobLabelSelector(jobName string) string {
 	return fmt.Sprintf("job=%s", jobName)
 }
 
-func (client *client) ExecuteJob(imageName string, envMap map[string]string) (string, error) {
+func (client *client) ExecuteJob(imageName string, envMap map[string]string, jobOptions backend.JobOptions) (string, error) {
 	uniqueJobName := uniqueName()
-	label := jobLabel(uniqueJobName)
 
 	batchV1 := client.clientSet.BatchV1()
 	kubernetesJobs := batchV1.Jobs(namespace)
 
+	resourceRequirements, err := getResourceRequirements(jobOptions.Resources)
+	if err != nil {
+		return "", err
+	}
+
+	volumes, volumeMounts, err := getVolumes(jobOptions.Pod.Volumes)
+	if err != nil {
+		return "", err
+	}
+
 	container := v1.Container{
-		Name:  uniqueJobName,
-		Image: imageName,
-		Env:   getEnvVars(envMap),
+		Name:         uniqueJobName,
+		Image:        imageName,
+		Env:          getEnvVars(uniqueJobName, envMap, jobOptions.Secrets),
+		Resources:    resourceRequirements,
+		VolumeMounts: volumeMounts,
 	}
 
 	podSpec := v1.PodSpec{
-		Containers:    []v1.Container{container},
-		RestartPolicy: v1.RestartPolicyNever,
+		Containers:         []v1.Container{container},
+		RestartPolicy:      v1.RestartPolicyNever,
+		ServiceAccountName: jobOptions.Pod.ServiceAccountName,
+		NodeSelector:       jobOptions.Pod.NodeSelector,
+		Tolerations:        getTolerations(jobOptions.Pod.Tolerations),
+		Volumes:            volumes,
 	}
 
 	objectMeta := meta_v1.ObjectMeta{
 		Name:        uniqueJobName,
-		Labels:      label,
-		Annotations: config.JobPodAnnotations(),
+		Labels:      executionLabels(uniqueJobName, jobOptions.Labels),
+		Annotations: executionAnnotations(jobOptions.Labels, jobOptions.Reason),
 	}
 
 	template := v1.PodTemplateSpec{
@@ -118,10 +271,19 @@ func (client *client) ExecuteJob(imageName string, envMap map[string]string) (st
 		Spec:       podSpec,
 	}
 
+	activeDeadlineSeconds := jobOptions.ActiveDeadlineSeconds
+	if activeDeadlineSeconds == nil {
+		activeDeadlineSeconds = config.KubeJobActiveDeadlineSeconds()
+	}
+	backoffLimit := jobOptions.BackoffLimit
+	if backoffLimit == nil {
+		backoffLimit = config.KubeJobRetries()
+	}
+
 	jobSpec := batch_v1.JobSpec{
 		Template:              template,
-		ActiveDeadlineSeconds: config.KubeJobActiveDeadlineSeconds(),
-		BackoffLimit:          config.KubeJobRetries(),
+		ActiveDeadlineSeconds: activeDeadlineSeconds,
+		BackoffLimit:          backoffLimit,
 	}
 
 	jobToRun := batch_v1.Job{
@@ -130,14 +292,29 @@ func (client *client) ExecuteJob(imageName string, envMap map[string]string) (st
 		Spec:       jobSpec,
 	}
 
-	_, err := kubernetesJobs.Create(context.Background(), &jobToRun, meta_v1.CreateOptions{})
+	createdJob, err := kubernetesJobs.Create(context.Background(), &jobToRun, meta_v1.CreateOptions{})
 	if err != nil {
 		return "", err
 	}
+
+	if len(jobOptions.Secrets) > 0 {
+		// the pod can't start until the secret exists, kubelet keeps retrying till then
+		_, err = client.clientSet.CoreV1().Secrets(namespace).Create(context.Background(), jobSecret(createdJob, jobOptions.Secrets), meta_v1.CreateOptions{})
+		if err != nil {
+			cancelErr := client.CancelJob(uniqueJobName)
+			if cancelErr != nil {
+				logger.Error("Error deleting job", uniqueJobName, "after failing to create its secret", cancelErr.Error())
+			}
+			return "", fmt.Errorf("Error creating secret for job %s: %v", uniqueJobName, err)
+		}
+	}
+
 	return uniqueJobName, nil
 }
 
-func (client *client) StreamJobLogs(jobName string) (io.ReadCloser, error) {
+// StreamJobLogs waits for the pod of a job to start. Without follow the stream ends at the logs written so far,
+// and there are none to wait for while the pod hasn't started
+func (client *client) StreamJobLogs(jobName string, follow bool) (io.ReadCloser, error) {
 	listOptions := meta_v1.ListOptions{
 		TypeMeta:      typeMeta,
 		LabelSelector: jobLabelSelector(jobName),
@@ -156,8 +333,14 @@ func (client *client) StreamJobLogs(jobName string) (io.ReadCloser, error) {
 
 		if len(listOfPods.Items) > 0 {
 			podJob := listOfPods.Items[0]
+			if podJob.Status.Phase == v1.PodFailed && podJob.Status.Reason == "Evicted" {
+				return nil, backend.ErrNoLogs
+			}
 			if podJob.Status.Phase == v1.PodRunning || podJob.Status.Phase == v1.PodSucceeded || podJob.Status.Phase == v1.PodFailed {
-				return client.getLogsStreamReaderFor(podJob.ObjectMeta.Name)
+				return client.getLogsStreamReaderFor(podJob.ObjectMeta.Name, follow)
+			}
+			if !follow {
+				return nil, backend.ErrNoLogs
 			}
 			watchPod, err := kubernetesPods.Watch(context.Background(), listOptions)
 			if err != nil {
@@ -185,6 +368,18 @@ func (client *client) StreamJobLogs(jobName string) (io.ReadCloser, error) {
 			batchV1 := client.clientSet.BatchV1()
 			kubernetesJobs := batchV1.Jobs(namespace)
 
+			// a finished job without pods won't get any, nor will one that's gone
+			job, err := kubernetesJobs.Get(context.Background(), jobName, meta_v1.GetOptions{})
+			if errors.IsNotFound(err) {
+				return nil, backend.ErrNoLogs
+			}
+			if err == nil && (jobExecutionStatus(job) == utility.JobSucceeded || jobExecutionStatus(job) == utility.JobFailed) {
+				return nil, backend.ErrNoLogs
+			}
+			if !follow {
+				return nil, backend.ErrNoLogs
+			}
+
 			watchJob, err := kubernetesJobs.Watch(context.Background(), listOptions)
 			if err != nil {
 				return nil, fmt.Errorf("Error watching kubernetes Jobs %v", err)
@@ -233,6 +428,10 @@ func (client *client) JobExecutionStatus(jobExecutionID string) (string, error)
 			return utility.JobExecutionStatusFetchError, nil
 		}
 
+		if event.Type == watch.Deleted {
+			return utility.JobCancelled, nil
+		}
+
 		jobEvent = event.Object.(*batch_v1.Job)
 		if jobEvent.Status.Succeeded >= int32(1) {
 			return utility.JobSucceeded, nil
@@ -244,15 +443,165 @@ func (client *client) JobExecutionStatus(jobExecutionID string) (string, error)
 	return utility.NoDefinitiveJobExecutionStatusFound, nil
 }
 
-func (client *client) getLogsStreamReaderFor(podName string) (io.ReadCloser, error) {
+func jobExecutionStatus(job *batch_v1.Job) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != v1.ConditionTrue {
+			continue
+		}
+		if condition.Type == batch_v1.JobComplete {
+			return utility.JobSucceeded
+		}
+		if condition.Type == batch_v1.JobFailed {
+			return utility.JobFailed
+		}
+	}
+
+	if job.Status.Succeeded >= int32(1) {
+		return utility.JobSucceeded
+	}
+	if job.Status.Active >= int32(1) {
+		return utility.JobRunning
+	}
+	return utility.JobWaiting
+}
+
+// GetJobStatus is the current status of a job, NOT_FOUND once the job is gone
+func (client *client) GetJobStatus(jobExecutionID string) (string, error) {
+	job, err := client.clientSet.BatchV1().Jobs(namespace).Get(context.Background(), jobExecutionID, meta_v1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return utility.JobNotFound, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return jobExecutionStatus(job), nil
+}
+
+// WatchJobStatuses sends the status of every job created by proctor and then of every change to the status of one,
+// until stopChan is closed. The informer lists the jobs again when the watch can't be resumed, which sends nothing
+// for jobs whose status didn't change meanwhile
+func (client *client) WatchJobStatuses(statusChan chan<- backend.JobStatus, stopChan <-chan struct{}) {
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(client.clientSet, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(listOptions *meta_v1.ListOptions) {
+			listOptions.LabelSelector = managedByLabelSelector
+		}),
+	)
+	send := func(job *batch_v1.Job, status string) {
+		select {
+		case statusChan <- backend.JobStatus{JobExecutionID: job.Name, Status: status}:
+		case <-stopChan:
+		}
+	}
+
+	informerFactory.Batch().V1().Jobs().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if job, ok := obj.(*batch_v1.Job); ok {
+				send(job, jobExecutionStatus(job))
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldJob, ok := oldObj.(*batch_v1.Job)
+			if !ok {
+				return
+			}
+			newJob, ok := newObj.(*batch_v1.Job)
+			if !ok {
+				return
+			}
+			if status := jobExecutionStatus(newJob); status != jobExecutionStatus(oldJob) {
+				send(newJob, status)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if job, ok := obj.(*batch_v1.Job); ok {
+				send(job, utility.JobCancelled)
+			}
+		},
+	})
+
+	informerFactory.Start(stopChan)
+	<-stopChan
+}
+
+// ListFinishedJobs includes jobs created before proctor labelled them as managed, which carry only the job label
+func (client *client) ListFinishedJobs() ([]backend.FinishedJob, error) {
+	listOptions := meta_v1.ListOptions{
+		TypeMeta:      typeMeta,
+		LabelSelector: "job",
+	}
+	jobList, err := client.clientSet.BatchV1().Jobs(namespace).List(context.Background(), listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	finishedJobs := []backend.FinishedJob{}
+	for i := range jobList.Items {
+		job := &jobList.Items[i]
+		if !strings.HasPrefix(job.Name, "proctor-") || job.Labels["job"] != job.Name {
+			continue
+		}
+
+		status := jobExecutionStatus(job)
+		if status != utility.JobSucceeded && status != utility.JobFailed {
+			continue
+		}
+		finishedJobs = append(finishedJobs, backend.FinishedJob{
+			Name:       job.Name,
+			Status:     status,
+			FinishedAt: jobFinishedAt(job),
+		})
+	}
+	return finishedJobs, nil
+}
+
+func jobFinishedAt(job *batch_v1.Job) time.Time {
+	if job.Status.CompletionTime != nil {
+		return job.Status.CompletionTime.Time
+	}
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batch_v1.JobComplete || condition.Type == batch_v1.JobFailed) && condition.Status == v1.ConditionTrue {
+			return condition.LastTransitionTime.Time
+		}
+	}
+	return job.CreationTimestamp.Time
+}
+
+// DeleteJob removes a job along with its pods
+func (client *client) DeleteJob(jobExecutionID string) error {
+	return client.CancelJob(jobExecutionID)
+}
+
+// CancelJob deletes the job along with its pods, a job which is already gone is not an error
+func (client *client) CancelJob(jobExecutionID string) error {
+	batchV1 := client.clientSet.BatchV1()
+	kubernetesJobs := batchV1.Jobs(namespace)
+
+	propagationPolicy := meta_v1.DeletePropagationForeground
+	err := kubernetesJobs.Delete(context.Background(), jobExecutionID, meta_v1.DeleteOptions{
+		PropagationPolicy: &propagationPolicy,
+	})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (client *client) getLogsStreamReaderFor(podName string, follow bool) (io.ReadCloser, error) {
 	logger.Debug("reading pod logs for: ", podName)
 
 	// Use the authenticated client instead of manually requesting the control plane
 	clt := client.clientSet.CoreV1()
 	req := clt.Pods(namespace).GetLogs(podName, &v1.PodLogOptions{
-		Follow: true,
+		Follow: follow,
 	})
 	logs, err := req.Stream(context.Background())
+	if errors.IsNotFound(err) {
+		return nil, backend.ErrNoLogs
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,7 @@ package kubernetes
 import (
 	"bufio"
 	"context"
+	"errors"
 	"net/http"
 	"os"
 	"testing"
@@ -12,10 +13,15 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	batch_v1 "k8s.io/client-go/kubernetes/typed/batch/v1"
+	"proctor/proctord/backend"
 	"proctor/proctord/config"
+	"proctor/proctord/jobs/metadata/pod"
+	"proctor/proctord/jobs/metadata/resources"
 	"proctor/proctord/utility"
 
 	batchV1 "k8s.io/api/batch/v1"
@@ -25,14 +31,14 @@ import (
 
 type ClientTestSuite struct {
 	suite.Suite
-	testClient             Client
+	testClient             backend.ExecutionBackend
 	testKubernetesJobs     batch_v1.JobInterface
 	fakeClientSet          *fakeclientset.Clientset
 	jobName                string
 	podName                string
 	fakeClientSetStreaming *fakeclientset.Clientset
 	fakeHttpClient         *http.Client
-	testClientStreaming    Client
+	testClientStreaming    backend.ExecutionBackend
 }
 
 func (suite *ClientTestSuite) SetupTest() {
@@ -74,7 +80,9 @@ func (suite *ClientTestSuite) TestJobExecution() {
 	envVarsForContainer := map[string]string{"SAMPLE_ARG": "samle-value"}
 	sampleImageName := "img1"
 
-	executedJobname, err := suite.testClient.ExecuteJob(sampleImageName, envVarsForContainer)
+	jobOptions := backend.JobOptions{Resources: resources.Requirements{Limits: resources.Quantities{CPU: "1"}}}
+
+	executedJobname, err := suite.testClient.ExecuteJob(sampleImageName, envVarsForContainer, jobOptions)
 	assert.NoError(t, err)
 
 	typeMeta := meta_v1.TypeMeta{
@@ -111,8 +119,190 @@ func (suite *ClientTestSuite) TestJobExecution() {
 
 	assert.Equal(t, sampleImageName, container.Image)
 
-	expectedEnvVars := getEnvVars(envVarsForContainer)
+	expectedEnvVars := getEnvVars(executedJobname, envVarsForContainer, nil)
 	assert.Equal(t, expectedEnvVars, container.Env)
+
+	assert.Equal(t, "1", container.Resources.Limits.Cpu().String())
+	assert.Equal(t, config.KubeJobDefaultMemoryLimit(), container.Resources.Limits.Memory().String())
+	assert.Equal(t, config.KubeJobDefaultCPURequest(), container.Resources.Requests.Cpu().String())
+	assert.Equal(t, config.KubeJobDefaultMemoryRequest(), container.Resources.Requests.Memory().String())
+}
+
+func (suite *ClientTestSuite) TestJobExecutionWithInvalidResources() {
+	t := suite.T()
+
+	jobOptions := backend.JobOptions{Resources: resources.Requirements{Limits: resources.Quantities{Memory: "lots"}}}
+
+	_, err := suite.testClient.ExecuteJob("img1", map[string]string{}, jobOptions)
+	assert.Error(t, err)
+}
+
+func (suite *ClientTestSuite) TestJobExecutionWithDeadlineAndRetries() {
+	t := suite.T()
+
+	activeDeadlineSeconds := int64(10800)
+	backoffLimit := int32(3)
+	jobOptions := backend.JobOptions{ActiveDeadlineSeconds: &activeDeadlineSeconds, BackoffLimit: &backoffLimit}
+
+	executedJobname, err := suite.testClient.ExecuteJob("img1", map[string]string{}, jobOptions)
+	assert.NoError(t, err)
+
+	executedJob, err := suite.fakeClientSet.BatchV1().Jobs(config.DefaultNamespace()).Get(context.Background(), executedJobname, meta_v1.GetOptions{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, &activeDeadlineSeconds, executedJob.Spec.ActiveDeadlineSeconds)
+	assert.Equal(t, &backoffLimit, executedJob.Spec.BackoffLimit)
+}
+
+func (suite *ClientTestSuite) TestJobExecutionWithLabelsAndReason() {
+	t := suite.T()
+	os.Setenv("PROCTOR_JOB_POD_ANNOTATIONS", "{\"key.one\":\"true\"}")
+
+	jobOptions := backend.JobOptions{
+		Labels: map[string]string{"ticket": "OPS-123", "incident url": "https://status.example.com/incidents/42"},
+		Reason: "Clearing the stuck payments queue",
+	}
+
+	executedJobname, err := suite.testClient.ExecuteJob("img1", map[string]string{}, jobOptions)
+	assert.NoError(t, err)
+
+	executedJob, err := suite.fakeClientSet.BatchV1().Jobs(config.DefaultNamespace()).Get(context.Background(), executedJobname, meta_v1.GetOptions{})
+	assert.NoError(t, err)
+
+	expectedLabels := jobLabel(executedJobname)
+	expectedLabels["label.proctor/ticket"] = "OPS-123"
+	expectedLabels["label.proctor/incident-url"] = "https---status.example.com-incidents-42"
+	assert.Equal(t, expectedLabels, executedJob.ObjectMeta.Labels)
+	assert.Equal(t, expectedLabels, executedJob.Spec.Template.ObjectMeta.Labels)
+
+	expectedAnnotations := map[string]string{
+		"key.one":                    "true",
+		"label.proctor/ticket":       "OPS-123",
+		"label.proctor/incident-url": "https://status.example.com/incidents/42",
+		"proctor/reason":             "Clearing the stuck payments queue",
+	}
+	assert.Equal(t, expectedAnnotations, executedJob.Spec.Template.Annotations)
+}
+
+func (suite *ClientTestSuite) TestJobExecutionWithPodSpec() {
+	t := suite.T()
+
+	jobOptions := backend.JobOptions{Pod: pod.Spec{
+		ServiceAccountName: "reports",
+		NodeSelector:       map[string]string{"cloud.google.com/gke-nodepool": "batch"},
+		Tolerations:        []pod.Toleration{{Key: "dedicated", Operator: "Equal", Value: "batch", Effect: "NoSchedule"}},
+		Volumes: []pod.Volume{
+			{Name: "ca-bundle", MountPath: "/etc/ssl/certs", ConfigMap: "ca-bundle"},
+			{Name: "config", MountPath: "/etc/reports", Secret: "reports-config"},
+			{Name: "scratch", MountPath: "/scratch", EmptyDir: &pod.EmptyDir{SizeLimit: "1Gi"}},
+		},
+	}}
+
+	executedJobname, err := suite.testClient.ExecuteJob("img1", map[string]string{}, jobOptions)
+	assert.NoError(t, err)
+
+	executedJob, err := suite.fakeClientSet.BatchV1().Jobs(config.DefaultNamespace()).Get(context.Background(), executedJobname, meta_v1.GetOptions{})
+	assert.NoError(t, err)
+
+	podSpec := executedJob.Spec.Template.Spec
+	assert.Equal(t, "reports", podSpec.ServiceAccountName)
+	assert.Equal(t, map[string]string{"cloud.google.com/gke-nodepool": "batch"}, podSpec.NodeSelector)
+	assert.Equal(t, []v1.Toleration{{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "batch", Effect: v1.TaintEffectNoSchedule}}, podSpec.Tolerations)
+
+	sizeLimit := resource.MustParse("1Gi")
+	assert.Equal(t, []v1.Volume{
+		{Name: "ca-bundle", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "ca-bundle"}}}},
+		{Name: "config", VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "reports-config"}}},
+		{Name: "scratch", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{SizeLimit: &sizeLimit}}},
+	}, podSpec.Volumes)
+	assert.Equal(t, []v1.VolumeMount{
+		{Name: "ca-bundle", MountPath: "/etc/ssl/certs", ReadOnly: true},
+		{Name: "config", MountPath: "/etc/reports", ReadOnly: true},
+		{Name: "scratch", MountPath: "/scratch"},
+	}, podSpec.Containers[0].VolumeMounts)
+}
+
+func (suite *ClientTestSuite) TestJobExecutionWithSecrets() {
+	t := suite.T()
+
+	envVarsForContainer := map[string]string{"SAMPLE_ARG": "sample-value"}
+	jobOptions := backend.JobOptions{Secrets: map[string]string{"SAMPLE_SECRET": "secret-value"}}
+
+	executedJobname, err := suite.testClient.ExecuteJob("img1", envVarsForContainer, jobOptions)
+	assert.NoError(t, err)
+
+	namespace := config.DefaultNamespace()
+	executedJob, err := suite.fakeClientSet.BatchV1().Jobs(namespace).Get(context.Background(), executedJobname, meta_v1.GetOptions{})
+	assert.NoError(t, err)
+
+	container := executedJob.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, []v1.EnvVar{
+		{Name: "SAMPLE_ARG", Value: "sample-value"},
+		{Name: "SAMPLE_SECRET", ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: executedJobname},
+			Key:                  "SAMPLE_SECRET",
+		}}},
+	}, container.Env)
+
+	executedJobSecret, err := suite.fakeClientSet.CoreV1().Secrets(namespace).Get(context.Background(), executedJobname, meta_v1.GetOptions{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string][]byte{"SAMPLE_SECRET": []byte("secret-value")}, executedJobSecret.Data)
+	assert.Equal(t, jobLabel(executedJobname), executedJobSecret.Labels)
+	assert.Equal(t, "Job", executedJobSecret.OwnerReferences[0].Kind)
+	assert.Equal(t, executedJobname, executedJobSecret.OwnerReferences[0].Name)
+}
+
+func (suite *ClientTestSuite) TestJobExecutionWithArgNamedAsSecret() {
+	t := suite.T()
+
+	envVarsForContainer := map[string]string{"SAMPLE_SECRET": "user-value"}
+	jobOptions := backend.JobOptions{Secrets: map[string]string{"SAMPLE_SECRET": "secret-value"}}
+
+	executedJobname, err := suite.testClient.ExecuteJob("img1", envVarsForContainer, jobOptions)
+	assert.NoError(t, err)
+
+	executedJob, err := suite.fakeClientSet.BatchV1().Jobs(config.DefaultNamespace()).Get(context.Background(), executedJobname, meta_v1.GetOptions{})
+	assert.NoError(t, err)
+
+	container := executedJob.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, []v1.EnvVar{
+		{Name: "SAMPLE_SECRET", ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: executedJobname},
+			Key:                  "SAMPLE_SECRET",
+		}}},
+	}, container.Env)
+	assert.Empty(t, container.EnvFrom)
+}
+
+func (suite *ClientTestSuite) TestJobExecutionWithoutSecrets() {
+	t := suite.T()
+
+	executedJobname, err := suite.testClient.ExecuteJob("img1", map[string]string{}, backend.JobOptions{})
+	assert.NoError(t, err)
+
+	namespace := config.DefaultNamespace()
+	executedJob, err := suite.fakeClientSet.BatchV1().Jobs(namespace).Get(context.Background(), executedJobname, meta_v1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, executedJob.Spec.Template.Spec.Containers[0].Env)
+
+	_, err = suite.fakeClientSet.CoreV1().Secrets(namespace).Get(context.Background(), executedJobname, meta_v1.GetOptions{})
+	assert.Error(t, err)
+}
+
+func (suite *ClientTestSuite) TestJobExecutionDeletesJobOnSecretCreationFailure() {
+	t := suite.T()
+
+	suite.fakeClientSet.PrependReactor("create", "secrets", func(action testing_kubernetes.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("secret creation failed")
+	})
+
+	_, err := suite.testClient.ExecuteJob("img1", map[string]string{}, backend.JobOptions{Secrets: map[string]string{"SAMPLE_SECRET": "secret-value"}})
+	assert.Error(t, err)
+
+	listOfJobs, err := suite.fakeClientSet.BatchV1().Jobs(config.DefaultNamespace()).List(context.Background(), meta_v1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, listOfJobs.Items)
 }
 
 func (suite *ClientTestSuite) TestStreamLogsSuccess() {
@@ -121,7 +311,7 @@ func (suite *ClientTestSuite) TestStreamLogsSuccess() {
 	httpmock.ActivateNonDefault(suite.fakeHttpClient)
 	defer httpmock.DeactivateAndReset()
 
-	logStream, err := suite.testClientStreaming.StreamJobLogs(suite.jobName)
+	logStream, err := suite.testClientStreaming.StreamJobLogs(suite.jobName, true)
 	assert.NoError(t, err)
 
 	defer logStream.Close()
@@ -138,8 +328,59 @@ func (suite *ClientTestSuite) TestStreamLogsSuccess() {
 func (suite *ClientTestSuite) TestStreamLogsPodNotFoundFailure() {
 	t := suite.T()
 
-	_, err := suite.testClientStreaming.StreamJobLogs("unknown-job")
-	assert.Error(t, err)
+	_, err := suite.testClientStreaming.StreamJobLogs("unknown-job", true)
+	assert.Equal(t, backend.ErrNoLogs, err)
+}
+
+func (suite *ClientTestSuite) TestStreamLogsOfFinishedJobWithoutPods() {
+	t := suite.T()
+
+	_, err := suite.fakeClientSetStreaming.BatchV1().Jobs(config.DefaultNamespace()).Create(context.Background(), &batchV1.Job{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "finished-job", Namespace: config.DefaultNamespace(), Labels: jobLabel("finished-job")},
+		Status:     batchV1.JobStatus{Failed: 1, Conditions: []batchV1.JobCondition{{Type: batchV1.JobFailed, Status: v1.ConditionTrue}}},
+	}, meta_v1.CreateOptions{})
+	assert.NoError(t, err)
+
+	_, err = suite.testClientStreaming.StreamJobLogs("finished-job", false)
+	assert.Equal(t, backend.ErrNoLogs, err)
+}
+
+func (suite *ClientTestSuite) TestStreamLogsOfEvictedPod() {
+	t := suite.T()
+
+	_, err := suite.fakeClientSetStreaming.CoreV1().Pods(config.DefaultNamespace()).Create(context.Background(), &v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "evicted-pod", Namespace: config.DefaultNamespace(), Labels: map[string]string{"job": "evicted-job"}},
+		Status:     v1.PodStatus{Phase: v1.PodFailed, Reason: "Evicted"},
+	}, meta_v1.CreateOptions{})
+	assert.NoError(t, err)
+
+	_, err = suite.testClientStreaming.StreamJobLogs("evicted-job", false)
+	assert.Equal(t, backend.ErrNoLogs, err)
+}
+
+func (suite *ClientTestSuite) TestStreamLogsOfPendingPodWithoutFollow() {
+	t := suite.T()
+
+	_, err := suite.fakeClientSetStreaming.CoreV1().Pods(config.DefaultNamespace()).Create(context.Background(), &v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "pending-pod", Namespace: config.DefaultNamespace(), Labels: map[string]string{"job": "pending-job"}},
+		Status:     v1.PodStatus{Phase: v1.PodPending},
+	}, meta_v1.CreateOptions{})
+	assert.NoError(t, err)
+
+	_, err = suite.testClientStreaming.StreamJobLogs("pending-job", false)
+	assert.Equal(t, backend.ErrNoLogs, err)
+}
+
+func (suite *ClientTestSuite) TestStreamLogsOfUnfinishedJobWithoutPodsWithoutFollow() {
+	t := suite.T()
+
+	_, err := suite.fakeClientSetStreaming.BatchV1().Jobs(config.DefaultNamespace()).Create(context.Background(), &batchV1.Job{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "unstarted-job", Namespace: config.DefaultNamespace(), Labels: jobLabel("unstarted-job")},
+	}, meta_v1.CreateOptions{})
+	assert.NoError(t, err)
+
+	_, err = suite.testClientStreaming.StreamJobLogs("unstarted-job", false)
+	assert.Equal(t, backend.ErrNoLogs, err)
 }
 
 func (suite *ClientTestSuite) TestShouldReturnSuccessJobExecutionStatus() {
@@ -268,6 +509,173 @@ func (suite *ClientTestSuite) TestShouldReturnJobExecutionStatusFetchError() {
 	assert.Equal(t, utility.JobExecutionStatusFetchError, jobExecutionStatus, "Should return JOB_EXECUTION_STATUS_FETCH_ERROR")
 }
 
+func (suite *ClientTestSuite) TestShouldReturnCancelledJobExecutionStatusForDeletedJob() {
+	t := suite.T()
+
+	watcher := watch.NewFake()
+	suite.fakeClientSet.PrependWatchReactor("jobs", testing_kubernetes.DefaultWatchReactor(watcher, nil))
+
+	var activeJob batchV1.Job
+	uniqueJobName := "proctor-job-4"
+	activeJob.ObjectMeta = meta_v1.ObjectMeta{
+		Name:   uniqueJobName,
+		Labels: jobLabel(uniqueJobName),
+	}
+
+	go func() {
+		activeJob.Status.Active = 1
+		watcher.Modify(&activeJob)
+		watcher.Delete(&activeJob)
+
+		time.Sleep(time.Second * 1)
+		watcher.Stop()
+	}()
+
+	jobExecutionStatus, err := suite.testClient.JobExecutionStatus(uniqueJobName)
+	assert.NoError(t, err)
+
+	assert.Equal(t, utility.JobCancelled, jobExecutionStatus, "Should return CANCELLED")
+}
+
+func (suite *ClientTestSuite) TestCancelJob() {
+	t := suite.T()
+
+	executedJobname, err := suite.testClient.ExecuteJob("img1", map[string]string{}, backend.JobOptions{})
+	assert.NoError(t, err)
+
+	err = suite.testClient.CancelJob(executedJobname)
+	assert.NoError(t, err)
+
+	deleteAction := suite.fakeClientSet.Actions()[len(suite.fakeClientSet.Actions())-1].(testing_kubernetes.DeleteAction)
+	assert.Equal(t, executedJobname, deleteAction.GetName())
+
+	_, err = suite.fakeClientSet.BatchV1().Jobs(config.DefaultNamespace()).Get(context.Background(), executedJobname, meta_v1.GetOptions{})
+	assert.Error(t, err)
+}
+
+func (suite *ClientTestSuite) TestCancelJobForMissingJob() {
+	t := suite.T()
+
+	err := suite.testClient.CancelJob("proctor-job-5")
+	assert.NoError(t, err)
+}
+
+func (suite *ClientTestSuite) TestGetJobStatus() {
+	t := suite.T()
+
+	executedJobname, err := suite.testClient.ExecuteJob("img1", map[string]string{}, backend.JobOptions{})
+	assert.NoError(t, err)
+
+	jobExecutionStatus, err := suite.testClient.GetJobStatus(executedJobname)
+	assert.NoError(t, err)
+	assert.Equal(t, utility.JobWaiting, jobExecutionStatus)
+}
+
+func (suite *ClientTestSuite) TestGetJobStatusForMissingJob() {
+	t := suite.T()
+
+	jobExecutionStatus, err := suite.testClient.GetJobStatus("proctor-job-6")
+	assert.NoError(t, err)
+	assert.Equal(t, utility.JobNotFound, jobExecutionStatus)
+}
+
+func (suite *ClientTestSuite) TestJobExecutionStatusOfJob() {
+	t := suite.T()
+
+	job := &batchV1.Job{}
+	assert.Equal(t, utility.JobWaiting, jobExecutionStatus(job))
+
+	job.Status.Active = 1
+	assert.Equal(t, utility.JobRunning, jobExecutionStatus(job))
+
+	job.Status.Failed = 1
+	assert.Equal(t, utility.JobRunning, jobExecutionStatus(job), "a failed pod is retried until the job fails")
+
+	job.Status.Conditions = []batchV1.JobCondition{{Type: batchV1.JobFailed, Status: v1.ConditionTrue}}
+	assert.Equal(t, utility.JobFailed, jobExecutionStatus(job))
+
+	job.Status.Conditions = []batchV1.JobCondition{{Type: batchV1.JobComplete, Status: v1.ConditionTrue}}
+	assert.Equal(t, utility.JobSucceeded, jobExecutionStatus(job))
+}
+
+func (suite *ClientTestSuite) TestWatchJobStatuses() {
+	t := suite.T()
+
+	executedJobname, err := suite.testClient.ExecuteJob("img1", map[string]string{}, backend.JobOptions{})
+	assert.NoError(t, err)
+
+	watcher := watch.NewFake()
+	suite.fakeClientSet.PrependWatchReactor("jobs", testing_kubernetes.DefaultWatchReactor(watcher, nil))
+
+	statusChan := make(chan backend.JobStatus)
+	stopChan := make(chan struct{})
+	watchStopped := make(chan bool)
+	go func() {
+		suite.testClient.WatchJobStatuses(statusChan, stopChan)
+		watchStopped <- true
+	}()
+
+	assert.Equal(t, backend.JobStatus{JobExecutionID: executedJobname, Status: utility.JobWaiting}, <-statusChan)
+
+	namespace := config.DefaultNamespace()
+	annotatedJob := &batchV1.Job{ObjectMeta: meta_v1.ObjectMeta{Name: executedJobname, Namespace: namespace, Labels: jobLabel(executedJobname), Annotations: map[string]string{"key": "value"}}}
+
+	runningJob := &batchV1.Job{ObjectMeta: meta_v1.ObjectMeta{Name: executedJobname, Namespace: namespace, Labels: jobLabel(executedJobname)}}
+	runningJob.Status.Active = 1
+	go func() {
+		watcher.Modify(annotatedJob)
+		watcher.Modify(runningJob)
+	}()
+	// the change of annotations leaves the status as it was, so the running status is the next one sent
+	assert.Equal(t, backend.JobStatus{JobExecutionID: executedJobname, Status: utility.JobRunning}, <-statusChan)
+
+	go watcher.Delete(runningJob)
+	assert.Equal(t, backend.JobStatus{JobExecutionID: executedJobname, Status: utility.JobCancelled}, <-statusChan)
+
+	close(stopChan)
+	<-watchStopped
+}
+
+func (suite *ClientTestSuite) TestListFinishedJobs() {
+	t := suite.T()
+
+	namespace := config.DefaultNamespace()
+	completedAt := meta_v1.NewTime(time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC))
+	failedAt := meta_v1.NewTime(time.Date(2019, 5, 2, 10, 0, 0, 0, time.UTC))
+	jobs := []*batchV1.Job{
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "proctor-job-1", Namespace: namespace, Labels: jobLabel("proctor-job-1")},
+			Status:     batchV1.JobStatus{Succeeded: 1, CompletionTime: &completedAt},
+		},
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "proctor-job-2", Namespace: namespace, Labels: map[string]string{"job": "proctor-job-2"}},
+			Status: batchV1.JobStatus{Failed: 1, Conditions: []batchV1.JobCondition{
+				{Type: batchV1.JobFailed, Status: v1.ConditionTrue, LastTransitionTime: failedAt},
+			}},
+		},
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "proctor-job-3", Namespace: namespace, Labels: jobLabel("proctor-job-3")},
+			Status:     batchV1.JobStatus{Active: 1},
+		},
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "other-job", Namespace: namespace, Labels: map[string]string{"job": "other-job"}},
+			Status:     batchV1.JobStatus{Succeeded: 1, CompletionTime: &completedAt},
+		},
+	}
+	for _, job := range jobs {
+		_, err := suite.fakeClientSet.BatchV1().Jobs(namespace).Create(context.Background(), job, meta_v1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+
+	finishedJobs, err := suite.testClient.ListFinishedJobs()
+	assert.NoError(t, err)
+
+	assert.ElementsMatch(t, []backend.FinishedJob{
+		{Name: "proctor-job-1", Status: utility.JobSucceeded, FinishedAt: completedAt.Time},
+		{Name: "proctor-job-2", Status: utility.JobFailed, FinishedAt: failedAt.Time},
+	}, finishedJobs)
+}
+
 func TestClientTestSuite(t *testing.T) {
 	suite.Run(t, new(ClientTestSuite))
 }
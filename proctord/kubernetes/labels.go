@@ -0,0 +1,55 @@
+package kubernetes
+
+import (
+	"strings"
+
+	"proctor/proctord/config"
+)
+
+const executionLabelPrefix = "label.proctor/"
+const reasonAnnotationKey = "proctor/reason"
+const maxLabelLength = 63
+
+// SanitizeLabel turns free-form text into a valid label name or value: at most 63 letters, digits, '-', '_' or '.',
+// beginning and ending with a letter or digit. It can end up empty
+func SanitizeLabel(text string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		if isAlphanumeric(r) || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '-'
+	}, text)
+
+	if len(sanitized) > maxLabelLength {
+		sanitized = sanitized[:maxLabelLength]
+	}
+	return strings.TrimFunc(sanitized, func(r rune) bool { return !isAlphanumeric(r) })
+}
+
+func isAlphanumeric(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// executionLabels adds the sanitized labels of an execution to those proctor selects its jobs by
+func executionLabels(jobName string, labels map[string]string) map[string]string {
+	executionLabels := jobLabel(jobName)
+	for key, value := range labels {
+		executionLabels[executionLabelPrefix+SanitizeLabel(key)] = SanitizeLabel(value)
+	}
+	return executionLabels
+}
+
+// executionAnnotations keep the labels of an execution as given, sanitizing a label can lose information
+func executionAnnotations(labels map[string]string, reason string) map[string]string {
+	annotations := make(map[string]string)
+	for key, value := range config.JobPodAnnotations() {
+		annotations[key] = value
+	}
+	for key, value := range labels {
+		annotations[executionLabelPrefix+SanitizeLabel(key)] = value
+	}
+	if reason != "" {
+		annotations[reasonAnnotationKey] = reason
+	}
+	return annotations
+}
@@ -0,0 +1,21 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeLabel(t *testing.T) {
+	assert.Equal(t, "OPS-123", SanitizeLabel("OPS-123"))
+	assert.Equal(t, "restart-after-deploy", SanitizeLabel("restart after deploy"))
+	assert.Equal(t, "user-example.com", SanitizeLabel("_user@example.com!"))
+	assert.Equal(t, "", SanitizeLabel("#!"))
+}
+
+func TestSanitizeLabelTruncates(t *testing.T) {
+	sanitized := SanitizeLabel(strings.Repeat("a", 62) + "-b")
+
+	assert.Equal(t, strings.Repeat("a", 62), sanitized)
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"proctor/proctord/auth"
+	"proctor/proctord/logger"
+	"proctor/proctord/utility"
+
+	"github.com/getsentry/raven-go"
+)
+
+func Authenticate(authenticator auth.Authenticator) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			userEmail := r.Header.Get(utility.UserEmailHeaderKey)
+			accessToken := r.Header.Get(utility.AccessTokenHeaderKey)
+
+			user, err := authenticator.Authenticate(userEmail, accessToken)
+			if err != nil {
+				if err == auth.ErrInvalidCredentials {
+					logger.Info(fmt.Sprintf("User: %s: authentication failed for %s", userEmail, r.URL.Path))
+					w.WriteHeader(http.StatusUnauthorized)
+					w.Write([]byte(utility.UnauthenticatedClientError))
+					return
+				}
+
+				logger.Error(fmt.Sprintf("User: %s: Error authenticating request", userEmail), err.Error())
+				raven.CaptureError(err, map[string]string{"user_email": userEmail})
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(utility.ServerError))
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), user)))
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"proctor/proctord/auth"
+	"proctor/proctord/utility"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticate(t *testing.T) {
+	mockAuthenticator := &auth.MockAuthenticator{}
+	user := auth.User{Email: "mrproctor@example.com"}
+	mockAuthenticator.On("Authenticate", "mrproctor@example.com", "access-token").Return(user, nil).Once()
+
+	var authenticatedUser auth.User
+	handler := Authenticate(mockAuthenticator)(func(w http.ResponseWriter, r *http.Request) {
+		authenticatedUser, _ = auth.FromContext(r.Context())
+	})
+
+	req := httptest.NewRequest("GET", "/jobs/metadata", nil)
+	req.Header.Set(utility.UserEmailHeaderKey, "mrproctor@example.com")
+	req.Header.Set(utility.AccessTokenHeaderKey, "access-token")
+	responseRecorder := httptest.NewRecorder()
+
+	handler(responseRecorder, req)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, user, authenticatedUser)
+	mockAuthenticator.AssertExpectations(t)
+}
+
+func TestAuthenticateForInvalidCredentials(t *testing.T) {
+	mockAuthenticator := &auth.MockAuthenticator{}
+	mockAuthenticator.On("Authenticate", "mrproctor@example.com", "invalid-token").Return(auth.User{}, auth.ErrInvalidCredentials).Once()
+
+	handler := Authenticate(mockAuthenticator)(func(w http.ResponseWriter, r *http.Request) {
+		assert.Fail(t, "should not call next handler")
+	})
+
+	req := httptest.NewRequest("GET", "/jobs/metadata", nil)
+	req.Header.Set(utility.UserEmailHeaderKey, "mrproctor@example.com")
+	req.Header.Set(utility.AccessTokenHeaderKey, "invalid-token")
+	responseRecorder := httptest.NewRecorder()
+
+	handler(responseRecorder, req)
+
+	assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+	assert.Equal(t, utility.UnauthenticatedClientError, responseRecorder.Body.String())
+	mockAuthenticator.AssertExpectations(t)
+}
+
+func TestAuthenticateForAuthenticatorFailure(t *testing.T) {
+	mockAuthenticator := &auth.MockAuthenticator{}
+	mockAuthenticator.On("Authenticate", "mrproctor@example.com", "access-token").Return(auth.User{}, errors.New("error")).Once()
+
+	handler := Authenticate(mockAuthenticator)(func(w http.ResponseWriter, r *http.Request) {
+		assert.Fail(t, "should not call next handler")
+	})
+
+	req := httptest.NewRequest("GET", "/jobs/metadata", nil)
+	req.Header.Set(utility.UserEmailHeaderKey, "mrproctor@example.com")
+	req.Header.Set(utility.AccessTokenHeaderKey, "access-token")
+	responseRecorder := httptest.NewRecorder()
+
+	handler(responseRecorder, req)
+
+	assert.Equal(t, http.StatusInternalServerError, responseRecorder.Code)
+	assert.Equal(t, utility.ServerError, responseRecorder.Body.String())
+	mockAuthenticator.AssertExpectations(t)
+}
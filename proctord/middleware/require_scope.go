@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"proctor/proctord/auth"
+	"proctor/proctord/logger"
+	"proctor/proctord/utility"
+)
+
+func RequireScope(scope string) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			user, _ := auth.FromContext(r.Context())
+			if !user.HasScope(scope) {
+				logger.Info(fmt.Sprintf("User: %s: access token without %s scope used for %s", user.Email, scope, r.URL.Path))
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(utility.InsufficientScopeClientError))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+	}
+}
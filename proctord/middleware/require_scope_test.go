@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"proctor/proctord/auth"
+	"proctor/proctord/utility"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireScope(t *testing.T) {
+	for _, scopes := range [][]string{nil, {auth.ScheduleScope, auth.ExecuteScope}} {
+		nextHandlerCalled := false
+		handler := RequireScope(auth.ExecuteScope)(func(w http.ResponseWriter, r *http.Request) {
+			nextHandlerCalled = true
+		})
+
+		req := httptest.NewRequest("POST", "/jobs/execute", nil)
+		req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: "mrproctor@example.com", Scopes: scopes}))
+		responseRecorder := httptest.NewRecorder()
+
+		handler(responseRecorder, req)
+
+		assert.Equal(t, http.StatusOK, responseRecorder.Code)
+		assert.True(t, nextHandlerCalled)
+	}
+}
+
+func TestRequireScopeForAccessTokenWithoutScope(t *testing.T) {
+	handler := RequireScope(auth.ExecuteScope)(func(w http.ResponseWriter, r *http.Request) {
+		assert.Fail(t, "should not call next handler")
+	})
+
+	req := httptest.NewRequest("POST", "/jobs/execute", nil)
+	req = req.WithContext(auth.NewContext(req.Context(), auth.User{Email: "mrproctor@example.com", Scopes: []string{auth.ScheduleScope}}))
+	responseRecorder := httptest.NewRecorder()
+
+	handler(responseRecorder, req)
+
+	assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	assert.Equal(t, utility.InsufficientScopeClientError, responseRecorder.Body.String())
+}
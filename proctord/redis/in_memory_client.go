@@ -0,0 +1,67 @@
+package redis
+
+import (
+	"path"
+	"sort"
+	"sync"
+
+	"github.com/garyburd/redigo/redis"
+)
+
+type inMemoryClient struct {
+	mutex  sync.RWMutex
+	values map[string][]byte
+}
+
+// NewInMemoryClient keeps keys in a map of the process, it stands in for redis in tests
+func NewInMemoryClient() Client {
+	return &inMemoryClient{values: make(map[string][]byte)}
+}
+
+func (c *inMemoryClient) GET(key string) ([]byte, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	value, ok := c.values[key]
+	if !ok {
+		return nil, redis.ErrNil
+	}
+	return value, nil
+}
+
+func (c *inMemoryClient) SET(key string, value []byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.values[key] = append([]byte{}, value...)
+	return nil
+}
+
+func (c *inMemoryClient) KEYS(pattern string) ([]string, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	keys := []string{}
+	for key := range c.values {
+		matched, err := path.Match(pattern, key)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (c *inMemoryClient) MGET(keys ...interface{}) ([][]byte, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		values[i] = c.values[key.(string)]
+	}
+	return values, nil
+}
@@ -0,0 +1,33 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryClient(t *testing.T) {
+	client := NewInMemoryClient()
+
+	assert.NoError(t, client.SET("one-metadata", []byte("1")))
+	assert.NoError(t, client.SET("two-metadata", []byte("2")))
+	assert.NoError(t, client.SET("one-secret", []byte("secret")))
+
+	value, err := client.GET("one-metadata")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("1"), value)
+
+	keys, err := client.KEYS("*-metadata")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"one-metadata", "two-metadata"}, keys)
+
+	values, err := client.MGET("two-metadata", "three-metadata")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("2"), nil}, values)
+}
+
+func TestInMemoryClientGetMissingKey(t *testing.T) {
+	_, err := NewInMemoryClient().GET("missing")
+
+	assert.EqualError(t, err, "redigo: nil returned")
+}
@@ -6,13 +6,12 @@ import (
 	"time"
 
 	"proctor/proctord/audit"
+	"proctor/proctord/backend/configured"
 	"proctor/proctord/config"
-	http_client "proctor/proctord/http"
 	"proctor/proctord/jobs/execution"
 	"proctor/proctord/jobs/metadata"
 	"proctor/proctord/jobs/schedule"
 	"proctor/proctord/jobs/secrets"
-	"proctor/proctord/kubernetes"
 	"proctor/proctord/mail"
 	"proctor/proctord/redis"
 	"proctor/proctord/storage"
@@ -29,16 +28,14 @@ func Start() error {
 	metadataStore := metadata.NewStore(redisClient)
 	secretsStore := secrets.NewStore(redisClient)
 
-	httpClient, err := http_client.NewClient()
+	executionBackend, err := configured.New()
 	if err != nil {
 		return err
 	}
-	kubeConfig := kubernetes.KubeConfig()
-	kubeClient := kubernetes.NewClient(kubeConfig, httpClient)
 
-	jobExecutioner := execution.NewExecutioner(kubeClient, metadataStore, secretsStore)
+	jobExecutioner := execution.NewExecutioner(executionBackend, metadataStore, secretsStore)
 
-	auditor := audit.New(store, kubeClient)
+	auditor := audit.New(store, executionBackend)
 
 	mailer := mail.New(config.MailServerHost(), config.MailServerPort())
 
@@ -1,11 +1,19 @@
 package server
 
 import (
+	"net/http"
+	"os"
+	"proctor/proctord/backend/configured"
 	"proctor/proctord/config"
 	"proctor/proctord/instrumentation"
+	"proctor/proctord/jobs/callback"
+	"proctor/proctord/jobs/execution"
+	"proctor/proctord/jobs/gc"
 	"proctor/proctord/logger"
 	"proctor/proctord/redis"
+	"proctor/proctord/storage"
 	"proctor/proctord/storage/postgres"
+	"syscall"
 	"time"
 
 	"github.com/tylerb/graceful"
@@ -22,17 +30,63 @@ func Start() error {
 	}
 	appPort := ":" + config.AppPort()
 
+	executionBackend, err := configured.New()
+	if err != nil {
+		return err
+	}
+
+	store := storage.New(postgresClient)
+
 	server := negroni.New(negroni.NewRecovery())
-	router, err := NewRouter(postgresClient, redisClient)
+	router, err := NewRouter(store, redisClient, executionBackend)
 	if err != nil {
 		return err
 	}
 	server.UseHandler(router)
 
+	statusController := execution.NewStatusController(store, executionBackend)
+	reconcileTicker := time.NewTicker(time.Duration(config.ExecutionStatusReconcileIntervalInMins()) * time.Minute)
+	statusSignalsChan := make(chan os.Signal, 1)
+	statusControllerStopped := make(chan bool)
+	go func() {
+		statusController.Run(reconcileTicker.C, statusSignalsChan)
+		statusControllerStopped <- true
+	}()
+
+	jobCollector := gc.NewCollector(executionBackend, store,
+		time.Duration(config.SucceededJobTTLInMins())*time.Minute, time.Duration(config.FailedJobTTLInMins())*time.Minute, config.JobLogsArchiveDir())
+	gcTicker := time.NewTicker(time.Duration(config.JobGCIntervalInMins()) * time.Minute)
+	gcSignalsChan := make(chan os.Signal, 1)
+	jobCollectorStopped := make(chan bool)
+	go func() {
+		jobCollector.Run(gcTicker.C, gcSignalsChan)
+		jobCollectorStopped <- true
+	}()
+
+	callbackClient := &http.Client{Timeout: time.Duration(config.StatusCallbackTimeoutInSeconds()) * time.Second}
+	callbackDispatcher := callback.NewDispatcher(store, callbackClient, config.StatusCallbackMaxAttempts(), time.Duration(config.StatusCallbackBackoffInSeconds())*time.Second)
+	callbackTicker := time.NewTicker(time.Duration(config.StatusCallbackDispatchIntervalInSeconds()) * time.Second)
+	callbackSignalsChan := make(chan os.Signal, 1)
+	callbackDispatcherStopped := make(chan bool)
+	go func() {
+		callbackDispatcher.Run(callbackTicker.C, callbackSignalsChan)
+		callbackDispatcherStopped <- true
+	}()
+
 	logger.Info("Starting server on port", appPort)
 
 	graceful.Run(appPort, 2*time.Second, server)
 
+	callbackTicker.Stop()
+	callbackSignalsChan <- syscall.SIGTERM
+	<-callbackDispatcherStopped
+	reconcileTicker.Stop()
+	statusSignalsChan <- syscall.SIGTERM
+	<-statusControllerStopped
+	gcTicker.Stop()
+	gcSignalsChan <- syscall.SIGTERM
+	<-jobCollectorStopped
+
 	postgresClient.Close()
 	logger.Info("Stopped server gracefully")
 	return nil
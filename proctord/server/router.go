@@ -5,46 +5,54 @@ import (
 	"net/http"
 	"path"
 	"proctor/proctord/audit"
+	"proctor/proctord/auth"
+	"proctor/proctord/backend"
 	"proctor/proctord/config"
 	"proctor/proctord/docs"
-	http_client "proctor/proctord/http"
 	"proctor/proctord/instrumentation"
 	"proctor/proctord/jobs/execution"
 	"proctor/proctord/jobs/logs"
 	"proctor/proctord/jobs/metadata"
 	"proctor/proctord/jobs/schedule"
 	"proctor/proctord/jobs/secrets"
-	"proctor/proctord/kubernetes"
 	"proctor/proctord/middleware"
 	"proctor/proctord/redis"
 	"proctor/proctord/storage"
-	"proctor/proctord/storage/postgres"
+	"proctor/proctord/tokens"
 
 	"github.com/gorilla/mux"
 )
 
-func NewRouter(postgresClient postgres.Client, redisClient redis.Client) (*mux.Router, error) {
+func NewRouter(store storage.Store, redisClient redis.Client, executionBackend backend.ExecutionBackend) (*mux.Router, error) {
 	router := mux.NewRouter()
 
-	store := storage.New(postgresClient)
 	metadataStore := metadata.NewStore(redisClient)
 	secretsStore := secrets.NewStore(redisClient)
 
-	httpClient, err := http_client.NewClient()
+	authenticator, err := auth.New(store)
 	if err != nil {
 		return router, err
 	}
-	kubeConfig := kubernetes.KubeConfig()
-	kubeClient := kubernetes.NewClient(kubeConfig, httpClient)
+	authenticate := middleware.Authenticate(authenticator)
+	requireExecuteScope := middleware.RequireScope(auth.ExecuteScope)
+	requireScheduleScope := middleware.RequireScope(auth.ScheduleScope)
+	requirePublishScope := middleware.RequireScope(auth.PublishScope)
 
-	auditor := audit.New(store, kubeClient)
-	jobExecutioner := execution.NewExecutioner(kubeClient, metadataStore, secretsStore)
-	jobExecutionHandler := execution.NewExecutionHandler(auditor, store, jobExecutioner)
-	jobLogger := logs.NewLogger(kubeClient)
-	jobMetadataHandler := metadata.NewHandler(metadataStore)
-	jobSecretsHandler := secrets.NewHandler(secretsStore)
+	groupResolver, err := auth.NewGroupResolver(store)
+	if err != nil {
+		return router, err
+	}
+	authorizer := auth.NewAuthorizer(groupResolver)
+
+	auditor := audit.New(store, executionBackend)
+	jobExecutioner := execution.NewExecutioner(executionBackend, metadataStore, secretsStore)
+	jobExecutionHandler := execution.NewExecutionHandler(auditor, store, jobExecutioner, metadataStore, authorizer)
+	jobLogger := logs.NewLogger(executionBackend, store, metadataStore, auditor, authorizer, config.JobLogsArchiveDir())
+	jobMetadataHandler := metadata.NewHandler(metadataStore, auditor, authorizer)
+	jobSecretsHandler := secrets.NewHandler(secretsStore, auditor, authorizer)
 
-	scheduledJobsHandler := schedule.NewScheduler(store, metadataStore)
+	scheduledJobsHandler := schedule.NewScheduler(store, metadataStore, auditor, authorizer)
+	accessTokensHandler := tokens.NewHandler(store)
 
 	router.HandleFunc("/ping", func(w http.ResponseWriter, req *http.Request) {
 		fmt.Fprintf(w, "pong")
@@ -56,16 +64,27 @@ func NewRouter(postgresClient postgres.Client, redisClient redis.Client) (*mux.R
 		http.ServeFile(w, r, path.Join(config.DocsPath(), "swagger.yml"))
 	})
 
-	router.HandleFunc(instrumentation.Wrap("/jobs/execute", middleware.ValidateClientVersion(jobExecutionHandler.Handle()))).Methods("POST")
-	router.HandleFunc(instrumentation.Wrap("/jobs/execute/{name}/status", middleware.ValidateClientVersion(jobExecutionHandler.Status()))).Methods("GET")
-	router.HandleFunc(instrumentation.Wrap("/jobs/logs", middleware.ValidateClientVersion(jobLogger.Stream()))).Methods("GET")
-	router.HandleFunc(instrumentation.Wrap("/jobs/metadata", middleware.ValidateClientVersion(jobMetadataHandler.HandleSubmission()))).Methods("POST")
-	router.HandleFunc(instrumentation.Wrap("/jobs/metadata", middleware.ValidateClientVersion(jobMetadataHandler.HandleBulkDisplay()))).Methods("GET")
-	router.HandleFunc(instrumentation.Wrap("/jobs/secrets", middleware.ValidateClientVersion(jobSecretsHandler.HandleSubmission()))).Methods("POST")
-	router.HandleFunc(instrumentation.Wrap("/jobs/schedule", middleware.ValidateClientVersion(scheduledJobsHandler.Schedule()))).Methods("POST")
-	router.HandleFunc(instrumentation.Wrap("/jobs/schedule", middleware.ValidateClientVersion(scheduledJobsHandler.GetScheduledJobs()))).Methods("GET")
-	router.HandleFunc(instrumentation.Wrap("/jobs/schedule/{id}", middleware.ValidateClientVersion(scheduledJobsHandler.GetScheduledJob()))).Methods("GET")
-	router.HandleFunc(instrumentation.Wrap("/jobs/schedule/{id}", middleware.ValidateClientVersion(scheduledJobsHandler.RemoveScheduledJob()))).Methods("DELETE")
+	router.HandleFunc(instrumentation.Wrap("/jobs/execute", middleware.ValidateClientVersion(authenticate(requireExecuteScope(jobExecutionHandler.Handle()))))).Methods("POST")
+	router.HandleFunc(instrumentation.Wrap("/jobs/execute/{name}/status", middleware.ValidateClientVersion(authenticate(requireExecuteScope(jobExecutionHandler.Status()))))).Methods("GET")
+	router.HandleFunc(instrumentation.Wrap("/jobs/execute/{name}", middleware.ValidateClientVersion(authenticate(requireExecuteScope(jobExecutionHandler.Detail()))))).Methods("GET")
+	router.HandleFunc(instrumentation.Wrap("/jobs/executions", middleware.ValidateClientVersion(authenticate(requireExecuteScope(jobExecutionHandler.List()))))).Methods("GET")
+	router.HandleFunc(instrumentation.Wrap("/jobs/execute/{name}", middleware.ValidateClientVersion(authenticate(requireExecuteScope(jobExecutionHandler.Cancel()))))).Methods("DELETE")
+	router.HandleFunc(instrumentation.Wrap("/jobs/execute/{name}/callbacks", middleware.ValidateClientVersion(authenticate(requireExecuteScope(jobExecutionHandler.Callbacks()))))).Methods("GET")
+	router.HandleFunc(instrumentation.Wrap("/jobs/execute/{name}/rerun", middleware.ValidateClientVersion(authenticate(requireExecuteScope(jobExecutionHandler.Rerun()))))).Methods("POST")
+	router.HandleFunc(instrumentation.Wrap("/jobs/approvals", middleware.ValidateClientVersion(authenticate(requireExecuteScope(jobExecutionHandler.Approvals()))))).Methods("GET")
+	router.HandleFunc(instrumentation.Wrap("/jobs/approvals/{id}/approve", middleware.ValidateClientVersion(authenticate(requireExecuteScope(jobExecutionHandler.Approve()))))).Methods("POST")
+	router.HandleFunc(instrumentation.Wrap("/jobs/approvals/{id}/reject", middleware.ValidateClientVersion(authenticate(requireExecuteScope(jobExecutionHandler.Reject()))))).Methods("POST")
+	router.HandleFunc(instrumentation.Wrap("/jobs/logs", middleware.ValidateClientVersion(authenticate(requireExecuteScope(jobLogger.Stream()))))).Methods("GET")
+	router.HandleFunc(instrumentation.Wrap("/jobs/metadata", middleware.ValidateClientVersion(authenticate(requirePublishScope(jobMetadataHandler.HandleSubmission()))))).Methods("POST")
+	router.HandleFunc(instrumentation.Wrap("/jobs/metadata", middleware.ValidateClientVersion(authenticate(jobMetadataHandler.HandleBulkDisplay())))).Methods("GET")
+	router.HandleFunc(instrumentation.Wrap("/jobs/secrets", middleware.ValidateClientVersion(authenticate(requirePublishScope(jobSecretsHandler.HandleSubmission()))))).Methods("POST")
+	router.HandleFunc(instrumentation.Wrap("/jobs/schedule", middleware.ValidateClientVersion(authenticate(requireScheduleScope(scheduledJobsHandler.Schedule()))))).Methods("POST")
+	router.HandleFunc(instrumentation.Wrap("/jobs/schedule", middleware.ValidateClientVersion(authenticate(requireScheduleScope(scheduledJobsHandler.GetScheduledJobs()))))).Methods("GET")
+	router.HandleFunc(instrumentation.Wrap("/jobs/schedule/{id}", middleware.ValidateClientVersion(authenticate(requireScheduleScope(scheduledJobsHandler.GetScheduledJob()))))).Methods("GET")
+	router.HandleFunc(instrumentation.Wrap("/jobs/schedule/{id}", middleware.ValidateClientVersion(authenticate(requireScheduleScope(scheduledJobsHandler.RemoveScheduledJob()))))).Methods("DELETE")
+	router.HandleFunc(instrumentation.Wrap("/tokens", middleware.ValidateClientVersion(authenticate(accessTokensHandler.Create())))).Methods("POST")
+	router.HandleFunc(instrumentation.Wrap("/tokens", middleware.ValidateClientVersion(authenticate(accessTokensHandler.List())))).Methods("GET")
+	router.HandleFunc(instrumentation.Wrap("/tokens/{id}", middleware.ValidateClientVersion(authenticate(accessTokensHandler.Revoke())))).Methods("DELETE")
 
 	return router, nil
 }
@@ -0,0 +1,659 @@
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"proctor/proctord/storage/postgres"
+	"proctor/proctord/utility"
+
+	"github.com/lib/pq"
+	"github.com/satori/go.uuid"
+)
+
+type inMemoryStore struct {
+	mutex                  sync.Mutex
+	lastID                 int64
+	jobsExecutionAuditLogs []postgres.JobsExecutionAuditLog
+	jobsSchedules          []postgres.JobsSchedule
+	accessTokens           []postgres.AccessToken
+	adminAuditLogs         []postgres.AdminAuditLog
+	statusCallbacks        []postgres.StatusCallback
+	statusCallbackAttempts []postgres.StatusCallbackAttempt
+	idempotencyKeys        []postgres.IdempotencyKey
+	approvalRequests       []postgres.ApprovalRequest
+}
+
+// NewInMemoryStore keeps rows in slices of the process, it stands in for the postgres backed store in tests.
+// Users have no groups as nothing but the database populates them
+func NewInMemoryStore() Store {
+	return &inMemoryStore{}
+}
+
+func (store *inMemoryStore) nextID() int64 {
+	store.lastID++
+	return store.lastID
+}
+
+func duplicateKeyError(constraint string) error {
+	return fmt.Errorf("pq: duplicate key value violates unique constraint \"%s\"", constraint)
+}
+
+func validateUUID(id string) error {
+	if _, err := uuid.FromString(id); err != nil {
+		return fmt.Errorf("pq: invalid input syntax for type uuid: \"%s\"", id)
+	}
+	return nil
+}
+
+func isFinished(jobExecutionStatus string) bool {
+	switch jobExecutionStatus {
+	case utility.JobSucceeded, utility.JobFailed, utility.JobCancelled, utility.JobNotFound:
+		return true
+	}
+	return false
+}
+
+func (store *inMemoryStore) AuditJobsExecution(jobsExecutionAuditLog *postgres.JobsExecutionAuditLog) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for _, existing := range store.jobsExecutionAuditLogs {
+		if jobsExecutionAuditLog.ExecutionID.Valid && existing.ExecutionID == jobsExecutionAuditLog.ExecutionID {
+			return duplicateKeyError("job_name_submitted_for_execution_uniqueness")
+		}
+	}
+
+	row := *jobsExecutionAuditLog
+	row.ID = store.nextID()
+	row.Errors = ""
+	row.CancelledBy = ""
+	if row.Labels == "" {
+		row.Labels = "{}"
+	}
+	row.CreatedAt = time.Now()
+	row.UpdatedAt = row.CreatedAt
+	store.jobsExecutionAuditLogs = append(store.jobsExecutionAuditLogs, row)
+	return nil
+}
+
+func (store *inMemoryStore) UpdateJobsExecutionAuditLog(jobExecutionID, jobExecutionStatus string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for i, row := range store.jobsExecutionAuditLogs {
+		if row.ExecutionID.String == jobExecutionID && row.JobExecutionStatus != jobExecutionStatus && !isFinished(row.JobExecutionStatus) {
+			store.jobsExecutionAuditLogs[i].JobExecutionStatus = jobExecutionStatus
+			store.jobsExecutionAuditLogs[i].UpdatedAt = time.Now()
+		}
+	}
+	return nil
+}
+
+func (store *inMemoryStore) GetUnfinishedJobsExecutionAuditLogs() ([]postgres.JobsExecutionAuditLog, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	jobsExecutionAuditLogs := []postgres.JobsExecutionAuditLog{}
+	for _, row := range store.jobsExecutionAuditLogs {
+		if row.JobSubmissionStatus == utility.JobSubmissionSuccess && !isFinished(row.JobExecutionStatus) {
+			jobsExecutionAuditLogs = append(jobsExecutionAuditLogs, row)
+		}
+	}
+	return jobsExecutionAuditLogs, nil
+}
+
+func (store *inMemoryStore) GetJobExecutionStatus(jobExecutionID string) (string, error) {
+	jobsExecutionAuditLogs, err := store.GetJobsExecutionAuditLog(jobExecutionID)
+	if err != nil || len(jobsExecutionAuditLogs) == 0 {
+		return "", err
+	}
+	return jobsExecutionAuditLogs[0].JobExecutionStatus, nil
+}
+
+func (store *inMemoryStore) GetJobsExecutionAuditLog(jobExecutionID string) ([]postgres.JobsExecutionAuditLog, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	jobsExecutionAuditLogs := []postgres.JobsExecutionAuditLog{}
+	for _, row := range store.jobsExecutionAuditLogs {
+		if row.ExecutionID.String == jobExecutionID {
+			jobsExecutionAuditLogs = append(jobsExecutionAuditLogs, row)
+		}
+	}
+	return jobsExecutionAuditLogs, nil
+}
+
+func (store *inMemoryStore) CancelJobsExecution(jobExecutionID, cancelledBy string) (int64, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	var rowsAffected int64
+	for i, row := range store.jobsExecutionAuditLogs {
+		if row.ExecutionID.String == jobExecutionID {
+			store.jobsExecutionAuditLogs[i].JobExecutionStatus = utility.JobCancelled
+			store.jobsExecutionAuditLogs[i].CancelledBy = cancelledBy
+			store.jobsExecutionAuditLogs[i].UpdatedAt = time.Now()
+			rowsAffected++
+		}
+	}
+	return rowsAffected, nil
+}
+
+func (store *inMemoryStore) GetJobsExecutionAuditLogArgs(afterID int64, limit int) ([]postgres.JobsExecutionAuditLog, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	jobsExecutionAuditLogs := []postgres.JobsExecutionAuditLog{}
+	for _, row := range store.jobsExecutionAuditLogs {
+		if row.ID > afterID && len(jobsExecutionAuditLogs) < limit {
+			jobsExecutionAuditLogs = append(jobsExecutionAuditLogs, postgres.JobsExecutionAuditLog{ID: row.ID, JobName: row.JobName, JobArgs: row.JobArgs})
+		}
+	}
+	return jobsExecutionAuditLogs, nil
+}
+
+func hasLabels(jobsExecutionAuditLog postgres.JobsExecutionAuditLog, labels map[string]string) bool {
+	auditedLabels := map[string]string{}
+	if err := json.Unmarshal([]byte(jobsExecutionAuditLog.Labels), &auditedLabels); err != nil {
+		return false
+	}
+	for key, value := range labels {
+		if auditedValue, ok := auditedLabels[key]; !ok || auditedValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (store *inMemoryStore) GetJobsExecutionAuditLogs(filter JobsExecutionAuditLogFilter) ([]postgres.JobsExecutionAuditLog, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	jobsExecutionAuditLogs := []postgres.JobsExecutionAuditLog{}
+	for i := len(store.jobsExecutionAuditLogs) - 1; i >= 0 && len(jobsExecutionAuditLogs) < filter.Limit; i-- {
+		row := store.jobsExecutionAuditLogs[i]
+		matches := (filter.JobName == "" || row.JobName == filter.JobName) &&
+			(filter.UserEmail == "" || row.UserEmail == filter.UserEmail) &&
+			(filter.ExecutionStatus == "" || row.JobExecutionStatus == filter.ExecutionStatus) &&
+			(filter.SubmissionStatus == "" || row.JobSubmissionStatus == filter.SubmissionStatus) &&
+			(len(filter.Labels) == 0 || hasLabels(row, filter.Labels)) &&
+			(filter.Reason == "" || strings.Contains(strings.ToLower(row.Reason), strings.ToLower(filter.Reason))) &&
+			(filter.CreatedAfter == nil || !row.CreatedAt.Before(*filter.CreatedAfter)) &&
+			(filter.CreatedBefore == nil || row.CreatedAt.Before(*filter.CreatedBefore)) &&
+			(filter.BeforeID <= 0 || row.ID < filter.BeforeID)
+		if matches {
+			jobsExecutionAuditLogs = append(jobsExecutionAuditLogs, row)
+		}
+	}
+	return jobsExecutionAuditLogs, nil
+}
+
+func (store *inMemoryStore) UpdateJobsExecutionAuditLogArgs(id int64, jobArgs string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for i, row := range store.jobsExecutionAuditLogs {
+		if row.ID == id {
+			store.jobsExecutionAuditLogs[i].JobArgs = jobArgs
+		}
+	}
+	return nil
+}
+
+func (store *inMemoryStore) InsertScheduledJob(name, tags, time, notificationEmails, userEmail, groupName string, args map[string]string) (string, error) {
+	jsonEncodedArgs, err := json.Marshal(args)
+	if err != nil {
+		return "", err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	jobsSchedule := postgres.JobsSchedule{
+		ID:                 uuid.NewV4().String(),
+		Name:               name,
+		Args:               base64.StdEncoding.EncodeToString(jsonEncodedArgs),
+		Tags:               tags,
+		Time:               time,
+		NotificationEmails: notificationEmails,
+		UserEmail:          userEmail,
+		Group:              groupName,
+		Enabled:            true,
+	}
+	for _, existing := range store.jobsSchedules {
+		if existing.Enabled && existing.Name == jobsSchedule.Name && existing.Args == jobsSchedule.Args {
+			return jobsSchedule.ID, duplicateKeyError("unique_jobs_schedule_name_args")
+		}
+	}
+	store.jobsSchedules = append(store.jobsSchedules, jobsSchedule)
+	return jobsSchedule.ID, nil
+}
+
+func (store *inMemoryStore) GetScheduledJobs() ([]postgres.JobsSchedule, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return append([]postgres.JobsSchedule{}, store.jobsSchedules...), nil
+}
+
+func (store *inMemoryStore) GetEnabledScheduledJobs() ([]postgres.JobsSchedule, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	scheduledJobs := []postgres.JobsSchedule{}
+	for _, jobsSchedule := range store.jobsSchedules {
+		if jobsSchedule.Enabled {
+			scheduledJobs = append(scheduledJobs, jobsSchedule)
+		}
+	}
+	return scheduledJobs, nil
+}
+
+func (store *inMemoryStore) GetScheduledJob(jobID string) ([]postgres.JobsSchedule, error) {
+	if err := validateUUID(jobID); err != nil {
+		return nil, err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	scheduledJob := []postgres.JobsSchedule{}
+	for _, jobsSchedule := range store.jobsSchedules {
+		if jobsSchedule.ID == jobID && jobsSchedule.Enabled {
+			scheduledJob = append(scheduledJob, jobsSchedule)
+		}
+	}
+	return scheduledJob, nil
+}
+
+func (store *inMemoryStore) RemoveScheduledJob(jobID string) (int64, error) {
+	if err := validateUUID(jobID); err != nil {
+		return 0, err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	var rowsAffected int64
+	for i, jobsSchedule := range store.jobsSchedules {
+		if jobsSchedule.ID == jobID && jobsSchedule.Enabled {
+			store.jobsSchedules[i].Enabled = false
+			store.jobsSchedules[i].UpdatedAt = time.Now()
+			rowsAffected++
+		}
+	}
+	return rowsAffected, nil
+}
+
+func (store *inMemoryStore) GetAccessTokens(userEmail string) ([]postgres.AccessToken, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	accessTokens := []postgres.AccessToken{}
+	for _, accessToken := range store.accessTokens {
+		if accessToken.UserEmail == userEmail {
+			accessTokens = append(accessTokens, accessToken)
+		}
+	}
+	return accessTokens, nil
+}
+
+func (store *inMemoryStore) InsertAccessToken(accessToken *postgres.AccessToken) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for _, existing := range store.accessTokens {
+		if existing.TokenHash == accessToken.TokenHash {
+			return duplicateKeyError("access_tokens_token_hash_uniqueness")
+		}
+	}
+
+	row := postgres.AccessToken{
+		ID:        store.nextID(),
+		UserEmail: accessToken.UserEmail,
+		TokenHash: accessToken.TokenHash,
+		Name:      accessToken.Name,
+		Scopes:    accessToken.Scopes,
+		ExpiresAt: accessToken.ExpiresAt,
+		CreatedAt: time.Now(),
+	}
+	row.UpdatedAt = row.CreatedAt
+	store.accessTokens = append(store.accessTokens, row)
+	return nil
+}
+
+func (store *inMemoryStore) RemoveAccessToken(userEmail string, accessTokenID int64) (int64, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for i, accessToken := range store.accessTokens {
+		if accessToken.ID == accessTokenID && accessToken.UserEmail == userEmail {
+			store.accessTokens = append(store.accessTokens[:i], store.accessTokens[i+1:]...)
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (store *inMemoryStore) UpdateAccessTokenLastUsedAt(accessTokenID int64) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for i, accessToken := range store.accessTokens {
+		if accessToken.ID == accessTokenID {
+			store.accessTokens[i].LastUsedAt = pq.NullTime{Time: time.Now(), Valid: true}
+		}
+	}
+	return nil
+}
+
+func (store *inMemoryStore) GetUserGroups(userEmail string) ([]postgres.UserGroup, error) {
+	return []postgres.UserGroup{}, nil
+}
+
+func (store *inMemoryStore) AuditAdminAction(adminAuditLog *postgres.AdminAuditLog) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	row := *adminAuditLog
+	row.ID = store.nextID()
+	row.CreatedAt = time.Now()
+	store.adminAuditLogs = append(store.adminAuditLogs, row)
+	return nil
+}
+
+func (store *inMemoryStore) InsertStatusCallback(statusCallback *postgres.StatusCallback) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	now := time.Now()
+	store.statusCallbacks = append(store.statusCallbacks, postgres.StatusCallback{
+		ID:            store.nextID(),
+		ExecutionID:   statusCallback.ExecutionID,
+		URL:           statusCallback.URL,
+		Secret:        statusCallback.Secret,
+		Status:        utility.StatusCallbackPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+	return nil
+}
+
+func (store *inMemoryStore) ClaimDueStatusCallbacks(leaseUntil time.Time, limit int) ([]postgres.StatusCallback, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	executionStatuses := make(map[string]string)
+	for _, row := range store.jobsExecutionAuditLogs {
+		executionStatuses[row.ExecutionID.String] = row.JobExecutionStatus
+	}
+
+	now := time.Now()
+	due := []int{}
+	for i, statusCallback := range store.statusCallbacks {
+		if statusCallback.Status == utility.StatusCallbackPending && !statusCallback.NextAttemptAt.After(now) && isFinished(executionStatuses[statusCallback.ExecutionID]) {
+			due = append(due, i)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return store.statusCallbacks[due[i]].NextAttemptAt.Before(store.statusCallbacks[due[j]].NextAttemptAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	statusCallbacks := []postgres.StatusCallback{}
+	for _, i := range due {
+		store.statusCallbacks[i].NextAttemptAt = leaseUntil
+		store.statusCallbacks[i].UpdatedAt = now
+		statusCallback := store.statusCallbacks[i]
+		statusCallback.ExecutionStatus = executionStatuses[statusCallback.ExecutionID]
+		statusCallbacks = append(statusCallbacks, statusCallback)
+	}
+	return statusCallbacks, nil
+}
+
+func (store *inMemoryStore) UpdateStatusCallback(statusCallback *postgres.StatusCallback) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for i, row := range store.statusCallbacks {
+		if row.ID == statusCallback.ID {
+			store.statusCallbacks[i].Status = statusCallback.Status
+			store.statusCallbacks[i].Attempts = statusCallback.Attempts
+			store.statusCallbacks[i].NextAttemptAt = statusCallback.NextAttemptAt
+			store.statusCallbacks[i].UpdatedAt = statusCallback.UpdatedAt
+		}
+	}
+	return nil
+}
+
+func (store *inMemoryStore) InsertStatusCallbackAttempt(statusCallbackAttempt *postgres.StatusCallbackAttempt) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	row := *statusCallbackAttempt
+	row.ID = store.nextID()
+	row.CreatedAt = time.Now()
+	store.statusCallbackAttempts = append(store.statusCallbackAttempts, row)
+	return nil
+}
+
+func (store *inMemoryStore) GetStatusCallbacks(executionID string) ([]postgres.StatusCallback, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	statusCallbacks := []postgres.StatusCallback{}
+	for _, statusCallback := range store.statusCallbacks {
+		if statusCallback.ExecutionID == executionID {
+			statusCallback.Secret = ""
+			statusCallbacks = append(statusCallbacks, statusCallback)
+		}
+	}
+	return statusCallbacks, nil
+}
+
+func (store *inMemoryStore) GetStatusCallbackAttempts(executionID string) ([]postgres.StatusCallbackAttempt, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	callbackIDs := make(map[int64]bool)
+	for _, statusCallback := range store.statusCallbacks {
+		if statusCallback.ExecutionID == executionID {
+			callbackIDs[statusCallback.ID] = true
+		}
+	}
+
+	statusCallbackAttempts := []postgres.StatusCallbackAttempt{}
+	for _, statusCallbackAttempt := range store.statusCallbackAttempts {
+		if callbackIDs[statusCallbackAttempt.CallbackID] {
+			statusCallbackAttempts = append(statusCallbackAttempts, statusCallbackAttempt)
+		}
+	}
+	return statusCallbackAttempts, nil
+}
+
+func (store *inMemoryStore) ClaimIdempotencyKey(idempotencyKey *postgres.IdempotencyKey) (bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	row := postgres.IdempotencyKey{
+		UserEmail: idempotencyKey.UserEmail,
+		Key:       idempotencyKey.Key,
+		JobName:   idempotencyKey.JobName,
+		ExpiresAt: idempotencyKey.ExpiresAt,
+		CreatedAt: time.Now(),
+	}
+	for i, existing := range store.idempotencyKeys {
+		if existing.UserEmail == row.UserEmail && existing.Key == row.Key {
+			if existing.ExpiresAt.After(time.Now()) {
+				return false, nil
+			}
+			row.ID = existing.ID
+			store.idempotencyKeys[i] = row
+			return true, nil
+		}
+	}
+	row.ID = store.nextID()
+	store.idempotencyKeys = append(store.idempotencyKeys, row)
+	return true, nil
+}
+
+func (store *inMemoryStore) GetIdempotencyKey(userEmail, key string) ([]postgres.IdempotencyKey, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	idempotencyKeys := []postgres.IdempotencyKey{}
+	for _, idempotencyKey := range store.idempotencyKeys {
+		if idempotencyKey.UserEmail == userEmail && idempotencyKey.Key == key {
+			idempotencyKeys = append(idempotencyKeys, idempotencyKey)
+		}
+	}
+	return idempotencyKeys, nil
+}
+
+func (store *inMemoryStore) UpdateIdempotencyKeyExecutionID(userEmail, key, executionID string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for i, idempotencyKey := range store.idempotencyKeys {
+		if idempotencyKey.UserEmail == userEmail && idempotencyKey.Key == key {
+			store.idempotencyKeys[i].ExecutionID = postgres.StringToSQLString(executionID)
+		}
+	}
+	return nil
+}
+
+func (store *inMemoryStore) UpdateIdempotencyKeyApprovalID(userEmail, key, approvalID string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for i, idempotencyKey := range store.idempotencyKeys {
+		if idempotencyKey.UserEmail == userEmail && idempotencyKey.Key == key {
+			store.idempotencyKeys[i].ApprovalID = postgres.StringToSQLString(approvalID)
+		}
+	}
+	return nil
+}
+
+func (store *inMemoryStore) RemoveIdempotencyKey(userEmail, key string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for i, idempotencyKey := range store.idempotencyKeys {
+		if idempotencyKey.UserEmail == userEmail && idempotencyKey.Key == key {
+			store.idempotencyKeys = append(store.idempotencyKeys[:i], store.idempotencyKeys[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (store *inMemoryStore) InsertApprovalRequest(approvalRequest *postgres.ApprovalRequest) (string, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	approvalRequest.ID = uuid.NewV4().String()
+	approvalRequest.Status = utility.ApprovalPending
+	row := postgres.ApprovalRequest{
+		ID:                approvalRequest.ID,
+		JobName:           approvalRequest.JobName,
+		UserEmail:         approvalRequest.UserEmail,
+		Job:               approvalRequest.Job,
+		CallbackSecret:    approvalRequest.CallbackSecret,
+		ParentExecutionID: approvalRequest.ParentExecutionID,
+		Status:            approvalRequest.Status,
+		ExpiresAt:         approvalRequest.ExpiresAt,
+		CreatedAt:         time.Now(),
+	}
+	row.UpdatedAt = row.CreatedAt
+	store.approvalRequests = append(store.approvalRequests, row)
+	return approvalRequest.ID, nil
+}
+
+// reportedApprovalRequest reports a pending request past its expiry as expired
+func reportedApprovalRequest(approvalRequest postgres.ApprovalRequest) postgres.ApprovalRequest {
+	if approvalRequest.Status == utility.ApprovalPending && !approvalRequest.ExpiresAt.After(time.Now()) {
+		approvalRequest.Status = utility.ApprovalExpired
+	}
+	return approvalRequest
+}
+
+func (store *inMemoryStore) GetApprovalRequest(id string) ([]postgres.ApprovalRequest, error) {
+	if err := validateUUID(id); err != nil {
+		return nil, err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	approvalRequests := []postgres.ApprovalRequest{}
+	for _, approvalRequest := range store.approvalRequests {
+		if approvalRequest.ID == id {
+			approvalRequests = append(approvalRequests, reportedApprovalRequest(approvalRequest))
+		}
+	}
+	return approvalRequests, nil
+}
+
+func (store *inMemoryStore) GetPendingApprovalRequests() ([]postgres.ApprovalRequest, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	approvalRequests := []postgres.ApprovalRequest{}
+	for _, approvalRequest := range store.approvalRequests {
+		if reportedApprovalRequest(approvalRequest).Status == utility.ApprovalPending {
+			approvalRequests = append(approvalRequests, approvalRequest)
+		}
+	}
+	return approvalRequests, nil
+}
+
+func (store *inMemoryStore) DecideApprovalRequest(id, status, decidedBy string) (int64, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for i, approvalRequest := range store.approvalRequests {
+		if approvalRequest.ID == id && reportedApprovalRequest(approvalRequest).Status == utility.ApprovalPending {
+			store.approvalRequests[i].Status = status
+			store.approvalRequests[i].DecidedBy = decidedBy
+			store.approvalRequests[i].UpdatedAt = time.Now()
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func (store *inMemoryStore) UpdateApprovalRequestExecutionID(id, executionID string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for i, approvalRequest := range store.approvalRequests {
+		if approvalRequest.ID == id {
+			store.approvalRequests[i].ExecutionID = postgres.StringToSQLString(executionID)
+			store.approvalRequests[i].UpdatedAt = time.Now()
+		}
+	}
+	return nil
+}
+
+func (store *inMemoryStore) ReopenApprovalRequest(id string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for i, approvalRequest := range store.approvalRequests {
+		if approvalRequest.ID == id && approvalRequest.Status == utility.ApprovalApproved && !approvalRequest.ExecutionID.Valid {
+			store.approvalRequests[i].Status = utility.ApprovalPending
+			store.approvalRequests[i].DecidedBy = ""
+			store.approvalRequests[i].UpdatedAt = time.Now()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"proctor/proctord/storage/postgres"
+	"proctor/proctord/utility"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryStoreJobsExecutionAuditLog(t *testing.T) {
+	store := NewInMemoryStore()
+
+	jobsExecutionAuditLog := &postgres.JobsExecutionAuditLog{
+		JobName:             "any-job",
+		UserEmail:           "mrproctor@example.com",
+		ExecutionID:         postgres.StringToSQLString("proctor-job-1"),
+		JobSubmissionStatus: utility.JobSubmissionSuccess,
+		JobExecutionStatus:  "WAITING",
+		Reason:              "Refund of order 42",
+		Labels:              `{"team":"payments"}`,
+	}
+	assert.NoError(t, store.AuditJobsExecution(jobsExecutionAuditLog))
+	assert.EqualError(t, store.AuditJobsExecution(jobsExecutionAuditLog), "pq: duplicate key value violates unique constraint \"job_name_submitted_for_execution_uniqueness\"")
+
+	assert.NoError(t, store.UpdateJobsExecutionAuditLog("proctor-job-1", utility.JobSucceeded))
+	assert.NoError(t, store.UpdateJobsExecutionAuditLog("proctor-job-1", utility.JobFailed))
+
+	status, err := store.GetJobExecutionStatus("proctor-job-1")
+	assert.NoError(t, err)
+	assert.Equal(t, utility.JobSucceeded, status)
+
+	unfinished, err := store.GetUnfinishedJobsExecutionAuditLogs()
+	assert.NoError(t, err)
+	assert.Empty(t, unfinished)
+
+	found, err := store.GetJobsExecutionAuditLogs(JobsExecutionAuditLogFilter{Labels: map[string]string{"team": "payments"}, Reason: "refund", Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, found, 1)
+
+	found, err = store.GetJobsExecutionAuditLogs(JobsExecutionAuditLogFilter{Labels: map[string]string{"team": "growth"}, Limit: 10})
+	assert.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+func TestInMemoryStoreClaimIdempotencyKey(t *testing.T) {
+	store := NewInMemoryStore()
+
+	claimed, err := store.ClaimIdempotencyKey(&postgres.IdempotencyKey{UserEmail: "mrproctor@example.com", Key: "any-key", JobName: "any-job", ExpiresAt: time.Now().Add(time.Hour)})
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+	assert.NoError(t, store.UpdateIdempotencyKeyApprovalID("mrproctor@example.com", "any-key", "any-approval-id"))
+
+	claimed, err = store.ClaimIdempotencyKey(&postgres.IdempotencyKey{UserEmail: "mrproctor@example.com", Key: "any-key", JobName: "any-job", ExpiresAt: time.Now().Add(time.Hour)})
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+
+	idempotencyKeys, err := store.GetIdempotencyKey("mrproctor@example.com", "any-key")
+	assert.NoError(t, err)
+	assert.Len(t, idempotencyKeys, 1)
+	assert.Equal(t, "any-approval-id", idempotencyKeys[0].ApprovalID.String)
+}
+
+func TestInMemoryStoreClaimExpiredIdempotencyKey(t *testing.T) {
+	store := NewInMemoryStore()
+
+	_, err := store.ClaimIdempotencyKey(&postgres.IdempotencyKey{UserEmail: "mrproctor@example.com", Key: "any-key", JobName: "any-job", ExpiresAt: time.Now().Add(-time.Minute)})
+	assert.NoError(t, err)
+	assert.NoError(t, store.UpdateIdempotencyKeyExecutionID("mrproctor@example.com", "any-key", "proctor-job-1"))
+
+	claimed, err := store.ClaimIdempotencyKey(&postgres.IdempotencyKey{UserEmail: "mrproctor@example.com", Key: "any-key", JobName: "other-job", ExpiresAt: time.Now().Add(time.Hour)})
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+
+	idempotencyKeys, err := store.GetIdempotencyKey("mrproctor@example.com", "any-key")
+	assert.NoError(t, err)
+	assert.Equal(t, "other-job", idempotencyKeys[0].JobName)
+	assert.False(t, idempotencyKeys[0].ExecutionID.Valid)
+}
+
+func TestInMemoryStoreApprovalRequests(t *testing.T) {
+	store := NewInMemoryStore()
+
+	approvalID, err := store.InsertApprovalRequest(&postgres.ApprovalRequest{JobName: "any-job", UserEmail: "mrproctor@example.com", ExpiresAt: time.Now().Add(time.Hour)})
+	assert.NoError(t, err)
+	_, err = store.InsertApprovalRequest(&postgres.ApprovalRequest{JobName: "any-job", UserEmail: "mrproctor@example.com", ExpiresAt: time.Now().Add(-time.Minute)})
+	assert.NoError(t, err)
+
+	pending, err := store.GetPendingApprovalRequests()
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, approvalID, pending[0].ID)
+
+	rowsAffected, err := store.DecideApprovalRequest(approvalID, utility.ApprovalApproved, "approver@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+	rowsAffected, err = store.DecideApprovalRequest(approvalID, utility.ApprovalRejected, "approver@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rowsAffected)
+
+	assert.NoError(t, store.ReopenApprovalRequest(approvalID))
+	approvalRequests, err := store.GetApprovalRequest(approvalID)
+	assert.NoError(t, err)
+	assert.Equal(t, utility.ApprovalPending, approvalRequests[0].Status)
+	assert.Empty(t, approvalRequests[0].DecidedBy)
+
+	_, err = store.GetApprovalRequest("not-a-uuid")
+	assert.EqualError(t, err, "pq: invalid input syntax for type uuid: \"not-a-uuid\"")
+}
+
+func TestInMemoryStoreClaimDueStatusCallbacks(t *testing.T) {
+	store := NewInMemoryStore()
+
+	assert.NoError(t, store.AuditJobsExecution(&postgres.JobsExecutionAuditLog{ExecutionID: postgres.StringToSQLString("proctor-job-1"), JobExecutionStatus: "RUNNING"}))
+	assert.NoError(t, store.InsertStatusCallback(&postgres.StatusCallback{ExecutionID: "proctor-job-1", URL: "http://example.com", Secret: "any-secret"}))
+
+	statusCallbacks, err := store.ClaimDueStatusCallbacks(time.Now().Add(time.Minute), 10)
+	assert.NoError(t, err)
+	assert.Empty(t, statusCallbacks)
+
+	assert.NoError(t, store.UpdateJobsExecutionAuditLog("proctor-job-1", utility.JobSucceeded))
+	statusCallbacks, err = store.ClaimDueStatusCallbacks(time.Now().Add(time.Minute), 10)
+	assert.NoError(t, err)
+	assert.Len(t, statusCallbacks, 1)
+	assert.Equal(t, utility.JobSucceeded, statusCallbacks[0].ExecutionStatus)
+	assert.Equal(t, "any-secret", statusCallbacks[0].Secret)
+
+	statusCallbacks, err = store.ClaimDueStatusCallbacks(time.Now().Add(time.Minute), 10)
+	assert.NoError(t, err)
+	assert.Empty(t, statusCallbacks, "claimed callbacks are leased")
+}
@@ -15,8 +15,7 @@ func (m ClientMock) NamedExec(query string, data interface{}) (int64, error) {
 }
 
 func (m ClientMock) Select(destination interface{}, query string, arguments ...interface{}) error {
-	jobName := arguments[0]
-	args := m.Called(destination, query, jobName)
+	args := m.Called(append([]interface{}{destination, query}, arguments...)...)
 	return args.Error(0)
 }
 
@@ -7,9 +7,11 @@ import (
 	"time"
 
 	"proctor/proctord/logger"
+	"github.com/lib/pq"
 )
 
 type JobsExecutionAuditLog struct {
+	ID                  int64          `db:"id"`
 	JobName             string         `db:"job_name"`
 	UserEmail           string         `db:"user_email"`
 	ImageName           string         `db:"image_name"`
@@ -18,6 +20,11 @@ type JobsExecutionAuditLog struct {
 	JobSubmissionStatus string         `db:"job_submission_status"`
 	Errors              string         `db:"errors"`
 	JobExecutionStatus  string         `db:"job_execution_status"`
+	CancelledBy         string         `db:"cancelled_by"`
+	ApprovedBy          string         `db:"approved_by"`
+	ParentExecutionID   sql.NullString `db:"parent_execution_id"`
+	Labels              string         `db:"labels"`
+	Reason              string         `db:"reason"`
 	CreatedAt           time.Time      `db:"created_at"`
 	UpdatedAt           time.Time      `db:"updated_at"`
 }
@@ -32,6 +39,21 @@ func (j *JobsExecutionAuditLog) AddJobArgs(jobArgs map[string]string) {
 	j.JobArgs = base64.StdEncoding.EncodeToString(jsonEncodedArgs)
 }
 
+// AddLabels keeps labels as plain JSON, unlike args, so that executions can be searched by them
+func (j *JobsExecutionAuditLog) AddLabels(labels map[string]string) {
+	if len(labels) == 0 {
+		return
+	}
+
+	jsonEncodedLabels, err := json.Marshal(labels)
+	if err != nil {
+		logger.Error("Error marshaling labels: ", err.Error())
+		return
+	}
+
+	j.Labels = string(jsonEncodedLabels)
+}
+
 func (j *JobsExecutionAuditLog) AddExecutionID(jobExecutionID string) {
 	j.ExecutionID = StringToSQLString(jobExecutionID)
 }
@@ -49,3 +71,80 @@ type JobsSchedule struct {
 	CreatedAt          time.Time `db:"created_at"`
 	UpdatedAt          time.Time `db:"updated_at"`
 }
+
+type AccessToken struct {
+	ID         int64       `db:"id"`
+	UserEmail  string      `db:"user_email"`
+	TokenHash  string      `db:"token_hash"`
+	Name       string      `db:"name"`
+	Scopes     string      `db:"scopes"`
+	ExpiresAt  pq.NullTime `db:"expires_at"`
+	LastUsedAt pq.NullTime `db:"last_used_at"`
+	CreatedAt  time.Time   `db:"created_at"`
+	UpdatedAt  time.Time   `db:"updated_at"`
+}
+
+type UserGroup struct {
+	ID        int64     `db:"id"`
+	UserEmail string    `db:"user_email"`
+	GroupName string    `db:"group_name"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type AdminAuditLog struct {
+	ID        int64     `db:"id"`
+	Actor     string    `db:"actor"`
+	ProcName  string    `db:"proc_name"`
+	Action    string    `db:"action"`
+	Diff      string    `db:"diff"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type StatusCallback struct {
+	ID              int64     `db:"id"`
+	ExecutionID     string    `db:"execution_id"`
+	URL             string    `db:"url"`
+	Secret          string    `db:"secret"`
+	Status          string    `db:"status"`
+	Attempts        int       `db:"attempts"`
+	NextAttemptAt   time.Time `db:"next_attempt_at"`
+	ExecutionStatus string    `db:"job_execution_status"`
+	CreatedAt       time.Time `db:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+type StatusCallbackAttempt struct {
+	ID           int64         `db:"id"`
+	CallbackID   int64         `db:"callback_id"`
+	ResponseCode sql.NullInt64 `db:"response_code"`
+	Error        string        `db:"error"`
+	CreatedAt    time.Time     `db:"created_at"`
+}
+
+type IdempotencyKey struct {
+	ID          int64          `db:"id"`
+	UserEmail   string         `db:"user_email"`
+	Key         string         `db:"key"`
+	JobName     string         `db:"job_name"`
+	ExecutionID sql.NullString `db:"execution_id"`
+	ApprovalID  sql.NullString `db:"approval_id"`
+	ExpiresAt   time.Time      `db:"expires_at"`
+	CreatedAt   time.Time      `db:"created_at"`
+}
+
+// ApprovalRequest holds an execution of a proc requiring approval until an approver decides on it.
+// Job is the JSON encoded execution request
+type ApprovalRequest struct {
+	ID                string         `db:"id"`
+	JobName           string         `db:"job_name"`
+	UserEmail         string         `db:"user_email"`
+	Job               string         `db:"job"`
+	CallbackSecret    string         `db:"callback_secret"`
+	ParentExecutionID sql.NullString `db:"parent_execution_id"`
+	Status            string         `db:"status"`
+	DecidedBy         string         `db:"decided_by"`
+	ExecutionID       sql.NullString `db:"execution_id"`
+	ExpiresAt         time.Time      `db:"expires_at"`
+	CreatedAt         time.Time      `db:"created_at"`
+	UpdatedAt         time.Time      `db:"updated_at"`
+}
@@ -3,23 +3,78 @@ package storage
 import (
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"proctor/proctord/storage/postgres"
+	"proctor/proctord/utility"
+	"github.com/lib/pq"
 	"github.com/satori/go.uuid"
 )
 
 type Store interface {
 	AuditJobsExecution(*postgres.JobsExecutionAuditLog) error
 	UpdateJobsExecutionAuditLog(string, string) error
+	GetUnfinishedJobsExecutionAuditLogs() ([]postgres.JobsExecutionAuditLog, error)
 	GetJobExecutionStatus(string) (string, error)
+	GetJobsExecutionAuditLog(string) ([]postgres.JobsExecutionAuditLog, error)
+	CancelJobsExecution(string, string) (int64, error)
+	GetJobsExecutionAuditLogArgs(int64, int) ([]postgres.JobsExecutionAuditLog, error)
+	GetJobsExecutionAuditLogs(JobsExecutionAuditLogFilter) ([]postgres.JobsExecutionAuditLog, error)
+	UpdateJobsExecutionAuditLogArgs(int64, string) error
 	InsertScheduledJob(string, string, string, string, string, string, map[string]string) (string, error)
 	GetScheduledJobs() ([]postgres.JobsSchedule, error)
 	GetEnabledScheduledJobs() ([]postgres.JobsSchedule, error)
 	GetScheduledJob(string) ([]postgres.JobsSchedule, error)
 	RemoveScheduledJob(string) (int64, error)
+	GetAccessTokens(string) ([]postgres.AccessToken, error)
+	InsertAccessToken(*postgres.AccessToken) error
+	RemoveAccessToken(string, int64) (int64, error)
+	UpdateAccessTokenLastUsedAt(int64) error
+	GetUserGroups(string) ([]postgres.UserGroup, error)
+	AuditAdminAction(*postgres.AdminAuditLog) error
+	InsertStatusCallback(*postgres.StatusCallback) error
+	ClaimDueStatusCallbacks(time.Time, int) ([]postgres.StatusCallback, error)
+	UpdateStatusCallback(*postgres.StatusCallback) error
+	InsertStatusCallbackAttempt(*postgres.StatusCallbackAttempt) error
+	GetStatusCallbacks(string) ([]postgres.StatusCallback, error)
+	GetStatusCallbackAttempts(string) ([]postgres.StatusCallbackAttempt, error)
+	ClaimIdempotencyKey(*postgres.IdempotencyKey) (bool, error)
+	GetIdempotencyKey(string, string) ([]postgres.IdempotencyKey, error)
+	UpdateIdempotencyKeyExecutionID(string, string, string) error
+	UpdateIdempotencyKeyApprovalID(string, string, string) error
+	RemoveIdempotencyKey(string, string) error
+	InsertApprovalRequest(*postgres.ApprovalRequest) (string, error)
+	GetApprovalRequest(string) ([]postgres.ApprovalRequest, error)
+	GetPendingApprovalRequests() ([]postgres.ApprovalRequest, error)
+	DecideApprovalRequest(string, string, string) (int64, error)
+	UpdateApprovalRequestExecutionID(string, string) error
+	ReopenApprovalRequest(string) error
 }
 
+// JobsExecutionAuditLogFilter narrows down executions, newest first. Zero values don't filter.
+// Executions match when they have all of the labels and a reason containing Reason
+type JobsExecutionAuditLogFilter struct {
+	JobName          string
+	UserEmail        string
+	ExecutionStatus  string
+	SubmissionStatus string
+	Labels           map[string]string
+	Reason           string
+	CreatedAfter     *time.Time
+	CreatedBefore    *time.Time
+	BeforeID         int64
+	Limit            int
+}
+
+var finishedStatusesSQL = fmt.Sprintf("('%s', '%s', '%s', '%s')", utility.JobSucceeded, utility.JobFailed, utility.JobCancelled, utility.JobNotFound)
+
+// approvalRequestsSQL reports pending requests past their expiry as expired
+var approvalRequestsSQL = fmt.Sprintf("SELECT id, job_name, user_email, job, coalesce(callback_secret, '') as callback_secret, parent_execution_id, "+
+	"case when status = '%s' and expires_at <= now() then '%s' else status end as status, coalesce(decided_by, '') as decided_by, execution_id, expires_at, created_at, updated_at "+
+	"from approval_requests", utility.ApprovalPending, utility.ApprovalExpired)
+
 type store struct {
 	postgresClient postgres.Client
 }
@@ -32,7 +87,8 @@ func New(postgresClient postgres.Client) Store {
 
 func (store *store) AuditJobsExecution(jobsExecutionAuditLog *postgres.JobsExecutionAuditLog) error {
 	_, err := store.postgresClient.NamedExec("INSERT INTO jobs_execution_audit_log (job_name, user_email, image_name, job_name_submitted_for_execution, job_args, job_submission_status,"+
-		" job_execution_status) VALUES (:job_name, :user_email, :image_name, :job_name_submitted_for_execution, :job_args, :job_submission_status, :job_execution_status)",
+		" job_execution_status, parent_execution_id, labels, reason, approved_by) VALUES (:job_name, :user_email, :image_name, :job_name_submitted_for_execution, :job_args, :job_submission_status, :job_execution_status,"+
+		" :parent_execution_id, coalesce(nullif(:labels, ''), '{}')::jsonb, nullif(:reason, ''), nullif(:approved_by, ''))",
 		&jobsExecutionAuditLog)
 	return err
 }
@@ -44,11 +100,20 @@ func (store *store) UpdateJobsExecutionAuditLog(jobExecutionID, jobExecutionStat
 		UpdatedAt:          time.Now(),
 	}
 
+	// a finished execution keeps its status, e.g. a cancelled job reports a failure while being torn down
 	_, err := store.postgresClient.NamedExec("UPDATE jobs_execution_audit_log SET job_execution_status = :job_execution_status, updated_at = :updated_at where job_name_submitted_for_execution = "+
-		":job_name_submitted_for_execution", &jobsExecutionAuditLog)
+		":job_name_submitted_for_execution and job_execution_status <> :job_execution_status and job_execution_status not in "+finishedStatusesSQL, &jobsExecutionAuditLog)
 	return err
 }
 
+// GetUnfinishedJobsExecutionAuditLogs are the submitted executions whose jobs haven't been seen finishing
+func (store *store) GetUnfinishedJobsExecutionAuditLogs() ([]postgres.JobsExecutionAuditLog, error) {
+	jobsExecutionAuditLogResult := []postgres.JobsExecutionAuditLog{}
+	err := store.postgresClient.Select(&jobsExecutionAuditLogResult, "SELECT job_name_submitted_for_execution, job_execution_status from jobs_execution_audit_log "+
+		"where job_submission_status = $1 and job_execution_status not in "+finishedStatusesSQL, utility.JobSubmissionSuccess)
+	return jobsExecutionAuditLogResult, err
+}
+
 func (store *store) GetJobExecutionStatus(JobNameSubmittedForExecution string) (string, error) {
 	jobsExecutionAuditLogResult := []postgres.JobsExecutionAuditLog{}
 	err := store.postgresClient.Select(&jobsExecutionAuditLogResult, "SELECT job_execution_status from jobs_execution_audit_log where job_name_submitted_for_execution = $1", JobNameSubmittedForExecution)
@@ -63,6 +128,94 @@ func (store *store) GetJobExecutionStatus(JobNameSubmittedForExecution string) (
 	return jobsExecutionAuditLogResult[0].JobExecutionStatus, nil
 }
 
+func (store *store) GetJobsExecutionAuditLog(jobExecutionID string) ([]postgres.JobsExecutionAuditLog, error) {
+	jobsExecutionAuditLogResult := []postgres.JobsExecutionAuditLog{}
+	err := store.postgresClient.Select(&jobsExecutionAuditLogResult, "SELECT job_name, coalesce(user_email, '') as user_email, image_name, job_name_submitted_for_execution, job_args, job_submission_status, job_execution_status, coalesce(cancelled_by, '') as cancelled_by, parent_execution_id, labels::text as labels, "+
+		"coalesce(reason, '') as reason, coalesce(approved_by, '') as approved_by, created_at, updated_at from jobs_execution_audit_log where job_name_submitted_for_execution = $1", jobExecutionID)
+	return jobsExecutionAuditLogResult, err
+}
+
+func (store *store) CancelJobsExecution(jobExecutionID, cancelledBy string) (int64, error) {
+	jobsExecutionAuditLog := postgres.JobsExecutionAuditLog{
+		JobExecutionStatus: utility.JobCancelled,
+		CancelledBy:        cancelledBy,
+		ExecutionID:        postgres.StringToSQLString(jobExecutionID),
+		UpdatedAt:          time.Now(),
+	}
+	rowsAffected, err := store.postgresClient.NamedExec("UPDATE jobs_execution_audit_log SET job_execution_status = :job_execution_status, cancelled_by = :cancelled_by, updated_at = :updated_at "+
+		"where job_name_submitted_for_execution = :job_name_submitted_for_execution", &jobsExecutionAuditLog)
+	return rowsAffected, err
+}
+
+func (store *store) GetJobsExecutionAuditLogArgs(afterID int64, limit int) ([]postgres.JobsExecutionAuditLog, error) {
+	jobsExecutionAuditLogResult := []postgres.JobsExecutionAuditLog{}
+	err := store.postgresClient.Select(&jobsExecutionAuditLogResult, "SELECT id, job_name, coalesce(job_args, '') as job_args from jobs_execution_audit_log where id > $1 order by id limit $2", afterID, limit)
+	return jobsExecutionAuditLogResult, err
+}
+
+func (store *store) GetJobsExecutionAuditLogs(filter JobsExecutionAuditLogFilter) ([]postgres.JobsExecutionAuditLog, error) {
+	conditions := []string{}
+	args := []interface{}{}
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.JobName != "" {
+		addCondition("job_name = $%d", filter.JobName)
+	}
+	if filter.UserEmail != "" {
+		addCondition("user_email = $%d", filter.UserEmail)
+	}
+	if filter.ExecutionStatus != "" {
+		addCondition("job_execution_status = $%d", filter.ExecutionStatus)
+	}
+	if filter.SubmissionStatus != "" {
+		addCondition("job_submission_status = $%d", filter.SubmissionStatus)
+	}
+	if len(filter.Labels) > 0 {
+		jsonEncodedLabels, err := json.Marshal(filter.Labels)
+		if err != nil {
+			return nil, err
+		}
+		addCondition("labels @> $%d::jsonb", string(jsonEncodedLabels))
+	}
+	if filter.Reason != "" {
+		addCondition("reason ilike $%d", "%"+filter.Reason+"%")
+	}
+	if filter.CreatedAfter != nil {
+		addCondition("created_at >= $%d", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		addCondition("created_at < $%d", *filter.CreatedBefore)
+	}
+	if filter.BeforeID > 0 {
+		addCondition("id < $%d", filter.BeforeID)
+	}
+
+	query := "SELECT id, job_name, coalesce(user_email, '') as user_email, coalesce(image_name, '') as image_name, job_name_submitted_for_execution, coalesce(job_args, '') as job_args, " +
+		"coalesce(job_submission_status, '') as job_submission_status, coalesce(job_execution_status, '') as job_execution_status, coalesce(cancelled_by, '') as cancelled_by, parent_execution_id, " +
+		"labels::text as labels, coalesce(reason, '') as reason, coalesce(approved_by, '') as approved_by, created_at, updated_at from jobs_execution_audit_log"
+	if len(conditions) > 0 {
+		query += " where " + strings.Join(conditions, " and ")
+	}
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" order by id desc limit $%d", len(args))
+
+	jobsExecutionAuditLogResult := []postgres.JobsExecutionAuditLog{}
+	err := store.postgresClient.Select(&jobsExecutionAuditLogResult, query, args...)
+	return jobsExecutionAuditLogResult, err
+}
+
+func (store *store) UpdateJobsExecutionAuditLogArgs(id int64, jobArgs string) error {
+	jobsExecutionAuditLog := postgres.JobsExecutionAuditLog{
+		ID:      id,
+		JobArgs: jobArgs,
+	}
+	_, err := store.postgresClient.NamedExec("UPDATE jobs_execution_audit_log SET job_args = :job_args where id = :id", &jobsExecutionAuditLog)
+	return err
+}
+
 func (store *store) InsertScheduledJob(name, tags, time, notificationEmails, userEmail, groupName string, args map[string]string) (string, error) {
 	jsonEncodedArgs, err := json.Marshal(args)
 	if err != nil {
@@ -111,3 +264,186 @@ func (store *store) RemoveScheduledJob(jobID string) (int64, error) {
 	rowsAffected, err := store.postgresClient.NamedExec("UPDATE jobs_schedule set enabled = 'f', updated_at = :updated_at where id = :id and enabled = 't'", &job)
 	return rowsAffected, err
 }
+
+func (store *store) GetAccessTokens(userEmail string) ([]postgres.AccessToken, error) {
+	accessTokens := []postgres.AccessToken{}
+	err := store.postgresClient.Select(&accessTokens, "SELECT id, user_email, token_hash, name, scopes, expires_at, last_used_at, created_at from access_tokens where user_email = $1", userEmail)
+	return accessTokens, err
+}
+
+func (store *store) InsertAccessToken(accessToken *postgres.AccessToken) error {
+	_, err := store.postgresClient.NamedExec("INSERT INTO access_tokens (user_email, token_hash, name, scopes, expires_at) "+
+		"VALUES (:user_email, :token_hash, :name, :scopes, :expires_at)", &accessToken)
+	return err
+}
+
+func (store *store) RemoveAccessToken(userEmail string, accessTokenID int64) (int64, error) {
+	accessToken := postgres.AccessToken{
+		ID:        accessTokenID,
+		UserEmail: userEmail,
+	}
+	rowsAffected, err := store.postgresClient.NamedExec("DELETE FROM access_tokens where id = :id and user_email = :user_email", &accessToken)
+	return rowsAffected, err
+}
+
+func (store *store) UpdateAccessTokenLastUsedAt(accessTokenID int64) error {
+	accessToken := postgres.AccessToken{
+		ID:         accessTokenID,
+		LastUsedAt: pq.NullTime{Time: time.Now(), Valid: true},
+	}
+	_, err := store.postgresClient.NamedExec("UPDATE access_tokens set last_used_at = :last_used_at where id = :id", &accessToken)
+	return err
+}
+
+func (store *store) GetUserGroups(userEmail string) ([]postgres.UserGroup, error) {
+	userGroups := []postgres.UserGroup{}
+	err := store.postgresClient.Select(&userGroups, "SELECT id, user_email, group_name from user_groups where user_email = $1", userEmail)
+	return userGroups, err
+}
+
+func (store *store) AuditAdminAction(adminAuditLog *postgres.AdminAuditLog) error {
+	_, err := store.postgresClient.NamedExec("INSERT INTO admin_audit_log (actor, proc_name, action, diff) VALUES (:actor, :proc_name, :action, :diff)", &adminAuditLog)
+	return err
+}
+
+func (store *store) InsertStatusCallback(statusCallback *postgres.StatusCallback) error {
+	_, err := store.postgresClient.NamedExec("INSERT INTO status_callbacks (execution_id, url, secret) VALUES (:execution_id, :url, :secret)", &statusCallback)
+	return err
+}
+
+// ClaimDueStatusCallbacks returns pending callbacks of finished executions which are due for delivery.
+// Their next attempt is pushed to leaseUntil so that other dispatchers skip them meanwhile
+func (store *store) ClaimDueStatusCallbacks(leaseUntil time.Time, limit int) ([]postgres.StatusCallback, error) {
+	statusCallbacks := []postgres.StatusCallback{}
+	err := store.postgresClient.Select(&statusCallbacks,
+		"UPDATE status_callbacks c set next_attempt_at = $1, updated_at = now() from jobs_execution_audit_log j "+
+			"where j.job_name_submitted_for_execution = c.execution_id and c.id in ("+
+			"SELECT sc.id from status_callbacks sc join jobs_execution_audit_log jl on jl.job_name_submitted_for_execution = sc.execution_id "+
+			"where sc.status = $2 and sc.next_attempt_at <= now() and jl.job_execution_status in "+finishedStatusesSQL+" "+
+			"order by sc.next_attempt_at limit $3 for update of sc skip locked) "+
+			"RETURNING c.id, c.execution_id, c.url, coalesce(c.secret, '') as secret, c.status, c.attempts, c.next_attempt_at, j.job_execution_status, c.created_at, c.updated_at",
+		leaseUntil, utility.StatusCallbackPending, limit)
+	return statusCallbacks, err
+}
+
+func (store *store) UpdateStatusCallback(statusCallback *postgres.StatusCallback) error {
+	_, err := store.postgresClient.NamedExec("UPDATE status_callbacks set status = :status, attempts = :attempts, next_attempt_at = :next_attempt_at, updated_at = :updated_at where id = :id", &statusCallback)
+	return err
+}
+
+func (store *store) InsertStatusCallbackAttempt(statusCallbackAttempt *postgres.StatusCallbackAttempt) error {
+	_, err := store.postgresClient.NamedExec("INSERT INTO status_callback_attempts (callback_id, response_code, error) VALUES (:callback_id, :response_code, :error)", &statusCallbackAttempt)
+	return err
+}
+
+func (store *store) GetStatusCallbacks(executionID string) ([]postgres.StatusCallback, error) {
+	statusCallbacks := []postgres.StatusCallback{}
+	err := store.postgresClient.Select(&statusCallbacks, "SELECT id, execution_id, url, status, attempts, next_attempt_at, created_at, updated_at from status_callbacks where execution_id = $1 order by id", executionID)
+	return statusCallbacks, err
+}
+
+func (store *store) GetStatusCallbackAttempts(executionID string) ([]postgres.StatusCallbackAttempt, error) {
+	statusCallbackAttempts := []postgres.StatusCallbackAttempt{}
+	err := store.postgresClient.Select(&statusCallbackAttempts,
+		"SELECT a.id, a.callback_id, a.response_code, coalesce(a.error, '') as error, a.created_at from status_callback_attempts a "+
+			"join status_callbacks c on c.id = a.callback_id where c.execution_id = $1 order by a.id",
+		executionID)
+	return statusCallbackAttempts, err
+}
+
+// ClaimIdempotencyKey returns false when the user holds the key already and it hasn't expired
+func (store *store) ClaimIdempotencyKey(idempotencyKey *postgres.IdempotencyKey) (bool, error) {
+	rowsAffected, err := store.postgresClient.NamedExec("INSERT INTO idempotency_keys (user_email, key, job_name, expires_at) VALUES (:user_email, :key, :job_name, :expires_at) "+
+		"ON CONFLICT (user_email, key) DO UPDATE SET job_name = excluded.job_name, execution_id = NULL, approval_id = NULL, expires_at = excluded.expires_at, created_at = now() "+
+		"where idempotency_keys.expires_at < now()", &idempotencyKey)
+	return rowsAffected == 1, err
+}
+
+func (store *store) GetIdempotencyKey(userEmail, key string) ([]postgres.IdempotencyKey, error) {
+	idempotencyKeys := []postgres.IdempotencyKey{}
+	err := store.postgresClient.Select(&idempotencyKeys, "SELECT id, user_email, key, job_name, execution_id, approval_id, expires_at, created_at from idempotency_keys where user_email = $1 and key = $2", userEmail, key)
+	return idempotencyKeys, err
+}
+
+func (store *store) UpdateIdempotencyKeyExecutionID(userEmail, key, executionID string) error {
+	idempotencyKey := postgres.IdempotencyKey{
+		UserEmail:   userEmail,
+		Key:         key,
+		ExecutionID: postgres.StringToSQLString(executionID),
+	}
+	_, err := store.postgresClient.NamedExec("UPDATE idempotency_keys set execution_id = :execution_id where user_email = :user_email and key = :key", &idempotencyKey)
+	return err
+}
+
+func (store *store) UpdateIdempotencyKeyApprovalID(userEmail, key, approvalID string) error {
+	idempotencyKey := postgres.IdempotencyKey{
+		UserEmail:  userEmail,
+		Key:        key,
+		ApprovalID: postgres.StringToSQLString(approvalID),
+	}
+	_, err := store.postgresClient.NamedExec("UPDATE idempotency_keys set approval_id = :approval_id where user_email = :user_email and key = :key", &idempotencyKey)
+	return err
+}
+
+func (store *store) RemoveIdempotencyKey(userEmail, key string) error {
+	idempotencyKey := postgres.IdempotencyKey{
+		UserEmail: userEmail,
+		Key:       key,
+	}
+	_, err := store.postgresClient.NamedExec("DELETE FROM idempotency_keys where user_email = :user_email and key = :key", &idempotencyKey)
+	return err
+}
+
+func (store *store) InsertApprovalRequest(approvalRequest *postgres.ApprovalRequest) (string, error) {
+	approvalRequest.ID = uuid.NewV4().String()
+	approvalRequest.Status = utility.ApprovalPending
+	_, err := store.postgresClient.NamedExec("INSERT INTO approval_requests (id, job_name, user_email, job, callback_secret, parent_execution_id, status, expires_at) "+
+		"VALUES (:id, :job_name, :user_email, :job, nullif(:callback_secret, ''), :parent_execution_id, :status, :expires_at)", &approvalRequest)
+	return approvalRequest.ID, err
+}
+
+func (store *store) GetApprovalRequest(id string) ([]postgres.ApprovalRequest, error) {
+	approvalRequests := []postgres.ApprovalRequest{}
+	err := store.postgresClient.Select(&approvalRequests, approvalRequestsSQL+" where id = $1", id)
+	return approvalRequests, err
+}
+
+func (store *store) GetPendingApprovalRequests() ([]postgres.ApprovalRequest, error) {
+	approvalRequests := []postgres.ApprovalRequest{}
+	err := store.postgresClient.Select(&approvalRequests, approvalRequestsSQL+" where status = $1 and expires_at > now() order by created_at", utility.ApprovalPending)
+	return approvalRequests, err
+}
+
+// DecideApprovalRequest records the decision on a request which is still pending, no rows are affected otherwise
+func (store *store) DecideApprovalRequest(id, status, decidedBy string) (int64, error) {
+	approvalRequest := postgres.ApprovalRequest{
+		ID:        id,
+		Status:    status,
+		DecidedBy: decidedBy,
+		UpdatedAt: time.Now(),
+	}
+	rowsAffected, err := store.postgresClient.NamedExec("UPDATE approval_requests set status = :status, decided_by = :decided_by, updated_at = :updated_at "+
+		"where id = :id and status = '"+utility.ApprovalPending+"' and expires_at > now()", &approvalRequest)
+	return rowsAffected, err
+}
+
+func (store *store) UpdateApprovalRequestExecutionID(id, executionID string) error {
+	approvalRequest := postgres.ApprovalRequest{
+		ID:          id,
+		ExecutionID: postgres.StringToSQLString(executionID),
+		UpdatedAt:   time.Now(),
+	}
+	_, err := store.postgresClient.NamedExec("UPDATE approval_requests set execution_id = :execution_id, updated_at = :updated_at where id = :id", &approvalRequest)
+	return err
+}
+
+// ReopenApprovalRequest takes back an approval whose execution failed to submit, leaving the request pending
+func (store *store) ReopenApprovalRequest(id string) error {
+	approvalRequest := postgres.ApprovalRequest{
+		ID:        id,
+		UpdatedAt: time.Now(),
+	}
+	_, err := store.postgresClient.NamedExec("UPDATE approval_requests set status = '"+utility.ApprovalPending+"', decided_by = NULL, updated_at = :updated_at "+
+		"where id = :id and status = '"+utility.ApprovalApproved+"' and execution_id is NULL", &approvalRequest)
+	return err
+}
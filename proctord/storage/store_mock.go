@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"time"
+
 	"proctor/proctord/storage/postgres"
 	"github.com/stretchr/testify/mock"
 )
@@ -24,6 +26,11 @@ func (m *MockStore) GetJobExecutionStatus(jobName string) (string, error) {
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockStore) GetJobsExecutionAuditLog(jobExecutionID string) ([]postgres.JobsExecutionAuditLog, error) {
+	args := m.Called(jobExecutionID)
+	return args.Get(0).([]postgres.JobsExecutionAuditLog), args.Error(1)
+}
+
 func (m *MockStore) InsertScheduledJob(jobName, tags, time, notificationEmails, userEmail, groupName string, jobArgs map[string]string) (string, error) {
 	args := m.Called(jobName, tags, time, notificationEmails, userEmail, groupName, jobArgs)
 	return args.String(0), args.Error(1)
@@ -48,3 +55,143 @@ func (m *MockStore) RemoveScheduledJob(jobID string) (int64, error) {
 	args := m.Called(jobID)
 	return args.Get(0).(int64), args.Error(1)
 }
+
+func (m *MockStore) GetAccessTokens(userEmail string) ([]postgres.AccessToken, error) {
+	args := m.Called(userEmail)
+	return args.Get(0).([]postgres.AccessToken), args.Error(1)
+}
+
+func (m *MockStore) GetUserGroups(userEmail string) ([]postgres.UserGroup, error) {
+	args := m.Called(userEmail)
+	return args.Get(0).([]postgres.UserGroup), args.Error(1)
+}
+
+func (m *MockStore) AuditAdminAction(adminAuditLog *postgres.AdminAuditLog) error {
+	args := m.Called(adminAuditLog)
+	return args.Error(0)
+}
+
+func (m *MockStore) InsertAccessToken(accessToken *postgres.AccessToken) error {
+	args := m.Called(accessToken)
+	return args.Error(0)
+}
+
+func (m *MockStore) RemoveAccessToken(userEmail string, accessTokenID int64) (int64, error) {
+	args := m.Called(userEmail, accessTokenID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStore) UpdateAccessTokenLastUsedAt(accessTokenID int64) error {
+	args := m.Called(accessTokenID)
+	return args.Error(0)
+}
+
+func (m *MockStore) CancelJobsExecution(jobExecutionID, cancelledBy string) (int64, error) {
+	args := m.Called(jobExecutionID, cancelledBy)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStore) GetJobsExecutionAuditLogArgs(afterID int64, limit int) ([]postgres.JobsExecutionAuditLog, error) {
+	args := m.Called(afterID, limit)
+	return args.Get(0).([]postgres.JobsExecutionAuditLog), args.Error(1)
+}
+
+func (m *MockStore) UpdateJobsExecutionAuditLogArgs(id int64, jobArgs string) error {
+	args := m.Called(id, jobArgs)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetJobsExecutionAuditLogs(filter JobsExecutionAuditLogFilter) ([]postgres.JobsExecutionAuditLog, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]postgres.JobsExecutionAuditLog), args.Error(1)
+}
+
+func (m *MockStore) InsertStatusCallback(statusCallback *postgres.StatusCallback) error {
+	args := m.Called(statusCallback)
+	return args.Error(0)
+}
+
+func (m *MockStore) ClaimDueStatusCallbacks(leaseUntil time.Time, limit int) ([]postgres.StatusCallback, error) {
+	args := m.Called(leaseUntil, limit)
+	return args.Get(0).([]postgres.StatusCallback), args.Error(1)
+}
+
+func (m *MockStore) UpdateStatusCallback(statusCallback *postgres.StatusCallback) error {
+	args := m.Called(statusCallback)
+	return args.Error(0)
+}
+
+func (m *MockStore) InsertStatusCallbackAttempt(statusCallbackAttempt *postgres.StatusCallbackAttempt) error {
+	args := m.Called(statusCallbackAttempt)
+	return args.Error(0)
+}
+
+func (m *MockStore) GetStatusCallbacks(executionID string) ([]postgres.StatusCallback, error) {
+	args := m.Called(executionID)
+	return args.Get(0).([]postgres.StatusCallback), args.Error(1)
+}
+
+func (m *MockStore) GetStatusCallbackAttempts(executionID string) ([]postgres.StatusCallbackAttempt, error) {
+	args := m.Called(executionID)
+	return args.Get(0).([]postgres.StatusCallbackAttempt), args.Error(1)
+}
+
+func (m *MockStore) GetUnfinishedJobsExecutionAuditLogs() ([]postgres.JobsExecutionAuditLog, error) {
+	args := m.Called()
+	return args.Get(0).([]postgres.JobsExecutionAuditLog), args.Error(1)
+}
+
+func (m *MockStore) ClaimIdempotencyKey(idempotencyKey *postgres.IdempotencyKey) (bool, error) {
+	args := m.Called(idempotencyKey)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockStore) GetIdempotencyKey(userEmail, key string) ([]postgres.IdempotencyKey, error) {
+	args := m.Called(userEmail, key)
+	return args.Get(0).([]postgres.IdempotencyKey), args.Error(1)
+}
+
+func (m *MockStore) UpdateIdempotencyKeyExecutionID(userEmail, key, executionID string) error {
+	args := m.Called(userEmail, key, executionID)
+	return args.Error(0)
+}
+
+func (m *MockStore) UpdateIdempotencyKeyApprovalID(userEmail, key, approvalID string) error {
+	args := m.Called(userEmail, key, approvalID)
+	return args.Error(0)
+}
+
+func (m *MockStore) RemoveIdempotencyKey(userEmail, key string) error {
+	args := m.Called(userEmail, key)
+	return args.Error(0)
+}
+
+func (m *MockStore) InsertApprovalRequest(approvalRequest *postgres.ApprovalRequest) (string, error) {
+	args := m.Called(approvalRequest)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockStore) GetApprovalRequest(id string) ([]postgres.ApprovalRequest, error) {
+	args := m.Called(id)
+	return args.Get(0).([]postgres.ApprovalRequest), args.Error(1)
+}
+
+func (m *MockStore) GetPendingApprovalRequests() ([]postgres.ApprovalRequest, error) {
+	args := m.Called()
+	return args.Get(0).([]postgres.ApprovalRequest), args.Error(1)
+}
+
+func (m *MockStore) DecideApprovalRequest(id, status, decidedBy string) (int64, error) {
+	args := m.Called(id, status, decidedBy)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStore) UpdateApprovalRequestExecutionID(id, executionID string) error {
+	args := m.Called(id, executionID)
+	return args.Error(0)
+}
+
+func (m *MockStore) ReopenApprovalRequest(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
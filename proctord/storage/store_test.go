@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"testing"
+	"time"
 )
 
 func TestJobsExecutionAuditLog(t *testing.T) {
@@ -28,7 +29,8 @@ func TestJobsExecutionAuditLog(t *testing.T) {
 	assert.NoError(t, err)
 
 	mockPostgresClient.On("NamedExec",
-		"INSERT INTO jobs_execution_audit_log (job_name, user_email, image_name, job_name_submitted_for_execution, job_args, job_submission_status, job_execution_status) VALUES (:job_name, :user_email, :image_name, :job_name_submitted_for_execution, :job_args, :job_submission_status, :job_execution_status)", mock.Anything).Run(func(args mock.Arguments) {
+		"INSERT INTO jobs_execution_audit_log (job_name, user_email, image_name, job_name_submitted_for_execution, job_args, job_submission_status, job_execution_status, parent_execution_id, labels, reason, approved_by) "+
+			"VALUES (:job_name, :user_email, :image_name, :job_name_submitted_for_execution, :job_args, :job_submission_status, :job_execution_status, :parent_execution_id, coalesce(nullif(:labels, ''), '{}')::jsonb, nullif(:reason, ''), nullif(:approved_by, ''))", mock.Anything).Run(func(args mock.Arguments) {
 	}).Return(int64(1), nil).Once()
 
 	err = testStore.AuditJobsExecution(jobExecutionAuditLog)
@@ -51,7 +53,8 @@ func TestJobsExecutionAuditLogPostgresClientFailure(t *testing.T) {
 	assert.NoError(t, err)
 
 	mockPostgresClient.On("NamedExec",
-		"INSERT INTO jobs_execution_audit_log (job_name, user_email, image_name, job_name_submitted_for_execution, job_args, job_submission_status, job_execution_status) VALUES (:job_name, :user_email, :image_name, :job_name_submitted_for_execution, :job_args, :job_submission_status, :job_execution_status)",
+		"INSERT INTO jobs_execution_audit_log (job_name, user_email, image_name, job_name_submitted_for_execution, job_args, job_submission_status, job_execution_status, parent_execution_id, labels, reason, approved_by) "+
+			"VALUES (:job_name, :user_email, :image_name, :job_name_submitted_for_execution, :job_args, :job_submission_status, :job_execution_status, :parent_execution_id, coalesce(nullif(:labels, ''), '{}')::jsonb, nullif(:reason, ''), nullif(:approved_by, ''))",
 		mock.Anything).
 		Return(int64(0), errors.New("error")).
 		Once()
@@ -70,7 +73,7 @@ func TestUpdateJobsExecutionAuditLog(t *testing.T) {
 	jobExecutionStatus := "updated-status"
 
 	mockPostgresClient.On("NamedExec",
-		"UPDATE jobs_execution_audit_log SET job_execution_status = :job_execution_status, updated_at = :updated_at where job_name_submitted_for_execution = :job_name_submitted_for_execution",
+		"UPDATE jobs_execution_audit_log SET job_execution_status = :job_execution_status, updated_at = :updated_at where job_name_submitted_for_execution = :job_name_submitted_for_execution and job_execution_status <> :job_execution_status and job_execution_status not in ('SUCCEEDED', 'FAILED', 'CANCELLED', 'NOT_FOUND')",
 		mock.Anything).
 		Run(func(args mock.Arguments) {
 			data := args.Get(1).(*postgres.JobsExecutionAuditLog)
@@ -252,3 +255,688 @@ func TestRemoveScheduledJobByIDReturnErrorIfIDIsInvalid(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid input syntax")
 	assert.Equal(t, int64(0), removedJobsCount)
 }
+
+func TestGetAccessTokens(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+	userEmail := "mrproctor@example.com"
+
+	dest := []postgres.AccessToken{}
+
+	mockPostgresClient.On("Select",
+		&dest,
+		"SELECT id, user_email, token_hash, name, scopes, expires_at, last_used_at, created_at from access_tokens where user_email = $1",
+		userEmail).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			accessTokensResult := args.Get(0).(*[]postgres.AccessToken)
+			*accessTokensResult = append(*accessTokensResult, postgres.AccessToken{
+				UserEmail: userEmail,
+				TokenHash: "any-token-hash",
+			})
+		}).
+		Once()
+
+	accessTokens, err := testStore.GetAccessTokens(userEmail)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []postgres.AccessToken{{UserEmail: userEmail, TokenHash: "any-token-hash"}}, accessTokens)
+
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestGetJobsExecutionAuditLog(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+	jobExecutionID := "proctor-ipsum-lorem"
+
+	dest := []postgres.JobsExecutionAuditLog{}
+
+	mockPostgresClient.On("Select",
+		&dest,
+		"SELECT job_name, coalesce(user_email, '') as user_email, image_name, job_name_submitted_for_execution, job_args, job_submission_status, job_execution_status, coalesce(cancelled_by, '') as cancelled_by, parent_execution_id, labels::text as labels, "+
+			"coalesce(reason, '') as reason, coalesce(approved_by, '') as approved_by, created_at, updated_at from jobs_execution_audit_log where job_name_submitted_for_execution = $1",
+		jobExecutionID).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			jobsExecutionAuditLogResult := args.Get(0).(*[]postgres.JobsExecutionAuditLog)
+			*jobsExecutionAuditLogResult = append(*jobsExecutionAuditLogResult, postgres.JobsExecutionAuditLog{
+				JobName:     "any-job",
+				ExecutionID: postgres.StringToSQLString(jobExecutionID),
+			})
+		}).
+		Once()
+
+	jobsExecutionAuditLog, err := testStore.GetJobsExecutionAuditLog(jobExecutionID)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, len(jobsExecutionAuditLog))
+	assert.Equal(t, "any-job", jobsExecutionAuditLog[0].JobName)
+
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestGetUserGroups(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+	userEmail := "mrproctor@example.com"
+
+	dest := []postgres.UserGroup{}
+
+	mockPostgresClient.On("Select",
+		&dest,
+		"SELECT id, user_email, group_name from user_groups where user_email = $1",
+		userEmail).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			userGroupsResult := args.Get(0).(*[]postgres.UserGroup)
+			*userGroupsResult = append(*userGroupsResult, postgres.UserGroup{
+				UserEmail: userEmail,
+				GroupName: "group_one",
+			})
+		}).
+		Once()
+
+	userGroups, err := testStore.GetUserGroups(userEmail)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []postgres.UserGroup{{UserEmail: userEmail, GroupName: "group_one"}}, userGroups)
+
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestAuditAdminAction(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	adminAuditLog := &postgres.AdminAuditLog{
+		Actor:    "mrproctor@example.com",
+		ProcName: "sample-job",
+		Action:   "metadata_created",
+		Diff:     `{"image_name":{"from":null,"to":"any-image"}}`,
+	}
+
+	mockPostgresClient.On("NamedExec",
+		"INSERT INTO admin_audit_log (actor, proc_name, action, diff) VALUES (:actor, :proc_name, :action, :diff)",
+		mock.Anything).
+		Return(int64(1), nil).
+		Once()
+
+	err := testStore.AuditAdminAction(adminAuditLog)
+
+	assert.NoError(t, err)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestInsertAccessToken(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	accessToken := &postgres.AccessToken{
+		UserEmail: "mrproctor@example.com",
+		TokenHash: "any-token-hash",
+		Name:      "laptop",
+		Scopes:    "execute",
+	}
+
+	mockPostgresClient.On("NamedExec",
+		"INSERT INTO access_tokens (user_email, token_hash, name, scopes, expires_at) VALUES (:user_email, :token_hash, :name, :scopes, :expires_at)",
+		&accessToken).
+		Return(int64(1), nil).
+		Once()
+
+	err := testStore.InsertAccessToken(accessToken)
+
+	assert.NoError(t, err)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestRemoveAccessToken(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	mockPostgresClient.On("NamedExec",
+		"DELETE FROM access_tokens where id = :id and user_email = :user_email",
+		&postgres.AccessToken{ID: 7, UserEmail: "mrproctor@example.com"}).
+		Return(int64(1), nil).
+		Once()
+
+	removedAccessTokensCount, err := testStore.RemoveAccessToken("mrproctor@example.com", 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), removedAccessTokensCount)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestUpdateAccessTokenLastUsedAt(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	mockPostgresClient.On("NamedExec",
+		"UPDATE access_tokens set last_used_at = :last_used_at where id = :id",
+		mock.AnythingOfType("*postgres.AccessToken")).
+		Return(int64(1), nil).
+		Run(func(args mock.Arguments) {
+			accessToken := args.Get(1).(*postgres.AccessToken)
+			assert.Equal(t, int64(7), accessToken.ID)
+			assert.True(t, accessToken.LastUsedAt.Valid)
+		}).
+		Once()
+
+	err := testStore.UpdateAccessTokenLastUsedAt(7)
+
+	assert.NoError(t, err)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestCancelJobsExecution(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	mockPostgresClient.On("NamedExec",
+		"UPDATE jobs_execution_audit_log SET job_execution_status = :job_execution_status, cancelled_by = :cancelled_by, updated_at = :updated_at where job_name_submitted_for_execution = :job_name_submitted_for_execution",
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			data := args.Get(1).(*postgres.JobsExecutionAuditLog)
+
+			assert.Equal(t, postgres.StringToSQLString("any-submission"), data.ExecutionID)
+			assert.Equal(t, utility.JobCancelled, data.JobExecutionStatus)
+			assert.Equal(t, "mrproctor@example.com", data.CancelledBy)
+		}).
+		Return(int64(1), nil).
+		Once()
+
+	cancelledExecutionsCount, err := testStore.CancelJobsExecution("any-submission", "mrproctor@example.com")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), cancelledExecutionsCount)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestGetJobsExecutionAuditLogArgs(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	dest := []postgres.JobsExecutionAuditLog{}
+
+	mockPostgresClient.On("Select",
+		&dest,
+		"SELECT id, job_name, coalesce(job_args, '') as job_args from jobs_execution_audit_log where id > $1 order by id limit $2",
+		int64(10), 100).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			jobsExecutionAuditLogResult := args.Get(0).(*[]postgres.JobsExecutionAuditLog)
+			*jobsExecutionAuditLogResult = append(*jobsExecutionAuditLogResult, postgres.JobsExecutionAuditLog{
+				ID:      11,
+				JobName: "any-job",
+				JobArgs: "e30=",
+			})
+		}).
+		Once()
+
+	jobsExecutionAuditLog, err := testStore.GetJobsExecutionAuditLogArgs(10, 100)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, len(jobsExecutionAuditLog))
+	assert.Equal(t, int64(11), jobsExecutionAuditLog[0].ID)
+	assert.Equal(t, "e30=", jobsExecutionAuditLog[0].JobArgs)
+
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestGetUnfinishedJobsExecutionAuditLogs(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	dest := []postgres.JobsExecutionAuditLog{}
+
+	mockPostgresClient.On("Select",
+		&dest,
+		"SELECT job_name_submitted_for_execution, job_execution_status from jobs_execution_audit_log "+
+			"where job_submission_status = $1 and job_execution_status not in ('SUCCEEDED', 'FAILED', 'CANCELLED', 'NOT_FOUND')",
+		utility.JobSubmissionSuccess).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			jobsExecutionAuditLogResult := args.Get(0).(*[]postgres.JobsExecutionAuditLog)
+			*jobsExecutionAuditLogResult = append(*jobsExecutionAuditLogResult, postgres.JobsExecutionAuditLog{ExecutionID: postgres.StringToSQLString("proctor-job-1"), JobExecutionStatus: utility.JobWaiting})
+		}).
+		Once()
+
+	jobsExecutionAuditLogs, err := testStore.GetUnfinishedJobsExecutionAuditLogs()
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, len(jobsExecutionAuditLogs))
+	assert.Equal(t, "proctor-job-1", jobsExecutionAuditLogs[0].ExecutionID.String)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestUpdateJobsExecutionAuditLogArgs(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	mockPostgresClient.On("NamedExec",
+		"UPDATE jobs_execution_audit_log SET job_args = :job_args where id = :id",
+		mock.Anything).
+		Run(func(args mock.Arguments) {
+			data := args.Get(1).(*postgres.JobsExecutionAuditLog)
+
+			assert.Equal(t, int64(11), data.ID)
+			assert.Equal(t, "e30=", data.JobArgs)
+		}).
+		Return(int64(1), nil).
+		Once()
+
+	err := testStore.UpdateJobsExecutionAuditLogArgs(11, "e30=")
+
+	assert.NoError(t, err)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestGetJobsExecutionAuditLogs(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	createdAfter := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)
+	filter := JobsExecutionAuditLogFilter{
+		JobName:         "any-job",
+		ExecutionStatus: utility.JobFailed,
+		Labels:          map[string]string{"ticket": "OPS-123"},
+		Reason:          "payments",
+		CreatedAfter:    &createdAfter,
+		BeforeID:        42,
+		Limit:           10,
+	}
+
+	dest := []postgres.JobsExecutionAuditLog{}
+
+	mockPostgresClient.On("Select",
+		&dest,
+		"SELECT id, job_name, coalesce(user_email, '') as user_email, coalesce(image_name, '') as image_name, job_name_submitted_for_execution, coalesce(job_args, '') as job_args, "+
+			"coalesce(job_submission_status, '') as job_submission_status, coalesce(job_execution_status, '') as job_execution_status, coalesce(cancelled_by, '') as cancelled_by, parent_execution_id, "+
+			"labels::text as labels, coalesce(reason, '') as reason, coalesce(approved_by, '') as approved_by, created_at, updated_at from jobs_execution_audit_log "+
+			"where job_name = $1 and job_execution_status = $2 and labels @> $3::jsonb and reason ilike $4 and created_at >= $5 and id < $6 order by id desc limit $7",
+		"any-job", utility.JobFailed, "{\"ticket\":\"OPS-123\"}", "%payments%", createdAfter, int64(42), 10).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			jobsExecutionAuditLogResult := args.Get(0).(*[]postgres.JobsExecutionAuditLog)
+			*jobsExecutionAuditLogResult = append(*jobsExecutionAuditLogResult, postgres.JobsExecutionAuditLog{ID: 41, JobName: "any-job"})
+		}).
+		Once()
+
+	jobsExecutionAuditLogs, err := testStore.GetJobsExecutionAuditLogs(filter)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, len(jobsExecutionAuditLogs))
+	assert.Equal(t, int64(41), jobsExecutionAuditLogs[0].ID)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestGetJobsExecutionAuditLogsWithoutFilters(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	dest := []postgres.JobsExecutionAuditLog{}
+
+	mockPostgresClient.On("Select",
+		&dest,
+		"SELECT id, job_name, coalesce(user_email, '') as user_email, coalesce(image_name, '') as image_name, job_name_submitted_for_execution, coalesce(job_args, '') as job_args, "+
+			"coalesce(job_submission_status, '') as job_submission_status, coalesce(job_execution_status, '') as job_execution_status, coalesce(cancelled_by, '') as cancelled_by, parent_execution_id, "+
+			"labels::text as labels, coalesce(reason, '') as reason, coalesce(approved_by, '') as approved_by, created_at, updated_at from jobs_execution_audit_log "+
+			"order by id desc limit $1",
+		50).
+		Return(nil).
+		Once()
+
+	_, err := testStore.GetJobsExecutionAuditLogs(JobsExecutionAuditLogFilter{Limit: 50})
+	assert.NoError(t, err)
+
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestInsertStatusCallback(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	statusCallback := &postgres.StatusCallback{
+		ExecutionID: "any-execution-id",
+		URL:         "http://example.com/callback",
+		Secret:      "any-secret",
+	}
+
+	mockPostgresClient.On("NamedExec",
+		"INSERT INTO status_callbacks (execution_id, url, secret) VALUES (:execution_id, :url, :secret)",
+		&statusCallback).
+		Return(int64(1), nil).
+		Once()
+
+	err := testStore.InsertStatusCallback(statusCallback)
+
+	assert.NoError(t, err)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestClaimDueStatusCallbacks(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	leaseUntil := time.Now().Add(time.Minute)
+	dest := []postgres.StatusCallback{}
+
+	mockPostgresClient.On("Select",
+		&dest,
+		"UPDATE status_callbacks c set next_attempt_at = $1, updated_at = now() from jobs_execution_audit_log j "+
+			"where j.job_name_submitted_for_execution = c.execution_id and c.id in ("+
+			"SELECT sc.id from status_callbacks sc join jobs_execution_audit_log jl on jl.job_name_submitted_for_execution = sc.execution_id "+
+			"where sc.status = $2 and sc.next_attempt_at <= now() and jl.job_execution_status in ('SUCCEEDED', 'FAILED', 'CANCELLED', 'NOT_FOUND') "+
+			"order by sc.next_attempt_at limit $3 for update of sc skip locked) "+
+			"RETURNING c.id, c.execution_id, c.url, coalesce(c.secret, '') as secret, c.status, c.attempts, c.next_attempt_at, j.job_execution_status, c.created_at, c.updated_at",
+		leaseUntil, utility.StatusCallbackPending, 10).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			statusCallbacksResult := args.Get(0).(*[]postgres.StatusCallback)
+			*statusCallbacksResult = append(*statusCallbacksResult, postgres.StatusCallback{ID: 3, ExecutionStatus: utility.JobSucceeded})
+		}).
+		Once()
+
+	statusCallbacks, err := testStore.ClaimDueStatusCallbacks(leaseUntil, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []postgres.StatusCallback{{ID: 3, ExecutionStatus: utility.JobSucceeded}}, statusCallbacks)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestUpdateStatusCallback(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	statusCallback := &postgres.StatusCallback{ID: 3, Status: utility.StatusCallbackDelivered, Attempts: 1}
+
+	mockPostgresClient.On("NamedExec",
+		"UPDATE status_callbacks set status = :status, attempts = :attempts, next_attempt_at = :next_attempt_at, updated_at = :updated_at where id = :id",
+		&statusCallback).
+		Return(int64(1), nil).
+		Once()
+
+	err := testStore.UpdateStatusCallback(statusCallback)
+
+	assert.NoError(t, err)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestInsertStatusCallbackAttempt(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	statusCallbackAttempt := &postgres.StatusCallbackAttempt{CallbackID: 3, Error: "connection refused"}
+
+	mockPostgresClient.On("NamedExec",
+		"INSERT INTO status_callback_attempts (callback_id, response_code, error) VALUES (:callback_id, :response_code, :error)",
+		&statusCallbackAttempt).
+		Return(int64(1), nil).
+		Once()
+
+	err := testStore.InsertStatusCallbackAttempt(statusCallbackAttempt)
+
+	assert.NoError(t, err)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestGetStatusCallbacks(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	dest := []postgres.StatusCallback{}
+
+	mockPostgresClient.On("Select",
+		&dest,
+		"SELECT id, execution_id, url, status, attempts, next_attempt_at, created_at, updated_at from status_callbacks where execution_id = $1 order by id",
+		"any-execution-id").
+		Return(nil).
+		Once()
+
+	_, err := testStore.GetStatusCallbacks("any-execution-id")
+
+	assert.NoError(t, err)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestGetStatusCallbackAttempts(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	dest := []postgres.StatusCallbackAttempt{}
+
+	mockPostgresClient.On("Select",
+		&dest,
+		"SELECT a.id, a.callback_id, a.response_code, coalesce(a.error, '') as error, a.created_at from status_callback_attempts a "+
+			"join status_callbacks c on c.id = a.callback_id where c.execution_id = $1 order by a.id",
+		"any-execution-id").
+		Return(nil).
+		Once()
+
+	_, err := testStore.GetStatusCallbackAttempts("any-execution-id")
+
+	assert.NoError(t, err)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestClaimIdempotencyKey(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	idempotencyKey := &postgres.IdempotencyKey{UserEmail: "mrproctor@example.com", Key: "any-key", JobName: "any-job", ExpiresAt: time.Now().Add(time.Hour)}
+
+	mockPostgresClient.On("NamedExec",
+		"INSERT INTO idempotency_keys (user_email, key, job_name, expires_at) VALUES (:user_email, :key, :job_name, :expires_at) "+
+			"ON CONFLICT (user_email, key) DO UPDATE SET job_name = excluded.job_name, execution_id = NULL, approval_id = NULL, expires_at = excluded.expires_at, created_at = now() "+
+			"where idempotency_keys.expires_at < now()",
+		&idempotencyKey).
+		Return(int64(1), nil).
+		Once()
+
+	claimed, err := testStore.ClaimIdempotencyKey(idempotencyKey)
+
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestClaimIdempotencyKeyHeldAlready(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	mockPostgresClient.On("NamedExec", mock.Anything, mock.Anything).Return(int64(0), nil).Once()
+
+	claimed, err := testStore.ClaimIdempotencyKey(&postgres.IdempotencyKey{UserEmail: "mrproctor@example.com", Key: "any-key"})
+
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestGetIdempotencyKey(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	dest := []postgres.IdempotencyKey{}
+
+	mockPostgresClient.On("Select",
+		&dest,
+		"SELECT id, user_email, key, job_name, execution_id, approval_id, expires_at, created_at from idempotency_keys where user_email = $1 and key = $2",
+		"mrproctor@example.com", "any-key").
+		Return(nil).
+		Once()
+
+	_, err := testStore.GetIdempotencyKey("mrproctor@example.com", "any-key")
+
+	assert.NoError(t, err)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestUpdateIdempotencyKeyExecutionID(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	mockPostgresClient.On("NamedExec",
+		"UPDATE idempotency_keys set execution_id = :execution_id where user_email = :user_email and key = :key",
+		&postgres.IdempotencyKey{UserEmail: "mrproctor@example.com", Key: "any-key", ExecutionID: postgres.StringToSQLString("proctor-job-1")}).
+		Return(int64(1), nil).
+		Once()
+
+	err := testStore.UpdateIdempotencyKeyExecutionID("mrproctor@example.com", "any-key", "proctor-job-1")
+
+	assert.NoError(t, err)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestUpdateIdempotencyKeyApprovalID(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	mockPostgresClient.On("NamedExec",
+		"UPDATE idempotency_keys set approval_id = :approval_id where user_email = :user_email and key = :key",
+		&postgres.IdempotencyKey{UserEmail: "mrproctor@example.com", Key: "any-key", ApprovalID: postgres.StringToSQLString("any-approval-id")}).
+		Return(int64(1), nil).
+		Once()
+
+	err := testStore.UpdateIdempotencyKeyApprovalID("mrproctor@example.com", "any-key", "any-approval-id")
+
+	assert.NoError(t, err)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestRemoveIdempotencyKey(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	mockPostgresClient.On("NamedExec",
+		"DELETE FROM idempotency_keys where user_email = :user_email and key = :key",
+		&postgres.IdempotencyKey{UserEmail: "mrproctor@example.com", Key: "any-key"}).
+		Return(int64(1), nil).
+		Once()
+
+	err := testStore.RemoveIdempotencyKey("mrproctor@example.com", "any-key")
+
+	assert.NoError(t, err)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestInsertApprovalRequest(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	approvalRequest := &postgres.ApprovalRequest{JobName: "any-job", UserEmail: "mrproctor@example.com", Job: `{"name":"any-job"}`, ExpiresAt: time.Now().Add(time.Hour)}
+
+	mockPostgresClient.On("NamedExec",
+		"INSERT INTO approval_requests (id, job_name, user_email, job, callback_secret, parent_execution_id, status, expires_at) "+
+			"VALUES (:id, :job_name, :user_email, :job, nullif(:callback_secret, ''), :parent_execution_id, :status, :expires_at)",
+		&approvalRequest).
+		Return(int64(1), nil).
+		Once()
+
+	id, err := testStore.InsertApprovalRequest(approvalRequest)
+
+	assert.NoError(t, err)
+	assert.Equal(t, approvalRequest.ID, id)
+	_, err = uuid.FromString(id)
+	assert.NoError(t, err)
+	assert.Equal(t, utility.ApprovalPending, approvalRequest.Status)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestGetApprovalRequest(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	dest := []postgres.ApprovalRequest{}
+
+	mockPostgresClient.On("Select",
+		&dest,
+		"SELECT id, job_name, user_email, job, coalesce(callback_secret, '') as callback_secret, parent_execution_id, "+
+			"case when status = 'PENDING' and expires_at <= now() then 'EXPIRED' else status end as status, coalesce(decided_by, '') as decided_by, execution_id, expires_at, created_at, updated_at "+
+			"from approval_requests where id = $1",
+		"any-id").
+		Return(nil).
+		Once()
+
+	_, err := testStore.GetApprovalRequest("any-id")
+
+	assert.NoError(t, err)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestGetPendingApprovalRequests(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	dest := []postgres.ApprovalRequest{}
+
+	mockPostgresClient.On("Select",
+		&dest,
+		"SELECT id, job_name, user_email, job, coalesce(callback_secret, '') as callback_secret, parent_execution_id, "+
+			"case when status = 'PENDING' and expires_at <= now() then 'EXPIRED' else status end as status, coalesce(decided_by, '') as decided_by, execution_id, expires_at, created_at, updated_at "+
+			"from approval_requests where status = $1 and expires_at > now() order by created_at",
+		utility.ApprovalPending).
+		Return(nil).
+		Once()
+
+	_, err := testStore.GetPendingApprovalRequests()
+
+	assert.NoError(t, err)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestDecideApprovalRequest(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	mockPostgresClient.On("NamedExec",
+		"UPDATE approval_requests set status = :status, decided_by = :decided_by, updated_at = :updated_at where id = :id and status = 'PENDING' and expires_at > now()",
+		mock.MatchedBy(func(approvalRequest *postgres.ApprovalRequest) bool {
+			return approvalRequest.ID == "any-id" && approvalRequest.Status == utility.ApprovalApproved && approvalRequest.DecidedBy == "approver@example.com"
+		})).
+		Return(int64(1), nil).
+		Once()
+
+	rowsAffected, err := testStore.DecideApprovalRequest("any-id", utility.ApprovalApproved, "approver@example.com")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestUpdateApprovalRequestExecutionID(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	mockPostgresClient.On("NamedExec",
+		"UPDATE approval_requests set execution_id = :execution_id, updated_at = :updated_at where id = :id",
+		mock.MatchedBy(func(approvalRequest *postgres.ApprovalRequest) bool {
+			return approvalRequest.ID == "any-id" && approvalRequest.ExecutionID.String == "proctor-job-1"
+		})).
+		Return(int64(1), nil).
+		Once()
+
+	err := testStore.UpdateApprovalRequestExecutionID("any-id", "proctor-job-1")
+
+	assert.NoError(t, err)
+	mockPostgresClient.AssertExpectations(t)
+}
+
+func TestReopenApprovalRequest(t *testing.T) {
+	mockPostgresClient := &postgres.ClientMock{}
+	testStore := New(mockPostgresClient)
+
+	mockPostgresClient.On("NamedExec",
+		"UPDATE approval_requests set status = 'PENDING', decided_by = NULL, updated_at = :updated_at where id = :id and status = 'APPROVED' and execution_id is NULL",
+		mock.MatchedBy(func(approvalRequest *postgres.ApprovalRequest) bool {
+			return approvalRequest.ID == "any-id"
+		})).
+		Return(int64(1), nil).
+		Once()
+
+	err := testStore.ReopenApprovalRequest("any-id")
+
+	assert.NoError(t, err)
+	mockPostgresClient.AssertExpectations(t)
+}
@@ -0,0 +1,232 @@
+package tokens
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"proctor/proctord/auth"
+	"proctor/proctord/config"
+	"proctor/proctord/logger"
+	"proctor/proctord/storage"
+	"proctor/proctord/storage/postgres"
+	"proctor/proctord/utility"
+
+	"github.com/getsentry/raven-go"
+	"github.com/gorilla/mux"
+	"github.com/lib/pq"
+)
+
+type handler struct {
+	store storage.Store
+}
+
+type Handler interface {
+	Create() http.HandlerFunc
+	List() http.HandlerFunc
+	Revoke() http.HandlerFunc
+}
+
+func NewHandler(store storage.Store) Handler {
+	return &handler{
+		store: store,
+	}
+}
+
+func (handler *handler) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		user, _ := auth.FromContext(req.Context())
+
+		var newAccessToken NewAccessToken
+		err := json.NewDecoder(req.Body).Decode(&newAccessToken)
+		defer req.Body.Close()
+		if err != nil {
+			logger.Error("Error parsing request body", err.Error())
+
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(utility.ClientError))
+			return
+		}
+
+		for _, scope := range newAccessToken.Scopes {
+			if !isKnownScope(scope) {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(fmt.Sprintf("%s: %s", utility.UnknownScopeClientError, scope)))
+				return
+			}
+		}
+
+		// a scoped access token can only issue access tokens with a subset of its own scopes
+		if len(user.Scopes) > 0 && !isSubset(newAccessToken.Scopes, user.Scopes) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(utility.InsufficientScopeClientError))
+			return
+		}
+
+		expiresInDays := newAccessToken.ExpiresInDays
+		if expiresInDays == 0 {
+			expiresInDays = config.AccessTokenTTLDays()
+		}
+		if expiresInDays < 0 || expiresInDays > config.AccessTokenTTLDays() {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf("%s: %d days", utility.AccessTokenExpiryClientError, config.AccessTokenTTLDays())))
+			return
+		}
+
+		token, err := auth.GenerateAccessToken()
+		if err != nil {
+			logger.Error("Error generating access token", err.Error())
+			raven.CaptureError(err, nil)
+
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+
+		expiresAt := time.Now().Add(time.Duration(expiresInDays) * 24 * time.Hour)
+		err = handler.store.InsertAccessToken(&postgres.AccessToken{
+			UserEmail: user.Email,
+			TokenHash: auth.HashAccessToken(token),
+			Name:      newAccessToken.Name,
+			Scopes:    strings.Join(newAccessToken.Scopes, ","),
+			ExpiresAt: pq.NullTime{Time: expiresAt, Valid: true},
+		})
+		if err != nil {
+			logger.Error("Error inserting access token", err.Error())
+			raven.CaptureError(err, nil)
+
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+
+		accessToken := AccessToken{
+			Name:      newAccessToken.Name,
+			Token:     token,
+			Scopes:    newAccessToken.Scopes,
+			ExpiresAt: &expiresAt,
+		}
+		if accessToken.Scopes == nil {
+			accessToken.Scopes = []string{}
+		}
+		accessTokenInJSON, err := json.Marshal(accessToken)
+		if err != nil {
+			logger.Error("Error marshalling access token in json", err.Error())
+			raven.CaptureError(err, nil)
+
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write(accessTokenInJSON)
+	}
+}
+
+func (handler *handler) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		user, _ := auth.FromContext(req.Context())
+
+		storedAccessTokens, err := handler.store.GetAccessTokens(user.Email)
+		if err != nil {
+			logger.Error("Error fetching access tokens", err.Error())
+			raven.CaptureError(err, nil)
+
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+
+		accessTokens := []AccessToken{}
+		for _, storedAccessToken := range storedAccessTokens {
+			createdAt := storedAccessToken.CreatedAt
+			accessToken := AccessToken{
+				ID:        storedAccessToken.ID,
+				Name:      storedAccessToken.Name,
+				Scopes:    auth.ParseScopes(storedAccessToken.Scopes),
+				CreatedAt: &createdAt,
+			}
+			if storedAccessToken.ExpiresAt.Valid {
+				accessToken.ExpiresAt = &storedAccessToken.ExpiresAt.Time
+			}
+			if storedAccessToken.LastUsedAt.Valid {
+				accessToken.LastUsedAt = &storedAccessToken.LastUsedAt.Time
+			}
+			accessTokens = append(accessTokens, accessToken)
+		}
+
+		accessTokensInJSON, err := json.Marshal(accessTokens)
+		if err != nil {
+			logger.Error("Error marshalling access tokens in json", err.Error())
+			raven.CaptureError(err, nil)
+
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+
+		w.Write(accessTokensInJSON)
+	}
+}
+
+func (handler *handler) Revoke() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		user, _ := auth.FromContext(req.Context())
+
+		accessTokenID, err := strconv.ParseInt(mux.Vars(req)["id"], 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("Invalid access token ID"))
+			return
+		}
+
+		removedAccessTokensCount, err := handler.store.RemoveAccessToken(user.Email, accessTokenID)
+		if err != nil {
+			logger.Error("Error revoking access token", err.Error())
+			raven.CaptureError(err, nil)
+
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(utility.ServerError))
+			return
+		}
+
+		if removedAccessTokensCount == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(utility.AccessTokenNotFoundError))
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fmt.Sprintf("Successfully revoked access token ID: %d", accessTokenID)))
+	}
+}
+
+func isKnownScope(scope string) bool {
+	for _, knownScope := range auth.Scopes {
+		if scope == knownScope {
+			return true
+		}
+	}
+	return false
+}
+
+func isSubset(scopes, allowedScopes []string) bool {
+	if len(scopes) == 0 {
+		return false
+	}
+
+	allowed := make(map[string]bool)
+	for _, allowedScope := range allowedScopes {
+		allowed[allowedScope] = true
+	}
+	for _, scope := range scopes {
+		if !allowed[scope] {
+			return false
+		}
+	}
+	return true
+}
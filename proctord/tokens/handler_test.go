@@ -0,0 +1,249 @@
+package tokens
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"proctor/proctord/auth"
+	"proctor/proctord/storage"
+	"proctor/proctord/storage/postgres"
+	"proctor/proctord/utility"
+
+	"github.com/gorilla/mux"
+	"github.com/lib/pq"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TokensHandlerTestSuite struct {
+	suite.Suite
+	mockStore         *storage.MockStore
+	testTokensHandler Handler
+	user              auth.User
+}
+
+func (suite *TokensHandlerTestSuite) SetupTest() {
+	suite.mockStore = &storage.MockStore{}
+	suite.testTokensHandler = NewHandler(suite.mockStore)
+	suite.user = auth.User{Email: "mrproctor@example.com"}
+	viper.Set("ACCESS_TOKEN_TTL_DAYS", 30)
+}
+
+func (suite *TokensHandlerTestSuite) newRequest(method, url string, body interface{}, user auth.User) *http.Request {
+	requestBody, err := json.Marshal(body)
+	assert.NoError(suite.T(), err)
+
+	req := httptest.NewRequest(method, url, bytes.NewReader(requestBody))
+	return req.WithContext(auth.NewContext(req.Context(), user))
+}
+
+func (suite *TokensHandlerTestSuite) TestCreate() {
+	t := suite.T()
+
+	req := suite.newRequest("POST", "/tokens", NewAccessToken{Name: "laptop", Scopes: []string{auth.ExecuteScope}, ExpiresInDays: 7}, suite.user)
+	responseRecorder := httptest.NewRecorder()
+
+	var insertedAccessToken *postgres.AccessToken
+	suite.mockStore.On("InsertAccessToken", mock.AnythingOfType("*postgres.AccessToken")).Return(nil).Run(func(args mock.Arguments) {
+		insertedAccessToken = args.Get(0).(*postgres.AccessToken)
+	}).Once()
+
+	suite.testTokensHandler.Create()(responseRecorder, req)
+
+	suite.mockStore.AssertExpectations(t)
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+	var accessToken AccessToken
+	err := json.NewDecoder(responseRecorder.Body).Decode(&accessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "laptop", accessToken.Name)
+	assert.Equal(t, []string{auth.ExecuteScope}, accessToken.Scopes)
+	assert.NotEmpty(t, accessToken.Token)
+	assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), *accessToken.ExpiresAt, time.Minute)
+
+	assert.Equal(t, suite.user.Email, insertedAccessToken.UserEmail)
+	assert.Equal(t, auth.HashAccessToken(accessToken.Token), insertedAccessToken.TokenHash)
+	assert.Equal(t, auth.ExecuteScope, insertedAccessToken.Scopes)
+	assert.True(t, insertedAccessToken.ExpiresAt.Valid)
+}
+
+func (suite *TokensHandlerTestSuite) TestCreateWithDefaultExpiry() {
+	t := suite.T()
+
+	req := suite.newRequest("POST", "/tokens", NewAccessToken{Name: "laptop"}, suite.user)
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("InsertAccessToken", mock.AnythingOfType("*postgres.AccessToken")).Return(nil).Once()
+
+	suite.testTokensHandler.Create()(responseRecorder, req)
+
+	assert.Equal(t, http.StatusCreated, responseRecorder.Code)
+
+	var accessToken AccessToken
+	err := json.NewDecoder(responseRecorder.Body).Decode(&accessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{}, accessToken.Scopes)
+	assert.WithinDuration(t, time.Now().Add(30*24*time.Hour), *accessToken.ExpiresAt, time.Minute)
+}
+
+func (suite *TokensHandlerTestSuite) TestCreateForExpiryBeyondMaximum() {
+	t := suite.T()
+
+	req := suite.newRequest("POST", "/tokens", NewAccessToken{Name: "laptop", ExpiresInDays: 31}, suite.user)
+	responseRecorder := httptest.NewRecorder()
+
+	suite.testTokensHandler.Create()(responseRecorder, req)
+
+	suite.mockStore.AssertNotCalled(t, "InsertAccessToken", mock.Anything)
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	assert.Equal(t, utility.AccessTokenExpiryClientError+": 30 days", responseRecorder.Body.String())
+}
+
+func (suite *TokensHandlerTestSuite) TestCreateForUnknownScope() {
+	t := suite.T()
+
+	req := suite.newRequest("POST", "/tokens", NewAccessToken{Name: "laptop", Scopes: []string{"everything"}}, suite.user)
+	responseRecorder := httptest.NewRecorder()
+
+	suite.testTokensHandler.Create()(responseRecorder, req)
+
+	suite.mockStore.AssertNotCalled(t, "InsertAccessToken", mock.Anything)
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	assert.Equal(t, utility.UnknownScopeClientError+": everything", responseRecorder.Body.String())
+}
+
+func (suite *TokensHandlerTestSuite) TestCreateFromScopedAccessToken() {
+	t := suite.T()
+
+	scopedUser := auth.User{Email: suite.user.Email, Scopes: []string{auth.ExecuteScope}}
+	for _, scopes := range [][]string{nil, {auth.ExecuteScope, auth.ScheduleScope}} {
+		req := suite.newRequest("POST", "/tokens", NewAccessToken{Name: "laptop", Scopes: scopes}, scopedUser)
+		responseRecorder := httptest.NewRecorder()
+
+		suite.testTokensHandler.Create()(responseRecorder, req)
+
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+		assert.Equal(t, utility.InsufficientScopeClientError, responseRecorder.Body.String())
+	}
+	suite.mockStore.AssertNotCalled(t, "InsertAccessToken", mock.Anything)
+}
+
+func (suite *TokensHandlerTestSuite) TestCreateForMalformedRequest() {
+	t := suite.T()
+
+	req := suite.newRequest("POST", "/tokens", "malformed", suite.user)
+	responseRecorder := httptest.NewRecorder()
+
+	suite.testTokensHandler.Create()(responseRecorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	assert.Equal(t, utility.ClientError, responseRecorder.Body.String())
+}
+
+func (suite *TokensHandlerTestSuite) TestCreateForStoreFailure() {
+	t := suite.T()
+
+	req := suite.newRequest("POST", "/tokens", NewAccessToken{Name: "laptop"}, suite.user)
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("InsertAccessToken", mock.AnythingOfType("*postgres.AccessToken")).Return(errors.New("error")).Once()
+
+	suite.testTokensHandler.Create()(responseRecorder, req)
+
+	assert.Equal(t, http.StatusInternalServerError, responseRecorder.Code)
+	assert.Equal(t, utility.ServerError, responseRecorder.Body.String())
+}
+
+func (suite *TokensHandlerTestSuite) TestList() {
+	t := suite.T()
+
+	req := suite.newRequest("GET", "/tokens", nil, suite.user)
+	responseRecorder := httptest.NewRecorder()
+
+	createdAt := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := createdAt.Add(24 * time.Hour)
+	suite.mockStore.On("GetAccessTokens", suite.user.Email).Return([]postgres.AccessToken{
+		{ID: 1, Name: "laptop", TokenHash: "any-token-hash", Scopes: "execute,schedule", CreatedAt: createdAt, ExpiresAt: pq.NullTime{Time: expiresAt, Valid: true}},
+	}, nil).Once()
+
+	suite.testTokensHandler.List()(responseRecorder, req)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.NotContains(t, responseRecorder.Body.String(), "any-token-hash")
+
+	var accessTokens []AccessToken
+	err := json.NewDecoder(responseRecorder.Body).Decode(&accessTokens)
+	assert.NoError(t, err)
+	assert.Equal(t, []AccessToken{
+		{ID: 1, Name: "laptop", Scopes: []string{auth.ExecuteScope, auth.ScheduleScope}, CreatedAt: &createdAt, ExpiresAt: &expiresAt},
+	}, accessTokens)
+}
+
+func (suite *TokensHandlerTestSuite) TestListForStoreFailure() {
+	t := suite.T()
+
+	req := suite.newRequest("GET", "/tokens", nil, suite.user)
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("GetAccessTokens", suite.user.Email).Return([]postgres.AccessToken{}, errors.New("error")).Once()
+
+	suite.testTokensHandler.List()(responseRecorder, req)
+
+	assert.Equal(t, http.StatusInternalServerError, responseRecorder.Code)
+	assert.Equal(t, utility.ServerError, responseRecorder.Body.String())
+}
+
+func (suite *TokensHandlerTestSuite) TestRevoke() {
+	t := suite.T()
+
+	req := suite.newRequest("DELETE", "/tokens/7", nil, suite.user)
+	req = mux.SetURLVars(req, map[string]string{"id": "7"})
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("RemoveAccessToken", suite.user.Email, int64(7)).Return(int64(1), nil).Once()
+
+	suite.testTokensHandler.Revoke()(responseRecorder, req)
+
+	suite.mockStore.AssertExpectations(t)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "Successfully revoked access token ID: 7", responseRecorder.Body.String())
+}
+
+func (suite *TokensHandlerTestSuite) TestRevokeForUnknownAccessToken() {
+	t := suite.T()
+
+	req := suite.newRequest("DELETE", "/tokens/7", nil, suite.user)
+	req = mux.SetURLVars(req, map[string]string{"id": "7"})
+	responseRecorder := httptest.NewRecorder()
+
+	suite.mockStore.On("RemoveAccessToken", suite.user.Email, int64(7)).Return(int64(0), nil).Once()
+
+	suite.testTokensHandler.Revoke()(responseRecorder, req)
+
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	assert.Equal(t, utility.AccessTokenNotFoundError, responseRecorder.Body.String())
+}
+
+func (suite *TokensHandlerTestSuite) TestRevokeForInvalidID() {
+	t := suite.T()
+
+	req := suite.newRequest("DELETE", "/tokens/abc", nil, suite.user)
+	req = mux.SetURLVars(req, map[string]string{"id": "abc"})
+	responseRecorder := httptest.NewRecorder()
+
+	suite.testTokensHandler.Revoke()(responseRecorder, req)
+
+	suite.mockStore.AssertNotCalled(t, "RemoveAccessToken", mock.Anything, mock.Anything)
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+}
+
+func TestTokensHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(TokensHandlerTestSuite))
+}
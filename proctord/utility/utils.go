@@ -13,9 +13,29 @@ const NonExistentProcClientError = "proc name non existent"
 const InvalidCronExpressionClientError = "Cron expression invalid"
 const InvalidEmailIdClientError = "Provided invalid Email ID"
 const InvalidTagError = "Tag(s) are missing"
+const InvalidMetadataClientError = "invalid proc metadata"
+const InvalidExecutionLimitsClientError = "invalid execution limits"
+const InvalidArgsClientError = "invalid proc args"
+const InvalidProvenanceClientError = "invalid execution labels or reason"
+const InvalidHistoryFilterClientError = "invalid executions filter"
+const InvalidCallbackURLClientError = "invalid callback url"
+const IdempotencyKeyReusedClientError = "idempotency key was used to execute a different proc"
+const IdempotencyKeyInProgressClientError = "a request with the same idempotency key is in progress"
 const DuplicateJobNameArgsClientError = "provided duplicate combination of job name and args for scheduling"
 const ServerError = "Something went wrong"
 const NoScheduledJobsError = "No scheduled jobs found"
+const UnauthenticatedClientError = "invalid or missing Email-Id and Access-Token"
+const UnauthorizedProcClientError = "user is not a member of any authorized group of the proc"
+const InsufficientRoleClientError = "user does not have the role required for this action"
+const InsufficientScopeClientError = "access token does not have the scope required for this action"
+const UnknownScopeClientError = "unknown access token scope"
+const AccessTokenExpiryClientError = "access token expiry exceeds the maximum allowed"
+const AccessTokenNotFoundError = "Access token not found"
+const ApprovalRequestNotFoundError = "Approval request not found"
+const ApprovalRequestDecidedClientError = "approval request is no longer pending"
+const SelfApprovalClientError = "an execution can't be approved or rejected by the user who requested it"
+const UnauthorizedApproverClientError = "user is not a member of any approver group of the proc"
+const ApprovalRequiredScheduleClientError = "procs requiring approval can't be scheduled"
 
 const UnauthorizedErrorMissingConfig = "EMAIL_ID or ACCESS_TOKEN is not present in proctor config file."
 const UnauthorizedErrorInvalidConfig = "Please check the EMAIL_ID and ACCESS_TOKEN validity in proctor config file."
@@ -36,10 +56,14 @@ const ClientOutdatedErrorMessage = "Your Proctor client is using an outdated ver
 const JobSubmissionSuccess = "success"
 const JobSubmissionClientError = "client_error"
 const JobSubmissionServerError = "server_error"
+const JobSubmissionForbidden = "forbidden"
 const JobNotFoundError = "Job not found"
+const JobAlreadyFinishedClientError = "execution has already finished"
 const JobSucceeded = "SUCCEEDED"
 const JobFailed = "FAILED"
 const JobWaiting = "WAITING"
+const JobRunning = "RUNNING"
+const JobCancelled = "CANCELLED"
 const JobNotFound="NOT_FOUND"
 const JobExecutionStatusFetchError = "JOB_EXECUTION_STATUS_FETCH_ERROR"
 const NoDefinitiveJobExecutionStatusFound = "NO_DEFINITIVE_JOB_EXECUTION_STATUS_FOUND"
@@ -55,9 +79,25 @@ const JobSchedulingStatusContextKey = "job_scheduling_status"
 const UserEmailHeaderKey = "Email-Id"
 const AccessTokenHeaderKey = "Access-Token"
 const ClientVersionHeaderKey = "Client-Version"
+const CallbackSecretHeaderKey = "Callback-Secret"
+const IdempotencyKeyHeaderKey = "Idempotency-Key"
+const IdempotentReplayedHeaderKey = "Idempotent-Replayed"
+const CallbackSignatureHeaderKey = "Proctor-Signature"
+const CallbackDeliveryHeaderKey = "Proctor-Delivery"
+
+const StatusCallbackPending = "PENDING"
+const StatusCallbackDelivered = "DELIVERED"
+const StatusCallbackFailed = "FAILED"
+
+const ApprovalPending = "PENDING"
+const ApprovalApproved = "APPROVED"
+const ApprovalRejected = "REJECTED"
+const ApprovalExpired = "EXPIRED"
 
 const WorkerEmail = "worker@proctor"
 
+const RedactedSecretValue = "[REDACTED]"
+
 func MergeMaps(mapOne, mapTwo map[string]string) map[string]string {
 	result := make(map[string]string)
 